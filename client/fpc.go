@@ -0,0 +1,23 @@
+package client
+
+import (
+	"net/http"
+
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+const (
+	routeFPCOpinions = "consensus/fpc/opinions"
+)
+
+// FPCOpinions gets the node's current opinions on the given conflict and timestamp IDs.
+func (api *GoShimmerAPI) FPCOpinions(conflictIDs, timestampIDs []string) (*jsonmodels.FPCOpinionsResponse, error) {
+	res := &jsonmodels.FPCOpinionsResponse{}
+	if err := api.do(http.MethodPost, routeFPCOpinions, &jsonmodels.FPCOpinionsRequest{
+		ConflictIDs:  conflictIDs,
+		TimestampIDs: timestampIDs,
+	}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,30 @@
+package client
+
+import (
+	"net/http"
+
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+const (
+	routeGossipNeighbors = "gossip/neighbors"
+	routeGossipNeighbor  = "gossip/neighbors/"
+)
+
+// GetGossipNeighbors gets the connected gossip neighbors together with their metrics.
+func (api *GoShimmerAPI) GetGossipNeighbors() (*jsonmodels.GossipNeighborsResponse, error) {
+	res := &jsonmodels.GossipNeighborsResponse{}
+	if err := api.do(http.MethodGet, routeGossipNeighbors, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetGossipNeighbor gets the detailed metrics of the gossip neighbor with the given ID.
+func (api *GoShimmerAPI) GetGossipNeighbor(base58EncodedID string) (*jsonmodels.GossipNeighborResponse, error) {
+	res := &jsonmodels.GossipNeighborResponse{}
+	if err := api.do(http.MethodGet, routeGossipNeighbor+base58EncodedID, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -7,7 +7,8 @@ import (
 )
 
 const (
-	routeInfo = "info"
+	routeInfo         = "info"
+	routeSyncedStatus = "status/synced"
 )
 
 // Info gets the info of the node.
@@ -18,3 +19,12 @@ func (api *GoShimmerAPI) Info() (*jsonmodels.InfoResponse, error) {
 	}
 	return res, nil
 }
+
+// SyncedStatus gets the synced state of the node and the time of its last transition.
+func (api *GoShimmerAPI) SyncedStatus() (*jsonmodels.SyncedStatusResponse, error) {
+	res := &jsonmodels.SyncedStatusResponse{}
+	if err := api.do(http.MethodGet, routeSyncedStatus, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
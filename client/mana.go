@@ -11,6 +11,12 @@ const (
 	routeGetMana                  = "mana"
 	routeGetAllMana               = "mana/all"
 	routeGetManaPercentile        = "mana/percentile"
+	routeGetOwnManaAndRank        = "mana/me"
+	routeGetManaConcentration     = "mana/concentration"
+	routeGetManaAggregate         = "mana/aggregate"
+	routeGetAllowedPledge         = "mana/allowedPledge"
+	routeGetManaTimeline          = "mana/timeline"
+	routeGetManaChanges           = "mana/changes"
 	routeGetOnlineAccessMana      = "mana/access/online"
 	routeGetOnlineConsensusMana   = "mana/consensus/online"
 	routeGetNHighestAccessMana    = "mana/access/nhighest"
@@ -18,6 +24,7 @@ const (
 	routePending                  = "mana/pending"
 	routePastConsensusVector      = "mana/consensus/past"
 	routePastConsensusEventLogs   = "mana/consensus/logs"
+	routeRefreshMana              = "mana/refresh"
 )
 
 // GetOwnMana returns the access and consensus mana of the node this api client is communicating with.
@@ -30,6 +37,17 @@ func (api *GoShimmerAPI) GetOwnMana() (*jsonmodels.GetManaResponse, error) {
 	return res, nil
 }
 
+// GetOwnManaAndRank returns the access and consensus mana of the node this api client is communicating with, together
+// with its ranks in the network.
+func (api *GoShimmerAPI) GetOwnManaAndRank() (*jsonmodels.GetOwnManaResponse, error) {
+	res := &jsonmodels.GetOwnManaResponse{}
+	if err := api.do(http.MethodGet, routeGetOwnManaAndRank,
+		nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // GetManaFullNodeID returns the access and consensus mana of the node specified in the argument.
 // Note, that for the node to understand which nodeID we are referring to, short node ID is not sufficient.
 func (api *GoShimmerAPI) GetManaFullNodeID(fullNodeID string) (*jsonmodels.GetManaResponse, error) {
@@ -76,6 +94,16 @@ func (api *GoShimmerAPI) GetAllMana() (*jsonmodels.GetAllManaResponse, error) {
 	return res, nil
 }
 
+// RefreshMana updates the access and consensus mana vectors of the node to the current time.
+func (api *GoShimmerAPI) RefreshMana() (*jsonmodels.RefreshManaResponse, error) {
+	res := &jsonmodels.RefreshManaResponse{}
+	if err := api.do(http.MethodPost, routeRefreshMana,
+		nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // GetManaPercentile returns the mana percentile for access and consensus mana of a node.
 func (api *GoShimmerAPI) GetManaPercentile(fullNodeID string) (*jsonmodels.GetPercentileResponse, error) {
 	res := &jsonmodels.GetPercentileResponse{}
@@ -86,6 +114,62 @@ func (api *GoShimmerAPI) GetManaPercentile(fullNodeID string) (*jsonmodels.GetPe
 	return res, nil
 }
 
+// GetManaConcentration returns the Nakamoto and Gini coefficients of the access or consensus mana distribution.
+// The Nakamoto coefficient is the minimum number of nodes owning at least the threshold share of the total mana.
+func (api *GoShimmerAPI) GetManaConcentration(manaType string, threshold float64) (*jsonmodels.GetConcentrationResponse, error) {
+	res := &jsonmodels.GetConcentrationResponse{}
+	if err := api.do(http.MethodGet, func() string {
+		return fmt.Sprintf("%s?type=%s&threshold=%g", routeGetManaConcentration, manaType, threshold)
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetManaAggregate returns the total, mean and median mana, the number of nodes and the mana held by the online nodes
+// of the access or consensus mana vector.
+func (api *GoShimmerAPI) GetManaAggregate(manaType string) (*jsonmodels.GetAggregateResponse, error) {
+	res := &jsonmodels.GetAggregateResponse{}
+	if err := api.do(http.MethodGet, func() string {
+		return fmt.Sprintf("%s?type=%s", routeGetManaAggregate, manaType)
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetAllowedPledge returns the node IDs that access and consensus mana can be pledged to.
+func (api *GoShimmerAPI) GetAllowedPledge() (*jsonmodels.GetAllowedPledgeResponse, error) {
+	res := &jsonmodels.GetAllowedPledgeResponse{}
+	if err := api.do(http.MethodGet, routeGetAllowedPledge, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetManaTimeline returns the access and consensus mana of a node at each mana snapshot retained by the node,
+// oldest first.
+func (api *GoShimmerAPI) GetManaTimeline(fullNodeID string) (*jsonmodels.GetManaTimelineResponse, error) {
+	res := &jsonmodels.GetManaTimelineResponse{}
+	if err := api.do(http.MethodGet, routeGetManaTimeline,
+		&jsonmodels.GetManaTimelineRequest{NodeID: fullNodeID}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetManaChanges returns the nodes whose access or consensus mana changed by more than threshold since the mana
+// snapshot retained by the node at or before the given unix timestamp.
+func (api *GoShimmerAPI) GetManaChanges(manaType string, since int64, threshold float64) (*jsonmodels.GetManaChangesResponse, error) {
+	res := &jsonmodels.GetManaChangesResponse{}
+	if err := api.do(http.MethodGet, func() string {
+		return fmt.Sprintf("%s?type=%s&since=%d&threshold=%g", routeGetManaChanges, manaType, since, threshold)
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // GetOnlineAccessMana returns the sorted list of online access mana of nodes.
 func (api *GoShimmerAPI) GetOnlineAccessMana() (*jsonmodels.GetOnlineResponse, error) {
 	res := &jsonmodels.GetOnlineResponse{}
@@ -0,0 +1,91 @@
+package gossip
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// rateWindow is the length of the windows over which the send rate of a neighbor is measured.
+const rateWindow = time.Second
+
+// byteRateLimiter limits the number of bytes written per second using a token bucket whose tokens are bytes.
+// In contrast to limiting the number of messages, this shapes bursts of large messages. The bucket can go into debt,
+// so that a packet larger than the burst is delayed instead of being blocked forever.
+type byteRateLimiter struct {
+	bytesPerSecond float64
+	burst          float64
+
+	tokens float64
+	last   time.Time
+}
+
+// newByteRateLimiter creates a new byteRateLimiter with a full bucket of the given burst size.
+func newByteRateLimiter(bytesPerSecond int, burst int) *byteRateLimiter {
+	return &byteRateLimiter{
+		bytesPerSecond: float64(bytesPerSecond),
+		burst:          float64(burst),
+		tokens:         float64(burst),
+	}
+}
+
+// reserve takes n bytes from the bucket and returns the duration to wait before they can be written.
+// It is not safe for concurrent use.
+func (l *byteRateLimiter) reserve(n int, now time.Time) time.Duration {
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.bytesPerSecond)
+	}
+	l.last = now
+
+	l.tokens -= float64(n)
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.bytesPerSecond * float64(time.Second))
+}
+
+// rateMeter measures the number of bytes per second. The rate is approximated over a sliding window by weighting
+// the bytes of the previous window with the part of it still covered by the sliding window.
+type rateMeter struct {
+	mu                sync.Mutex
+	start             time.Time
+	current, previous uint64
+}
+
+// add records n bytes at the given time.
+func (m *rateMeter) add(n int, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.advance(now)
+	m.current += uint64(n)
+}
+
+// rate returns the number of bytes per second at the given time.
+func (m *rateMeter) rate(now time.Time) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.advance(now)
+	covered := 1 - float64(now.Sub(m.start))/float64(rateWindow)
+	return (float64(m.previous)*covered + float64(m.current)) / rateWindow.Seconds()
+}
+
+// advance moves the current window forward to contain the given time. It must be called while holding mu.
+func (m *rateMeter) advance(now time.Time) {
+	if m.start.IsZero() {
+		m.start = now
+		return
+	}
+	elapsed := now.Sub(m.start)
+	if elapsed < rateWindow {
+		return
+	}
+	if elapsed < 2*rateWindow {
+		m.previous = m.current
+	} else {
+		m.previous = 0
+	}
+	m.current = 0
+	m.start = m.start.Add(elapsed.Truncate(rateWindow))
+}
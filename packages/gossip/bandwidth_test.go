@@ -0,0 +1,81 @@
+package gossip
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestByteRateLimiter(t *testing.T) {
+	now := time.Now()
+	limiter := newByteRateLimiter(1000, 500)
+
+	// the burst is available immediately
+	assert.Zero(t, limiter.reserve(500, now))
+	// afterwards, the bytes have to be waited for
+	assert.Equal(t, 100*time.Millisecond, limiter.reserve(100, now))
+	// packets larger than the burst go into debt
+	assert.Equal(t, 1100*time.Millisecond, limiter.reserve(1000, now))
+	// the bucket is refilled over time, but never above the burst
+	assert.Zero(t, limiter.reserve(500, now.Add(time.Minute)))
+	assert.Equal(t, time.Millisecond, limiter.reserve(1, now.Add(time.Minute)))
+}
+
+func TestRateMeter(t *testing.T) {
+	now := time.Now()
+	var meter rateMeter
+
+	meter.add(100, now)
+	meter.add(100, now.Add(500*time.Millisecond))
+	assert.InDelta(t, 200, meter.rate(now.Add(900*time.Millisecond)), 0.001)
+	// half of the previous window is still covered
+	assert.InDelta(t, 100, meter.rate(now.Add(1500*time.Millisecond)), 0.001)
+	// the rate decays when nothing is sent
+	assert.Zero(t, meter.rate(now.Add(3*time.Second)))
+}
+
+func TestNeighborBandwidthLimit(t *testing.T) {
+	const (
+		bytesPerSecond = 256 * 1024
+		burst          = 16 * 1024
+		packetSize     = 1024
+		numPackets     = 192
+	)
+
+	a, b, teardown := newPipe()
+	defer teardown()
+
+	neighborA := newTestNeighbor("A", a)
+	neighborA.sendLimiter = newByteRateLimiter(bytesPerSecond, burst)
+	defer neighborA.Close()
+	neighborA.Listen()
+
+	neighborB := newTestNeighbor("B", b)
+	defer neighborB.Close()
+
+	var count uint32
+	neighborB.Events.ReceiveMessage.Attach(events.NewClosure(func([]byte) {
+		atomic.AddUint32(&count, 1)
+	}))
+	neighborB.Listen()
+
+	start := time.Now()
+	for i := 0; i < numPackets; i++ {
+		_, err := neighborA.Write(make([]byte, packetSize))
+		require.NoError(t, err)
+	}
+	require.Eventually(t, func() bool { return atomic.LoadUint32(&count) == numPackets }, 5*time.Second, time.Millisecond)
+	elapsed := time.Since(start)
+
+	// apart from the initial burst, the packets are shaped to the limit
+	observedRate := float64(numPackets*packetSize-burst) / elapsed.Seconds()
+	assert.LessOrEqual(t, observedRate, float64(bytesPerSecond))
+
+	sendRate := neighborA.SendRate()
+	assert.Greater(t, sendRate, 0.)
+	assert.LessOrEqual(t, sendRate, float64(bytesPerSecond+burst))
+}
@@ -0,0 +1,83 @@
+package gossip
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+)
+
+const (
+	// compressionThreshold is the minimum size of a packet for it to be compressed.
+	compressionThreshold = 128
+
+	// the flags prepended to every packet, if compression was negotiated.
+	packetUncompressed byte = 0
+	packetCompressed   byte = 1
+)
+
+var gzipWriterPool = sync.Pool{
+	New: func() interface{} { return gzip.NewWriter(nil) },
+}
+
+// compressionConn is implemented by connections which negotiated a packet compression algorithm.
+type compressionConn interface {
+	Compression() string
+}
+
+// compressPacket returns a copy of the given packet prefixed by a flag telling whether it is compressed.
+// The packet is only compressed, if it is large enough and the compression actually reduces its size.
+// It also returns the number of bytes saved by the compression.
+func compressPacket(b []byte) ([]byte, int) {
+	if len(b) >= compressionThreshold {
+		var buf bytes.Buffer
+		buf.WriteByte(packetCompressed)
+
+		w := gzipWriterPool.Get().(*gzip.Writer)
+		w.Reset(&buf)
+		_, err := w.Write(b)
+		if err == nil {
+			err = w.Close()
+		}
+		gzipWriterPool.Put(w)
+
+		if err == nil && buf.Len() < len(b) {
+			return buf.Bytes(), len(b) - buf.Len()
+		}
+	}
+
+	result := make([]byte, len(b)+1)
+	result[0] = packetUncompressed
+	copy(result[1:], b)
+	return result, 0
+}
+
+// decompressPacket removes the compression flag of a received packet and decompresses it, if needed.
+func decompressPacket(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, ErrInvalidCompression
+	}
+
+	switch data[0] {
+	case packetUncompressed:
+		return data[1:], nil
+	case packetCompressed:
+		r, err := gzip.NewReader(bytes.NewReader(data[1:]))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCompression, err)
+		}
+		// limit the decompressed size, so that a small packet can not exhaust the memory
+		packet, err := ioutil.ReadAll(io.LimitReader(r, maxPacketSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCompression, err)
+		}
+		if len(packet) > maxPacketSize {
+			return nil, fmt.Errorf("%w: decompressed packet too large", ErrInvalidCompression)
+		}
+		return packet, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown flag %d", ErrInvalidCompression, data[0])
+	}
+}
@@ -0,0 +1,43 @@
+package gossip
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressPacket(t *testing.T) {
+	small := []byte("small")
+	large := bytes.Repeat([]byte("compressible"), 100)
+
+	// small packets are not compressed
+	data, saved := compressPacket(small)
+	assert.Equal(t, packetUncompressed, data[0])
+	assert.Zero(t, saved)
+	packet, err := decompressPacket(data)
+	require.NoError(t, err)
+	assert.Equal(t, small, packet)
+
+	data, saved = compressPacket(large)
+	assert.Equal(t, packetCompressed, data[0])
+	assert.Equal(t, len(large)-len(data), saved)
+	packet, err = decompressPacket(data)
+	require.NoError(t, err)
+	assert.Equal(t, large, packet)
+}
+
+func TestDecompressPacketInvalid(t *testing.T) {
+	_, err := decompressPacket(nil)
+	assert.ErrorIs(t, err, ErrInvalidCompression)
+	_, err = decompressPacket([]byte{2, 0})
+	assert.ErrorIs(t, err, ErrInvalidCompression)
+	_, err = decompressPacket([]byte{packetCompressed, 1, 2, 3})
+	assert.ErrorIs(t, err, ErrInvalidCompression)
+
+	// packets exceeding the maximum size after decompression are rejected
+	data, _ := compressPacket(make([]byte, maxPacketSize+1))
+	_, err = decompressPacket(data)
+	assert.ErrorIs(t, err, ErrInvalidCompression)
+}
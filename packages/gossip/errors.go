@@ -9,10 +9,16 @@ var (
 	ErrUnknownNeighbor = errors.New("unknown neighbor")
 	// ErrLoopbackNeighbor is returned when the own peer is specified as a neighbor.
 	ErrLoopbackNeighbor = errors.New("loopback connection not allowed")
+	// ErrNeighborNotAllowed is returned when a peer is rejected by the allow and deny lists of the gossip manager.
+	ErrNeighborNotAllowed = errors.New("neighbor not allowed")
 	// ErrDuplicateNeighbor is returned when the same peer is added more than once as a neighbor.
 	ErrDuplicateNeighbor = errors.New("already connected")
 	// ErrInvalidPacket is returned when the gossip manager receives an invalid packet.
 	ErrInvalidPacket = errors.New("invalid packet")
+	// ErrChecksumMismatch is returned when the checksum of a received packet does not match its content.
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+	// ErrInvalidCompression is returned when a received packet can not be decompressed.
+	ErrInvalidCompression = errors.New("invalid compressed packet")
 	// ErrNeighborQueueFull is returned when the send queue is already full.
 	ErrNeighborQueueFull = errors.New("send queue is full")
 )
@@ -15,6 +15,8 @@ type Events struct {
 	NeighborRemoved *events.Event
 	// Fired when a new message was received via the gossip protocol.
 	MessageReceived *events.Event
+	// Fired when a message request stayed unanswered after the maximum number of attempts.
+	MessageRequestFailed *events.Event
 }
 
 // MessageReceivedEvent holds data about a message received event.
@@ -36,3 +38,7 @@ func neighborCaller(handler interface{}, params ...interface{}) {
 func messageReceived(handler interface{}, params ...interface{}) {
 	handler.(func(*MessageReceivedEvent))(params[0].(*MessageReceivedEvent))
 }
+
+func messageIDCaller(handler interface{}, params ...interface{}) {
+	handler.(func([]byte))(params[0].([]byte))
+}
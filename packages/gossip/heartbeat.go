@@ -0,0 +1,91 @@
+package gossip
+
+import (
+	"sync"
+	"time"
+
+	pb "github.com/iotaledger/goshimmer/packages/gossip/proto"
+)
+
+const (
+	// HeartbeatVersion is the highest heartbeat version supported by this node.
+	// Version 0 heartbeats carry no information besides the version, version 1 adds the tip count and sync state.
+	HeartbeatVersion uint32 = 1
+
+	// heartbeatVersionStatus is the first heartbeat version containing the tip count and sync state.
+	heartbeatVersionStatus uint32 = 1
+)
+
+// HeartbeatInfo contains the information of the last heartbeat received from a neighbor.
+type HeartbeatInfo struct {
+	// Version is the heartbeat version used to interpret the heartbeat.
+	Version uint32
+	// TipCount is the number of tips of the neighbor, only set from version 1 on.
+	TipCount int
+	// Synced is whether the neighbor is synced, only set from version 1 on.
+	Synced bool
+	// Received is the time the heartbeat was received. It is zero, if no heartbeat has been received yet.
+	Received time.Time
+}
+
+// heartbeatConn is implemented by connections which negotiated whether heartbeat packets are exchanged.
+type heartbeatConn interface {
+	Heartbeat() bool
+}
+
+// HeartbeatStatusFunc defines a function that returns the tip count and sync state of the local node.
+type HeartbeatStatusFunc func() (tipCount int, synced bool)
+
+// heartbeatState keeps track of the heartbeats received from a single neighbor.
+type heartbeatState struct {
+	mu   sync.RWMutex
+	last HeartbeatInfo
+}
+
+// SupportsHeartbeats returns whether heartbeat packets were negotiated with the neighbor during the handshake.
+func (n *Neighbor) SupportsHeartbeats() bool {
+	return n.heartbeats
+}
+
+// LastHeartbeat returns the information of the last heartbeat received from the neighbor.
+func (n *Neighbor) LastHeartbeat() HeartbeatInfo {
+	n.heartbeat.mu.RLock()
+	defer n.heartbeat.mu.RUnlock()
+
+	return n.heartbeat.last
+}
+
+// receiveHeartbeat stores the information of a heartbeat received from the neighbor.
+// The heartbeat is interpreted using the lower one of the sender's and the local version, so that fields of newer
+// versions are ignored and fields not supported by the sender are left empty.
+func (n *Neighbor) receiveHeartbeat(packet *pb.Heartbeat) {
+	version := packet.GetVersion()
+	if version > HeartbeatVersion {
+		version = HeartbeatVersion
+	}
+
+	info := HeartbeatInfo{
+		Version:  version,
+		Received: time.Now(),
+	}
+	if version >= heartbeatVersionStatus {
+		info.TipCount = int(packet.GetTipCount())
+		info.Synced = packet.GetSynced()
+	}
+
+	n.heartbeat.mu.Lock()
+	defer n.heartbeat.mu.Unlock()
+
+	n.heartbeat.last = info
+}
+
+// newHeartbeat creates a heartbeat of the given version. The local status is only included from version 1 on.
+func newHeartbeat(version uint32, statusFunc HeartbeatStatusFunc) *pb.Heartbeat {
+	packet := &pb.Heartbeat{Version: version}
+	if version >= heartbeatVersionStatus {
+		tipCount, synced := statusFunc()
+		packet.TipCount = uint32(tipCount)
+		packet.Synced = synced
+	}
+	return packet
+}
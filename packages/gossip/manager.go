@@ -1,16 +1,21 @@
 package gossip
 
 import (
+	"container/list"
 	"fmt"
+	"math/rand"
 	"net"
 	"runtime"
 	"sync"
+	"time"
 
 	"github.com/iotaledger/hive.go/autopeering/peer"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/workerpool"
+	"go.uber.org/atomic"
+	"golang.org/x/crypto/blake2b"
 	"google.golang.org/protobuf/proto"
 
 	pb "github.com/iotaledger/goshimmer/packages/gossip/proto"
@@ -21,6 +26,12 @@ import (
 const (
 	// maxPacketSize defines the maximum packet size allowed for gossip and bufferedconn.
 	maxPacketSize = 65 * 1024
+
+	// sendRetryInterval defines the interval in which the fair sending retries to dispatch queued packets.
+	sendRetryInterval = 10 * time.Millisecond
+
+	// maxDisconnectHistory defines the number of most recent disconnects retained per neighbor.
+	maxDisconnectHistory = 10
 )
 
 var (
@@ -31,42 +42,231 @@ var (
 	messageRequestWorkerQueueSize = 100
 )
 
+const (
+	// DefaultRequestRetryInterval defines the default time after which an unanswered message request is re-sent.
+	DefaultRequestRetryInterval = 2 * time.Second
+	// DefaultRequestMaxAttempts defines the default number of attempts before a message request is given up.
+	DefaultRequestMaxAttempts = 5
+	// DefaultRequestFanout defines the default number of additional neighbors queried in each retry of a request.
+	DefaultRequestFanout = 0
+	// DefaultMaxOutstandingRequests defines the default maximum number of outstanding message requests.
+	DefaultMaxOutstandingRequests = 1000
+)
+
+// ManagerOptions holds options for the gossip manager.
+type ManagerOptions struct {
+	requestRetryInterval time.Duration
+	requestMaxAttempts   int
+	requestFanout        int
+	maxOutstandingReqs   int
+	maxInboundNeighbors  int
+	consensusManaFunc    ConsensusManaFunc
+	heartbeatInterval    time.Duration
+	heartbeatStatusFunc  HeartbeatStatusFunc
+	allowedPeers         map[identity.ID]struct{}
+	deniedPeers          map[identity.ID]struct{}
+	fairSending          bool
+	bandwidthLimit       int
+	bandwidthBurst       int
+	messageExistsFunc    MessageExistsFunc
+}
+
+func newManagerOptions(optionalOptions []ManagerOption) *ManagerOptions {
+	result := &ManagerOptions{
+		requestRetryInterval: DefaultRequestRetryInterval,
+		requestMaxAttempts:   DefaultRequestMaxAttempts,
+		requestFanout:        DefaultRequestFanout,
+		maxOutstandingReqs:   DefaultMaxOutstandingRequests,
+	}
+
+	for _, optionalOption := range optionalOptions {
+		optionalOption(result)
+	}
+
+	return result
+}
+
+// ManagerOption is a function which inits an option.
+type ManagerOption func(*ManagerOptions)
+
+// RequestRetryInterval creates an option which sets the time after which an unanswered message request is re-sent.
+func RequestRetryInterval(interval time.Duration) ManagerOption {
+	return func(args *ManagerOptions) {
+		args.requestRetryInterval = interval
+	}
+}
+
+// RequestMaxAttempts creates an option which sets the number of attempts before a message request is given up.
+func RequestMaxAttempts(attempts int) ManagerOption {
+	return func(args *ManagerOptions) {
+		args.requestMaxAttempts = attempts
+	}
+}
+
+// RequestFanout creates an option which sets the number of additional neighbors queried in each retry of a request.
+// The first attempt always queries all neighbors. A value of zero queries all additional neighbors at once.
+func RequestFanout(fanout int) ManagerOption {
+	return func(args *ManagerOptions) {
+		args.requestFanout = fanout
+	}
+}
+
+// MaxOutstandingRequests creates an option which limits the number of outstanding message requests.
+// Requests exceeding the limit are queued locally and issued as soon as outstanding requests are resolved.
+// A value of zero disables the limit.
+func MaxOutstandingRequests(maxRequests int) ManagerOption {
+	return func(args *ManagerOptions) {
+		args.maxOutstandingReqs = maxRequests
+	}
+}
+
+// InboundManaPrioritization creates an option which limits the amount of inbound neighbors to maxInbound.
+// When the limit is exceeded, the inbound neighbor with the lowest consensus mana according to the given function
+// is dropped.
+func InboundManaPrioritization(maxInbound int, consensusManaFunc ConsensusManaFunc) ManagerOption {
+	return func(args *ManagerOptions) {
+		args.maxInboundNeighbors = maxInbound
+		args.consensusManaFunc = consensusManaFunc
+	}
+}
+
+// Heartbeats creates an option which periodically sends heartbeats to all neighbors in the given interval.
+// Only neighbors which negotiated heartbeats during the handshake receive them.
+// If statusFunc is not nil, the heartbeats additionally contain the local tip count and sync state.
+// An interval of zero disables the periodic heartbeats.
+func Heartbeats(interval time.Duration, statusFunc HeartbeatStatusFunc) ManagerOption {
+	return func(args *ManagerOptions) {
+		args.heartbeatInterval = interval
+		args.heartbeatStatusFunc = statusFunc
+	}
+}
+
+// PeerFilter creates an option which restricts the peers that are accepted as inbound neighbors.
+// Peers contained in denied are always rejected. If allowed is not empty, only the peers contained in it are accepted.
+func PeerFilter(allowed []identity.ID, denied []identity.ID) ManagerOption {
+	return func(args *ManagerOptions) {
+		args.allowedPeers = make(map[identity.ID]struct{}, len(allowed))
+		for _, id := range allowed {
+			args.allowedPeers[id] = struct{}{}
+		}
+		args.deniedPeers = make(map[identity.ID]struct{}, len(denied))
+		for _, id := range denied {
+			args.deniedPeers[id] = struct{}{}
+		}
+	}
+}
+
+// FairSending creates an option which distributes the outbound packets over the neighbors in round-robin order,
+// so that each neighbor gets a fair share of the send bandwidth when it is saturated.
+func FairSending(enabled bool) ManagerOption {
+	return func(args *ManagerOptions) {
+		args.fairSending = enabled
+	}
+}
+
+// NeighborBandwidthLimit creates an option which limits the bytes written to each neighbor to bytesPerSecond,
+// while allowing bursts of up to burst bytes. Packets exceeding the limit are delayed, so that the send queue of the
+// neighbor fills up and further packets are dropped. This is independent of the number of messages sent.
+// A limit of zero disables the limit.
+func NeighborBandwidthLimit(bytesPerSecond int, burst int) ManagerOption {
+	return func(args *ManagerOptions) {
+		args.bandwidthLimit = bytesPerSecond
+		args.bandwidthBurst = burst
+	}
+}
+
+// MessageExists creates an option which detects the received messages that are already stored using the given
+// function. Without it, duplicate messages are not counted.
+func MessageExists(f MessageExistsFunc) ManagerOption {
+	return func(args *ManagerOptions) {
+		args.messageExistsFunc = f
+	}
+}
+
+// ConsensusManaFunc defines a function that returns the consensus mana of the given node.
+type ConsensusManaFunc func(nodeID identity.ID) float64
+
 // LoadMessageFunc defines a function that returns the message for the given id.
 type LoadMessageFunc func(messageId tangle.MessageID) ([]byte, error)
 
+// MessageExistsFunc defines a function that returns whether the message with the given id is stored.
+type MessageExistsFunc func(messageID tangle.MessageID) bool
+
 // The Manager handles the connected neighbors.
 type Manager struct {
 	local           *peer.Local
 	loadMessageFunc LoadMessageFunc
 	log             *logger.Logger
 	events          Events
+	options         *ManagerOptions
 
-	wg sync.WaitGroup
+	wg      sync.WaitGroup
+	closing chan struct{}
 
 	mu        sync.RWMutex
 	srv       *server.TCP
 	neighbors map[identity.ID]*Neighbor
 
+	// scheduler distributes the outbound packets over the neighbors, nil if fair sending is disabled.
+	scheduler *sendScheduler
+
 	// messageWorkerPool defines a worker pool where all incoming messages are processed.
 	messageWorkerPool *workerpool.WorkerPool
 
 	messageRequestWorkerPool *workerpool.WorkerPool
+
+	// requests contains the outstanding message requests of this node, keyed by the message ID.
+	requests map[string]*messageRequest
+	// pendingRequests contains the message IDs of the requests exceeding maxOutstandingReqs in FIFO order.
+	pendingRequests    *list.List
+	pendingRequestsSet map[string]*list.Element
+	requestsMu         sync.Mutex
+
+	// duplicateMessages counts the received messages which were already stored.
+	duplicateMessages atomic.Uint64
+
+	// disconnects contains the times of the most recent disconnects per peer, oldest first.
+	disconnects   map[identity.ID][]time.Time
+	disconnectsMu sync.Mutex
+
+	// beforeCloseHooks are called once at the beginning of Close.
+	beforeCloseHooks   []func()
+	beforeCloseHooksMu sync.Mutex
+}
+
+// messageRequest tracks the attempts of an outstanding message request.
+type messageRequest struct {
+	attempts int
+	queried  map[identity.ID]struct{}
+	timer    *time.Timer
 }
 
 // NewManager creates a new Manager.
-func NewManager(local *peer.Local, f LoadMessageFunc, log *logger.Logger) *Manager {
+func NewManager(local *peer.Local, f LoadMessageFunc, log *logger.Logger, optionalOptions ...ManagerOption) *Manager {
 	m := &Manager{
 		local:           local,
 		loadMessageFunc: f,
 		log:             log,
 		events: Events{
-			ConnectionFailed: events.NewEvent(peerAndErrorCaller),
-			NeighborAdded:    events.NewEvent(neighborCaller),
-			NeighborRemoved:  events.NewEvent(neighborCaller),
-			MessageReceived:  events.NewEvent(messageReceived),
+			ConnectionFailed:     events.NewEvent(peerAndErrorCaller),
+			NeighborAdded:        events.NewEvent(neighborCaller),
+			NeighborRemoved:      events.NewEvent(neighborCaller),
+			MessageReceived:      events.NewEvent(messageReceived),
+			MessageRequestFailed: events.NewEvent(messageIDCaller),
 		},
+		options:   newManagerOptions(optionalOptions),
+		closing:   make(chan struct{}),
 		srv:       nil,
 		neighbors: make(map[identity.ID]*Neighbor),
+		requests:  make(map[string]*messageRequest),
+
+		disconnects: make(map[identity.ID][]time.Time),
+
+		pendingRequests:    list.New(),
+		pendingRequestsSet: make(map[string]*list.Element),
+	}
+	if m.options.fairSending {
+		m.scheduler = newSendScheduler(neighborQueueSize)
 	}
 
 	m.messageWorkerPool = workerpool.New(func(task workerpool.Task) {
@@ -93,13 +293,41 @@ func (m *Manager) Start(srv *server.TCP) {
 
 	m.messageWorkerPool.Start()
 	m.messageRequestWorkerPool.Start()
+
+	if m.options.heartbeatInterval > 0 {
+		m.wg.Add(1)
+		go m.heartbeatLoop()
+	}
+	if m.scheduler != nil {
+		m.wg.Add(1)
+		go m.sendLoop()
+	}
+}
+
+// OnBeforeClose registers a hook which is called at the beginning of Close, while the neighbors are still connected.
+// This way, other components can flush their state before the gossip is stopped. The hooks are called once in the
+// order they were registered.
+func (m *Manager) OnBeforeClose(hook func()) {
+	m.beforeCloseHooksMu.Lock()
+	defer m.beforeCloseHooksMu.Unlock()
+	m.beforeCloseHooks = append(m.beforeCloseHooks, hook)
 }
 
 // Close stops the manager and closes all established connections.
 func (m *Manager) Close() {
+	m.runBeforeCloseHooks()
 	m.stop()
 	m.wg.Wait()
 
+	m.requestsMu.Lock()
+	for id, req := range m.requests {
+		req.timer.Stop()
+		delete(m.requests, id)
+	}
+	m.pendingRequests.Init()
+	m.pendingRequestsSet = make(map[string]*list.Element)
+	m.requestsMu.Unlock()
+
 	m.messageWorkerPool.Stop()
 	m.messageRequestWorkerPool.Stop()
 }
@@ -109,11 +337,24 @@ func (m *Manager) Events() Events {
 	return m.events
 }
 
+// runBeforeCloseHooks calls and removes all registered hooks.
+func (m *Manager) runBeforeCloseHooks() {
+	m.beforeCloseHooksMu.Lock()
+	hooks := m.beforeCloseHooks
+	m.beforeCloseHooks = nil
+	m.beforeCloseHooksMu.Unlock()
+
+	for _, hook := range hooks {
+		hook()
+	}
+}
+
 func (m *Manager) stop() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	m.srv = nil
+	close(m.closing)
 
 	// close all neighbor connections
 	for _, nbr := range m.neighbors {
@@ -132,7 +373,7 @@ func (m *Manager) AddOutbound(p *peer.Peer) error {
 	if m.srv == nil {
 		return ErrNotRunning
 	}
-	return m.addNeighbor(p, m.srv.DialPeer)
+	return m.addNeighbor(p, m.srv.DialPeer, false)
 }
 
 // AddInbound tries to add a neighbor by accepting an incoming connection from that peer.
@@ -146,7 +387,24 @@ func (m *Manager) AddInbound(p *peer.Peer) error {
 	if m.srv == nil {
 		return ErrNotRunning
 	}
-	return m.addNeighbor(p, m.srv.AcceptPeer)
+	if reason, allowed := m.isAllowed(p.ID()); !allowed {
+		m.log.Infow("Rejecting inbound neighbor", "peer-id", p.ID(), "reason", reason)
+		return ErrNeighborNotAllowed
+	}
+	return m.addNeighbor(p, m.srv.AcceptPeer, true)
+}
+
+// isAllowed checks the given peer against the allow and deny lists and returns the reason, if it is rejected.
+func (m *Manager) isAllowed(id identity.ID) (reason string, allowed bool) {
+	if _, denied := m.options.deniedPeers[id]; denied {
+		return "peer is on the deny list", false
+	}
+	if len(m.options.allowedPeers) > 0 {
+		if _, ok := m.options.allowedPeers[id]; !ok {
+			return "peer is not on the allow list", false
+		}
+	}
+	return "", true
 }
 
 // DropNeighbor disconnects the neighbor with the given ID.
@@ -164,10 +422,189 @@ func (m *Manager) DropNeighbor(id identity.ID) error {
 }
 
 // RequestMessage requests the message with the given id from the neighbors.
-// If no peer is provided, all neighbors are queried.
+// If peers are provided, the request is sent to exactly those peers. Otherwise, the request is sent to a random
+// subset of the neighbors and, if it stays unanswered, re-sent to additional neighbors until the maximum number of
+// attempts is reached and the MessageRequestFailed event is triggered.
+//
+// The manager only owns the escalation of a single request over its neighbors. Requesting a message again while it is
+// outstanding has no effect. Retrying the request after it was given up is left to the caller, i.e. the
+// tangle.Requester, which keeps triggering the requests of missing messages until they are stored.
 func (m *Manager) RequestMessage(messageID []byte, to ...identity.ID) {
-	msgReq := &pb.MessageRequest{Id: messageID}
-	m.send(marshal(msgReq), to...)
+	if len(to) > 0 {
+		msgReq := &pb.MessageRequest{Id: messageID}
+		m.send(marshal(msgReq), to...)
+		for _, nbr := range m.getNeighbors(to...) {
+			nbr.requestsSent.Inc()
+		}
+		return
+	}
+
+	m.requestsMu.Lock()
+	defer m.requestsMu.Unlock()
+
+	// ignore requests that are already in progress or queued
+	if _, exists := m.requests[string(messageID)]; exists {
+		return
+	}
+	if _, pending := m.pendingRequestsSet[string(messageID)]; pending {
+		return
+	}
+
+	// queue the request if there are too many outstanding ones
+	if m.options.maxOutstandingReqs > 0 && len(m.requests) >= m.options.maxOutstandingReqs {
+		m.pendingRequestsSet[string(messageID)] = m.pendingRequests.PushBack(messageID)
+		return
+	}
+
+	m.startMessageRequest(messageID)
+}
+
+// StopMessageRequest stops the outstanding request of the message with the given id, e.g. when it has been received.
+func (m *Manager) StopMessageRequest(messageID []byte) {
+	m.requestsMu.Lock()
+	defer m.requestsMu.Unlock()
+
+	if elem, pending := m.pendingRequestsSet[string(messageID)]; pending {
+		m.pendingRequests.Remove(elem)
+		delete(m.pendingRequestsSet, string(messageID))
+		return
+	}
+	if req, exists := m.requests[string(messageID)]; exists {
+		req.timer.Stop()
+		delete(m.requests, string(messageID))
+		m.issuePendingRequests()
+	}
+}
+
+// RequestQueueSize returns the number of outstanding message requests.
+func (m *Manager) RequestQueueSize() int {
+	m.requestsMu.Lock()
+	defer m.requestsMu.Unlock()
+
+	return len(m.requests)
+}
+
+// DuplicateMessages returns the number of received messages which were already stored.
+// They are only counted if the MessageExists option is set.
+func (m *Manager) DuplicateMessages() uint64 {
+	return m.duplicateMessages.Load()
+}
+
+// DisconnectHistory returns the times of the most recent disconnects of the neighbor with the given ID, oldest first.
+// At most maxDisconnectHistory disconnects are retained per neighbor.
+func (m *Manager) DisconnectHistory(id identity.ID) []time.Time {
+	m.disconnectsMu.Lock()
+	defer m.disconnectsMu.Unlock()
+
+	return append([]time.Time(nil), m.disconnects[id]...)
+}
+
+func (m *Manager) recordDisconnect(id identity.ID) {
+	m.disconnectsMu.Lock()
+	defer m.disconnectsMu.Unlock()
+
+	history := append(m.disconnects[id], time.Now())
+	if len(history) > maxDisconnectHistory {
+		history = history[len(history)-maxDisconnectHistory:]
+	}
+	m.disconnects[id] = history
+}
+
+// PendingRequestQueueSize returns the number of message requests queued because too many requests are outstanding.
+func (m *Manager) PendingRequestQueueSize() int {
+	m.requestsMu.Lock()
+	defer m.requestsMu.Unlock()
+
+	return m.pendingRequests.Len()
+}
+
+// startMessageRequest starts tracking and sends the first attempt of the request of the given message.
+// It must be called while holding requestsMu.
+func (m *Manager) startMessageRequest(messageID []byte) {
+	req := &messageRequest{queried: make(map[identity.ID]struct{})}
+	m.requests[string(messageID)] = req
+	m.sendMessageRequest(messageID, req)
+}
+
+// issuePendingRequests starts queued requests as long as the limit of outstanding requests allows it.
+// It must be called while holding requestsMu.
+func (m *Manager) issuePendingRequests() {
+	for m.pendingRequests.Len() > 0 {
+		if m.options.maxOutstandingReqs > 0 && len(m.requests) >= m.options.maxOutstandingReqs {
+			return
+		}
+		messageID := m.pendingRequests.Remove(m.pendingRequests.Front()).([]byte)
+		delete(m.pendingRequestsSet, string(messageID))
+		m.startMessageRequest(messageID)
+	}
+}
+
+func (m *Manager) reRequestMessage(messageID []byte) {
+	m.requestsMu.Lock()
+
+	req, exists := m.requests[string(messageID)]
+	if !exists {
+		m.requestsMu.Unlock()
+		return
+	}
+
+	// if we have requested too often => give up
+	if req.attempts >= m.options.requestMaxAttempts {
+		delete(m.requests, string(messageID))
+		m.issuePendingRequests()
+		m.requestsMu.Unlock()
+
+		m.events.MessageRequestFailed.Trigger(messageID)
+		return
+	}
+
+	m.sendMessageRequest(messageID, req)
+	m.requestsMu.Unlock()
+}
+
+// sendMessageRequest sends the next attempt of the given request and schedules the re-request.
+// It must be called while holding requestsMu.
+func (m *Manager) sendMessageRequest(messageID []byte, req *messageRequest) {
+	req.attempts++
+
+	b := marshal(&pb.MessageRequest{Id: messageID})
+	for _, nbr := range m.selectRequestNeighbors(req) {
+		req.queried[nbr.ID()] = struct{}{}
+		if _, err := nbr.Write(b); err != nil {
+			m.log.Warnw("send error", "peer-id", nbr.ID(), "err", err)
+			continue
+		}
+		nbr.requestsSent.Inc()
+	}
+
+	req.timer = time.AfterFunc(m.options.requestRetryInterval, func() { m.reRequestMessage(messageID) })
+}
+
+// selectRequestNeighbors returns the neighbors to query in the current attempt of the given request. The first attempt
+// queries all neighbors, each retry queries up to requestFanout random neighbors that have not been queried for the
+// request yet, e.g. as they connected after the previous attempt. If all neighbors have already been queried, all of
+// them are returned.
+func (m *Manager) selectRequestNeighbors(req *messageRequest) []*Neighbor {
+	neighbors := m.AllNeighbors()
+	if req.attempts <= 1 {
+		return neighbors
+	}
+
+	candidates := make([]*Neighbor, 0, len(neighbors))
+	for _, nbr := range neighbors {
+		if _, queried := req.queried[nbr.ID()]; !queried {
+			candidates = append(candidates, nbr)
+		}
+	}
+	if len(candidates) == 0 {
+		return neighbors
+	}
+
+	rand.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	if m.options.requestFanout > 0 && len(candidates) > m.options.requestFanout {
+		candidates = candidates[:m.options.requestFanout]
+	}
+	return candidates
 }
 
 // SendMessage adds the given message the send queue of the neighbors.
@@ -177,6 +614,84 @@ func (m *Manager) SendMessage(msgData []byte, to ...identity.ID) {
 	m.send(marshal(msg), to...)
 }
 
+// SendHeartbeat sends a heartbeat to the given neighbors. If no peer is provided, it is sent to all neighbors.
+// Neighbors which did not negotiate heartbeats during the handshake are skipped.
+func (m *Manager) SendHeartbeat(to ...identity.ID) {
+	m.sendHeartbeat(m.getNeighbors(to...))
+}
+
+func (m *Manager) sendHeartbeat(neighbors []*Neighbor) {
+	supported := make([]*Neighbor, 0, len(neighbors))
+	for _, nbr := range neighbors {
+		if nbr.SupportsHeartbeats() {
+			supported = append(supported, nbr)
+		}
+	}
+	if len(supported) == 0 {
+		return
+	}
+	m.sendToNeighbors(marshal(newHeartbeat(m.heartbeatVersion(), m.options.heartbeatStatusFunc)), supported)
+}
+
+// heartbeatVersion returns the heartbeat version of the local node.
+// Without a way to determine the local status, only minimal heartbeats are sent.
+func (m *Manager) heartbeatVersion() uint32 {
+	if m.options.heartbeatStatusFunc == nil {
+		return 0
+	}
+	return HeartbeatVersion
+}
+
+func (m *Manager) heartbeatLoop() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.options.heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// the first heartbeat is sent one interval after the connection was established, as the peer might still
+			// be processing the handshake response otherwise
+			var neighbors []*Neighbor
+			for _, nbr := range m.AllNeighbors() {
+				if time.Since(nbr.ConnectionEstablished()) >= m.options.heartbeatInterval {
+					neighbors = append(neighbors, nbr)
+				}
+			}
+			m.sendHeartbeat(neighbors)
+		case <-m.closing:
+			return
+		}
+	}
+}
+
+// sendLoop hands the packets queued in the scheduler over to the neighbors.
+func (m *Manager) sendLoop() {
+	defer m.wg.Done()
+
+	// the neighbors' send queues might have been full, so retry periodically even without new packets
+	ticker := time.NewTicker(sendRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		if m.scheduler.dispatch() > 0 {
+			select {
+			case <-m.closing:
+				return
+			default:
+				continue
+			}
+		}
+		select {
+		case <-m.scheduler.signal:
+		case <-ticker.C:
+		case <-m.closing:
+			return
+		}
+	}
+}
+
 // AllNeighbors returns all the neighbors that are currently connected.
 func (m *Manager) AllNeighbors() []*Neighbor {
 	m.mu.RLock()
@@ -211,16 +726,24 @@ func (m *Manager) getNeighborsByID(ids []identity.ID) []*Neighbor {
 }
 
 func (m *Manager) send(b []byte, to ...identity.ID) {
-	neighbors := m.getNeighbors(to...)
+	m.sendToNeighbors(b, m.getNeighbors(to...))
+}
 
+func (m *Manager) sendToNeighbors(b []byte, neighbors []*Neighbor) {
 	for _, nbr := range neighbors {
+		if m.scheduler != nil {
+			if !m.scheduler.enqueue(nbr, b) {
+				m.log.Debugw("send queue full, packet dropped", "peer-id", nbr.ID())
+			}
+			continue
+		}
 		if _, err := nbr.Write(b); err != nil {
 			m.log.Warnw("send error", "peer-id", nbr.ID(), "err", err)
 		}
 	}
 }
 
-func (m *Manager) addNeighbor(peer *peer.Peer, connectorFunc func(*peer.Peer) (net.Conn, error)) error {
+func (m *Manager) addNeighbor(peer *peer.Peer, connectorFunc func(*peer.Peer) (net.Conn, error), inbound bool) error {
 	conn, err := connectorFunc(peer)
 	if err != nil {
 		m.events.ConnectionFailed.Trigger(peer, err)
@@ -235,9 +758,17 @@ func (m *Manager) addNeighbor(peer *peer.Peer, connectorFunc func(*peer.Peer) (n
 
 	// create and add the neighbor
 	nbr := NewNeighbor(peer, conn, m.log)
+	nbr.inbound = inbound
+	if m.options.bandwidthLimit > 0 {
+		nbr.sendLimiter = newByteRateLimiter(m.options.bandwidthLimit, m.options.bandwidthBurst)
+	}
 	nbr.Events.Close.Attach(events.NewClosure(func() {
 		// assure that the neighbor is removed and notify
 		_ = m.DropNeighbor(peer.ID())
+		if m.scheduler != nil {
+			m.scheduler.remove(nbr)
+		}
+		m.recordDisconnect(peer.ID())
 		m.events.NeighborRemoved.Trigger(nbr)
 	}))
 	nbr.Events.ReceiveMessage.Attach(events.NewClosure(func(data []byte) {
@@ -252,10 +783,53 @@ func (m *Manager) addNeighbor(peer *peer.Peer, connectorFunc func(*peer.Peer) (n
 	nbr.Listen()
 	m.events.NeighborAdded.Trigger(nbr)
 
+	if inbound {
+		m.enforceInboundLimit()
+	}
+
 	return nil
 }
 
+// drops the inbound neighbor with the lowest consensus mana, if there are more inbound neighbors than allowed.
+// It must be called while holding mu.
+func (m *Manager) enforceInboundLimit() {
+	if m.options.maxInboundNeighbors <= 0 || m.options.consensusManaFunc == nil {
+		return
+	}
+
+	var (
+		inboundCount int
+		lowest       *Neighbor
+		lowestMana   float64
+	)
+	for _, nbr := range m.neighbors {
+		if !nbr.inbound {
+			continue
+		}
+		inboundCount++
+		if mana := m.options.consensusManaFunc(nbr.ID()); lowest == nil || mana < lowestMana {
+			lowest, lowestMana = nbr, mana
+		}
+	}
+	if inboundCount <= m.options.maxInboundNeighbors {
+		return
+	}
+
+	m.log.Debugw("dropping inbound neighbor with lowest consensus mana", "peer-id", lowest.ID(), "mana", lowestMana)
+	delete(m.neighbors, lowest.ID())
+	_ = lowest.Close()
+}
+
 func (m *Manager) handlePacket(data []byte, nbr *Neighbor) error {
+	// drop corrupted packets before they are processed any further
+	data, err := nbr.verifyChecksum(data)
+	if err != nil {
+		return err
+	}
+	if data, err = nbr.decompress(data); err != nil {
+		return err
+	}
+
 	// ignore empty packages
 	if len(data) == 0 {
 		return nil
@@ -270,6 +844,12 @@ func (m *Manager) handlePacket(data []byte, nbr *Neighbor) error {
 		if _, added := m.messageRequestWorkerPool.TrySubmit(data, nbr); !added {
 			return fmt.Errorf("messageRequestWorkerPool full: message request discarded")
 		}
+	case pb.PacketHeartbeat:
+		packet := new(pb.Heartbeat)
+		if err := proto.Unmarshal(data[1:], packet); err != nil {
+			return fmt.Errorf("invalid heartbeat: %w", err)
+		}
+		nbr.receiveHeartbeat(packet)
 
 	default:
 		return ErrInvalidPacket
@@ -306,6 +886,17 @@ func (m *Manager) processPacketMessage(data []byte, nbr *Neighbor) {
 	if err := proto.Unmarshal(data[1:], packet); err != nil {
 		m.log.Debugw("error processing packet", "err", err)
 	}
+	msgID := tangle.MessageID(blake2b.Sum256(packet.GetData()))
+	if m.wasRequestedFrom(msgID, nbr.ID()) {
+		nbr.responsesReceived.Inc()
+	}
+	// messages which are already stored were received before, e.g. from another neighbor
+	if len(packet.GetData()) > 0 && m.options.messageExistsFunc != nil {
+		if m.options.messageExistsFunc(msgID) {
+			nbr.duplicateMessages.Inc()
+			m.duplicateMessages.Inc()
+		}
+	}
 	m.events.MessageReceived.Trigger(&MessageReceivedEvent{Data: packet.GetData(), Peer: nbr.Peer})
 }
 
@@ -314,6 +905,7 @@ func (m *Manager) processMessageRequest(data []byte, nbr *Neighbor) {
 	if err := proto.Unmarshal(data[1:], packet); err != nil {
 		m.log.Debugw("invalid packet", "err", err)
 	}
+	nbr.requestsReceived.Inc()
 
 	msgID, _, err := tangle.MessageIDFromBytes(packet.GetId())
 	if err != nil {
@@ -326,5 +918,20 @@ func (m *Manager) processMessageRequest(data []byte, nbr *Neighbor) {
 	}
 
 	// send the loaded message directly to the neighbor
-	_, _ = nbr.Write(marshal(&pb.Message{Data: msgBytes}))
+	if _, writeErr := nbr.Write(marshal(&pb.Message{Data: msgBytes})); writeErr == nil && err == nil {
+		nbr.responsesSent.Inc()
+	}
+}
+
+// wasRequestedFrom returns whether the given message is outstanding and has been requested from the given neighbor.
+func (m *Manager) wasRequestedFrom(msgID tangle.MessageID, id identity.ID) bool {
+	m.requestsMu.Lock()
+	defer m.requestsMu.Unlock()
+
+	req, exists := m.requests[string(msgID[:])]
+	if !exists {
+		return false
+	}
+	_, queried := req.queried[id]
+	return queried
 }
@@ -1,19 +1,23 @@
 package gossip
 
 import (
+	"bytes"
 	"net"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/iotaledger/hive.go/autopeering/peer"
 	"github.com/iotaledger/hive.go/autopeering/peer/service"
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/kvstore/mapdb"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
 	"google.golang.org/protobuf/proto"
 
 	pb "github.com/iotaledger/goshimmer/packages/gossip/proto"
@@ -35,6 +39,32 @@ func TestClose(t *testing.T) {
 	teardown()
 }
 
+func TestOnBeforeClose(t *testing.T) {
+	mgrA, closeA, peerA := newTestManager(t, "A")
+	mgrB, closeB, peerB := newTestManager(t, "B")
+	defer closeB()
+
+	connectInbound(t, mgrA, peerA, mgrB, peerB)
+
+	var calls []string
+	mgrA.OnBeforeClose(func() {
+		// the neighbors are still connected when the hooks are called
+		assert.Len(t, mgrA.AllNeighbors(), 1)
+		calls = append(calls, "first")
+	})
+	mgrA.OnBeforeClose(func() {
+		calls = append(calls, "second")
+	})
+	assert.Empty(t, calls)
+
+	closeA()
+	assert.Equal(t, []string{"first", "second"}, calls)
+
+	// the hooks are only called once
+	mgrA.runBeforeCloseHooks()
+	assert.Equal(t, []string{"first", "second"}, calls)
+}
+
 func TestClosedConnection(t *testing.T) {
 	mgrA, closeA, peerA := newMockedManager(t, "A")
 	defer closeA()
@@ -75,6 +105,10 @@ func TestClosedConnection(t *testing.T) {
 	// the events should be there even before we close
 	mgrA.AssertExpectations(t)
 	mgrB.AssertExpectations(t)
+
+	// both sides record the disconnect
+	assert.Len(t, mgrA.DisconnectHistory(peerB.ID()), 1)
+	assert.Len(t, mgrB.DisconnectHistory(peerA.ID()), 1)
 }
 
 func TestP2PSend(t *testing.T) {
@@ -124,6 +158,117 @@ func TestP2PSend(t *testing.T) {
 	mgrB.AssertExpectations(t)
 }
 
+func TestChecksumMismatch(t *testing.T) {
+	mgrA, closeA, peerA := newMockedManager(t, "A")
+	mgrB, closeB, peerB := newMockedManager(t, "B")
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	// connect in the following way
+	// B -> A
+	mgrA.On("neighborAdded", mock.Anything).Once()
+	mgrB.On("neighborAdded", mock.Anything).Once()
+
+	go func() {
+		defer wg.Done()
+		err := mgrA.AddInbound(peerB)
+		assert.NoError(t, err)
+	}()
+	time.Sleep(graceTime)
+	go func() {
+		defer wg.Done()
+		err := mgrB.AddOutbound(peerA)
+		assert.NoError(t, err)
+	}()
+
+	// wait for the connections to establish
+	wg.Wait()
+
+	neighborsA := mgrA.getNeighborsByID([]identity.ID{peerB.ID()})
+	require.Len(t, neighborsA, 1)
+	neighborsB := mgrB.getNeighborsByID([]identity.ID{peerA.ID()})
+	require.Len(t, neighborsB, 1)
+	// both peers support checksums
+	require.True(t, neighborsA[0].checksum)
+	require.True(t, neighborsB[0].checksum)
+
+	// inject a corrupted frame directly into the connection
+	frame := appendChecksum(marshal(&pb.Message{Data: testMessageData}))
+	frame[len(frame)-checksumSize-1] ^= 0xff
+	_, err := neighborsA[0].BufferedConnection.Write(frame)
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool { return neighborsB[0].ChecksumFailures() == 1 }, time.Second, graceTime)
+
+	// valid packets are still processed
+	mgrB.On("messageReceived", &MessageReceivedEvent{
+		Data: testMessageData,
+		Peer: peerA,
+	}).Once()
+
+	mgrA.SendMessage(testMessageData)
+	time.Sleep(graceTime)
+
+	mgrA.On("neighborRemoved", mock.Anything).Once()
+	mgrB.On("neighborRemoved", mock.Anything).Once()
+
+	closeA()
+	closeB()
+	time.Sleep(graceTime)
+
+	mgrA.AssertExpectations(t)
+	mgrB.AssertExpectations(t)
+	assert.EqualValues(t, 1, neighborsB[0].ChecksumFailures())
+}
+
+func TestCompression(t *testing.T) {
+	largeMessageData := bytes.Repeat([]byte("compressible message data "), 2000)
+
+	run := func(t *testing.T, srvOptsA, srvOptsB []server.Option, wantCompression bool) {
+		mgrA, closeA, peerA := newTestManagerWithServer(t, "A", srvOptsA)
+		defer closeA()
+		mgrB, closeB, peerB := newTestManagerWithServer(t, "B", srvOptsB)
+		defer closeB()
+
+		received := make(chan *MessageReceivedEvent, 1)
+		mgrB.Events().MessageReceived.Attach(events.NewClosure(func(ev *MessageReceivedEvent) { received <- ev }))
+
+		connectInbound(t, mgrA, peerA, mgrB, peerB)
+		neighborsA := mgrA.getNeighborsByID([]identity.ID{peerB.ID()})
+		require.Len(t, neighborsA, 1)
+		neighborsB := mgrB.getNeighborsByID([]identity.ID{peerA.ID()})
+		require.Len(t, neighborsB, 1)
+		assert.Equal(t, wantCompression, neighborsA[0].compression)
+		assert.Equal(t, wantCompression, neighborsB[0].compression)
+
+		mgrA.SendMessage(largeMessageData)
+		select {
+		case ev := <-received:
+			assert.Equal(t, largeMessageData, ev.Data)
+			assert.Equal(t, peerA, ev.Peer)
+		case <-time.After(time.Second):
+			require.Fail(t, "message not received")
+		}
+		if wantCompression {
+			assert.Greater(t, neighborsA[0].BytesSaved(), uint64(len(largeMessageData)/2))
+		} else {
+			assert.Zero(t, neighborsA[0].BytesSaved())
+		}
+	}
+
+	compression := []server.Option{server.Compression(server.CompressionGzip)}
+	t.Run("both supported", func(t *testing.T) {
+		run(t, compression, compression, true)
+	})
+	t.Run("inbound not supported", func(t *testing.T) {
+		run(t, nil, compression, false)
+	})
+	t.Run("outbound not supported", func(t *testing.T) {
+		run(t, compression, nil, false)
+	})
+}
+
 func TestP2PSendTwice(t *testing.T) {
 	mgrA, closeA, peerA := newMockedManager(t, "A")
 	mgrB, closeB, peerB := newMockedManager(t, "B")
@@ -358,6 +503,355 @@ func TestMessageRequest(t *testing.T) {
 	mgrB.AssertExpectations(t)
 }
 
+func TestMessageRequestEscalation(t *testing.T) {
+	const (
+		maxAttempts   = 3
+		retryInterval = 500 * time.Millisecond
+	)
+
+	mgrA, closeA, peerA := newTestManager(t, "A",
+		RequestRetryInterval(retryInterval),
+		RequestMaxAttempts(maxAttempts),
+		RequestFanout(1),
+	)
+	defer closeA()
+
+	// count the requests received by each neighbor, none of them will answer
+	var requests [3]uint32
+	newNeighbor := func(i int, name string) (*Manager, func(), *peer.Peer) {
+		mgr, closeMgr, p := newTestManager(t, name)
+		counter := &requests[i]
+		mgr.loadMessageFunc = func(tangle.MessageID) ([]byte, error) {
+			atomic.AddUint32(counter, 1)
+			return nil, ErrInvalidPacket
+		}
+		return mgr, closeMgr, p
+	}
+	for i, name := range []string{"B", "C"} {
+		mgr, closeMgr, p := newNeighbor(i, name)
+		defer closeMgr()
+		connectInbound(t, mgrA, peerA, mgr, p)
+	}
+
+	var failed uint32
+	mgrA.Events().MessageRequestFailed.Attach(events.NewClosure(func(messageID []byte) {
+		assert.Equal(t, testData, messageID)
+		atomic.AddUint32(&failed, 1)
+	}))
+
+	mgrA.RequestMessage(testData)
+	// requesting the same message again must not start a second request
+	mgrA.RequestMessage(testData)
+	assert.Equal(t, 1, mgrA.RequestQueueSize())
+
+	// the first attempt queries all neighbors
+	assert.Eventually(t, func() bool {
+		return atomic.LoadUint32(&requests[0]) == 1 && atomic.LoadUint32(&requests[1]) == 1
+	}, retryInterval/2, graceTime)
+
+	// the first retry only queries the neighbor which connected after the first attempt
+	mgrD, closeD, peerD := newNeighbor(2, "D")
+	defer closeD()
+	connectInbound(t, mgrA, peerA, mgrD, peerD)
+	assert.Eventually(t, func() bool { return atomic.LoadUint32(&requests[2]) == 1 }, 2*retryInterval, graceTime)
+	assert.EqualValues(t, 1, atomic.LoadUint32(&requests[0]))
+	assert.EqualValues(t, 1, atomic.LoadUint32(&requests[1]))
+
+	// once all neighbors were queried, the last retry queries all of them again before the request is given up
+	assert.Eventually(t, func() bool { return atomic.LoadUint32(&failed) == 1 }, 3*retryInterval, graceTime)
+	for i := range requests {
+		assert.EqualValues(t, 2, atomic.LoadUint32(&requests[i]))
+	}
+	assert.Zero(t, mgrA.RequestQueueSize())
+}
+
+func TestRequestStats(t *testing.T) {
+	mgrA, closeA, peerA := newTestManager(t, "A", RequestRetryInterval(time.Hour))
+	defer closeA()
+	mgrB, closeB, peerB := newTestManager(t, "B")
+	defer closeB()
+
+	// B only serves messages as long as it has them
+	var messageAvailable int32 = 1
+	mgrB.loadMessageFunc = func(tangle.MessageID) ([]byte, error) {
+		if atomic.LoadInt32(&messageAvailable) == 0 {
+			return nil, assert.AnError
+		}
+		return testMessageData, nil
+	}
+
+	connectInbound(t, mgrA, peerA, mgrB, peerB)
+
+	// the first request is answered
+	id := blake2b.Sum256(testMessageData)
+	mgrA.RequestMessage(id[:])
+	time.Sleep(graceTime)
+
+	// the second request is not answered
+	atomic.StoreInt32(&messageAvailable, 0)
+	unknownID := blake2b.Sum256([]byte("unknown"))
+	mgrA.RequestMessage(unknownID[:])
+	time.Sleep(graceTime)
+
+	require.Len(t, mgrA.AllNeighbors(), 1)
+	statsA := mgrA.AllNeighbors()[0].RequestStats()
+	assert.Equal(t, RequestStats{RequestsSent: 2, ResponsesReceived: 1}, statsA)
+	assert.Equal(t, 0.5, statsA.ResponseRatio())
+	assert.Zero(t, statsA.ServedRatio())
+
+	require.Len(t, mgrB.AllNeighbors(), 1)
+	statsB := mgrB.AllNeighbors()[0].RequestStats()
+	assert.Equal(t, RequestStats{RequestsReceived: 2, ResponsesSent: 1}, statsB)
+	assert.Equal(t, 0.5, statsB.ServedRatio())
+	assert.Zero(t, statsB.ResponseRatio())
+}
+
+func TestDuplicateMessages(t *testing.T) {
+	// A stores every message it receives
+	var (
+		mu     sync.Mutex
+		stored = make(map[tangle.MessageID]struct{})
+	)
+	mgrA, closeA, peerA := newTestManager(t, "A", MessageExists(func(msgID tangle.MessageID) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		_, ok := stored[msgID]
+		return ok
+	}))
+	defer closeA()
+	mgrB, closeB, peerB := newTestManager(t, "B")
+	defer closeB()
+	// loading the message must not be used to detect duplicates
+	mgrA.loadMessageFunc = func(tangle.MessageID) ([]byte, error) {
+		return nil, assert.AnError
+	}
+	mgrA.Events().MessageReceived.Attach(events.NewClosure(func(ev *MessageReceivedEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		stored[blake2b.Sum256(ev.Data)] = struct{}{}
+	}))
+
+	connectInbound(t, mgrA, peerA, mgrB, peerB)
+
+	mgrB.SendMessage(testMessageData)
+	time.Sleep(graceTime)
+	assert.Zero(t, mgrA.DuplicateMessages())
+
+	mgrB.SendMessage(testMessageData)
+	time.Sleep(graceTime)
+	assert.EqualValues(t, 1, mgrA.DuplicateMessages())
+
+	require.Len(t, mgrA.AllNeighbors(), 1)
+	assert.EqualValues(t, 1, mgrA.AllNeighbors()[0].DuplicateMessages())
+}
+
+func TestMaxOutstandingRequests(t *testing.T) {
+	mgrA, closeA, peerA := newTestManager(t, "A",
+		RequestRetryInterval(time.Minute),
+		MaxOutstandingRequests(2),
+	)
+	defer closeA()
+
+	mgrB, closeB, peerB := newTestManager(t, "B")
+	defer closeB()
+
+	var (
+		mu        sync.Mutex
+		requested []tangle.MessageID
+	)
+	mgrB.loadMessageFunc = func(msgID tangle.MessageID) ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		requested = append(requested, msgID)
+		return nil, ErrInvalidPacket
+	}
+	requestedMessages := func() []tangle.MessageID {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]tangle.MessageID{}, requested...)
+	}
+
+	connectInbound(t, mgrA, peerA, mgrB, peerB)
+
+	msgIDs := []tangle.MessageID{{1}, {2}, {3}}
+	for _, msgID := range msgIDs {
+		mgrA.RequestMessage(msgID[:])
+	}
+	// requesting a queued message again must not queue it twice
+	mgrA.RequestMessage(msgIDs[2][:])
+
+	// the third request exceeds the limit and is queued instead of being sent
+	time.Sleep(graceTime)
+	assert.Equal(t, 2, mgrA.RequestQueueSize())
+	assert.Equal(t, 1, mgrA.PendingRequestQueueSize())
+	assert.ElementsMatch(t, msgIDs[:2], requestedMessages())
+
+	// once an outstanding request is resolved, the queued one is sent
+	mgrA.StopMessageRequest(msgIDs[0][:])
+	assert.Equal(t, 2, mgrA.RequestQueueSize())
+	assert.Zero(t, mgrA.PendingRequestQueueSize())
+	assert.Eventually(t, func() bool { return len(requestedMessages()) == 3 }, time.Second, 10*time.Millisecond)
+	assert.ElementsMatch(t, msgIDs, requestedMessages())
+}
+
+func TestInboundManaPrioritization(t *testing.T) {
+	consensusMana := make(map[identity.ID]float64)
+	mgrA, closeA, peerA := newTestManager(t, "A", InboundManaPrioritization(2, func(nodeID identity.ID) float64 {
+		return consensusMana[nodeID]
+	}))
+	defer closeA()
+
+	// the neighbor with the lowest mana is neither the oldest nor the newest one
+	managers := make(map[string]*Manager)
+	peers := make(map[string]*peer.Peer)
+	for _, name := range []string{"B", "C", "D"} {
+		mgr, closeMgr, p := newTestManager(t, name)
+		defer closeMgr()
+		managers[name], peers[name] = mgr, p
+	}
+	consensusMana[peers["B"].ID()] = 10
+	consensusMana[peers["C"].ID()] = 1
+	consensusMana[peers["D"].ID()] = 5
+
+	for _, name := range []string{"B", "C", "D"} {
+		connectInbound(t, mgrA, peerA, managers[name], peers[name])
+	}
+
+	neighborIDs := make(map[identity.ID]struct{})
+	for _, nbr := range mgrA.AllNeighbors() {
+		neighborIDs[nbr.ID()] = struct{}{}
+	}
+	assert.Equal(t, map[identity.ID]struct{}{
+		peers["B"].ID(): {},
+		peers["D"].ID(): {},
+	}, neighborIDs)
+}
+
+func TestPeerFilter(t *testing.T) {
+	mgrB, closeB, peerB := newTestManager(t, "B")
+	defer closeB()
+	mgrC, closeC, peerC := newTestManager(t, "C")
+	defer closeC()
+	_, closeD, peerD := newTestManager(t, "D")
+	defer closeD()
+
+	mgrA, closeA, peerA := newTestManager(t, "A", PeerFilter(
+		[]identity.ID{peerB.ID(), peerC.ID()},
+		[]identity.ID{peerC.ID()},
+	))
+	defer closeA()
+
+	// C is allowed but also denied, D is not on the allow list
+	assert.ErrorIs(t, mgrA.AddInbound(peerC), ErrNeighborNotAllowed)
+	assert.ErrorIs(t, mgrA.AddInbound(peerD), ErrNeighborNotAllowed)
+	assert.Empty(t, mgrA.AllNeighbors())
+
+	connectInbound(t, mgrA, peerA, mgrB, peerB)
+	require.Len(t, mgrA.AllNeighbors(), 1)
+	assert.Equal(t, peerB.ID(), mgrA.AllNeighbors()[0].ID())
+
+	// without an allow list, all peers except the denied ones are accepted
+	mgrE, closeE, peerE := newTestManager(t, "E", PeerFilter(nil, []identity.ID{peerB.ID()}))
+	defer closeE()
+	assert.ErrorIs(t, mgrE.AddInbound(peerB), ErrNeighborNotAllowed)
+	connectInbound(t, mgrE, peerE, mgrC, peerC)
+	assert.Len(t, mgrE.AllNeighbors(), 1)
+}
+
+func TestHeartbeat(t *testing.T) {
+	// A periodically sends heartbeats including its status, B only supports minimal heartbeats
+	mgrA, closeA, peerA := newTestManager(t, "A", Heartbeats(graceTime, func() (int, bool) { return 42, true }))
+	defer closeA()
+	mgrB, closeB, peerB := newTestManager(t, "B")
+	defer closeB()
+
+	connectInbound(t, mgrA, peerA, mgrB, peerB)
+	neighborsA, neighborsB := mgrA.AllNeighbors(), mgrB.AllNeighbors()
+	require.Len(t, neighborsA, 1)
+	require.Len(t, neighborsB, 1)
+
+	assert.Eventually(t, func() bool { return !neighborsB[0].LastHeartbeat().Received.IsZero() }, time.Second, graceTime)
+	heartbeatA := neighborsB[0].LastHeartbeat()
+	assert.Equal(t, HeartbeatVersion, heartbeatA.Version)
+	assert.Equal(t, 42, heartbeatA.TipCount)
+	assert.True(t, heartbeatA.Synced)
+
+	assert.True(t, neighborsA[0].LastHeartbeat().Received.IsZero())
+	mgrB.SendHeartbeat()
+	assert.Eventually(t, func() bool { return !neighborsA[0].LastHeartbeat().Received.IsZero() }, time.Second, graceTime)
+	heartbeatB := neighborsA[0].LastHeartbeat()
+	assert.Zero(t, heartbeatB.Version)
+	assert.Zero(t, heartbeatB.TipCount)
+	assert.False(t, heartbeatB.Synced)
+}
+
+func TestHeartbeatNegotiation(t *testing.T) {
+	run := func(t *testing.T, srvOptsA, srvOptsB []server.Option, wantHeartbeats bool) {
+		mgrA, closeA, peerA := newTestManagerWithServer(t, "A", srvOptsA, Heartbeats(graceTime, func() (int, bool) { return 42, true }))
+		defer closeA()
+		mgrB, closeB, peerB := newTestManagerWithServer(t, "B", srvOptsB)
+		defer closeB()
+
+		connectInbound(t, mgrA, peerA, mgrB, peerB)
+		neighborsA, neighborsB := mgrA.AllNeighbors(), mgrB.AllNeighbors()
+		require.Len(t, neighborsA, 1)
+		require.Len(t, neighborsB, 1)
+		assert.Equal(t, wantHeartbeats, neighborsA[0].SupportsHeartbeats())
+		assert.Equal(t, wantHeartbeats, neighborsB[0].SupportsHeartbeats())
+
+		if wantHeartbeats {
+			assert.Eventually(t, func() bool { return !neighborsB[0].LastHeartbeat().Received.IsZero() }, time.Second, graceTime)
+			return
+		}
+		// neither the periodic nor the explicit heartbeats are sent to neighbors not supporting them
+		mgrA.SendHeartbeat()
+		time.Sleep(5 * graceTime)
+		assert.True(t, neighborsB[0].LastHeartbeat().Received.IsZero())
+		assert.Len(t, mgrB.AllNeighbors(), 1)
+	}
+
+	noHeartbeat := []server.Option{server.Heartbeat(false)}
+	t.Run("both supported", func(t *testing.T) {
+		run(t, nil, nil, true)
+	})
+	t.Run("inbound not supported", func(t *testing.T) {
+		run(t, noHeartbeat, nil, false)
+	})
+	t.Run("outbound not supported", func(t *testing.T) {
+		run(t, nil, noHeartbeat, false)
+	})
+}
+
+func TestFairSending(t *testing.T) {
+	mgrA, closeA, peerA := newTestManager(t, "A", FairSending(true))
+	defer closeA()
+	mgrB, closeB, peerB := newTestManager(t, "B")
+	defer closeB()
+	mgrC, closeC, peerC := newTestManager(t, "C")
+	defer closeC()
+
+	var received int32
+	onMessage := events.NewClosure(func(ev *MessageReceivedEvent) {
+		assert.Equal(t, testMessageData, ev.Data)
+		atomic.AddInt32(&received, 1)
+	})
+	mgrB.Events().MessageReceived.Attach(onMessage)
+	mgrC.Events().MessageReceived.Attach(onMessage)
+
+	connectInbound(t, mgrA, peerA, mgrB, peerB)
+	connectInbound(t, mgrA, peerA, mgrC, peerC)
+	require.Len(t, mgrA.AllNeighbors(), 2)
+
+	const numMessages = 10
+	for i := 0; i < numMessages; i++ {
+		mgrA.SendMessage(testMessageData)
+	}
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&received) == 2*numMessages }, time.Second, graceTime)
+	for _, nbr := range mgrA.AllNeighbors() {
+		assert.EqualValues(t, numMessages, nbr.PacketsSent())
+	}
+}
+
 func TestDropNeighbor(t *testing.T) {
 	mgrA, closeA, peerA := newTestManager(t, "A")
 	defer closeA()
@@ -418,13 +912,33 @@ func TestDropNeighbor(t *testing.T) {
 	}
 }
 
+// connects mgrB to mgrA, i.e. mgrA accepts peerB as an inbound neighbor.
+func connectInbound(t *testing.T, mgrA *Manager, peerA *peer.Peer, mgrB *Manager, peerB *peer.Peer) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, mgrA.AddInbound(peerB))
+	}()
+	time.Sleep(graceTime)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, mgrB.AddOutbound(peerA))
+	}()
+	wg.Wait()
+}
+
 func newTestDB(t require.TestingT) *peer.DB {
 	db, err := peer.NewDB(mapdb.NewMapDB())
 	require.NoError(t, err)
 	return db
 }
 
-func newTestManager(t require.TestingT, name string) (*Manager, func(), *peer.Peer) {
+func newTestManager(t require.TestingT, name string, optionalOptions ...ManagerOption) (*Manager, func(), *peer.Peer) {
+	return newTestManagerWithServer(t, name, nil, optionalOptions...)
+}
+
+func newTestManagerWithServer(t require.TestingT, name string, srvOpts []server.Option, optionalOptions ...ManagerOption) (*Manager, func(), *peer.Peer) {
 	l := log.Named(name)
 
 	laddr, err := net.ResolveTCPAddr("tcp", "127.0.0.1:0")
@@ -439,10 +953,10 @@ func newTestManager(t require.TestingT, name string) (*Manager, func(), *peer.Pe
 	local, err := peer.NewLocal(lis.Addr().(*net.TCPAddr).IP, services, newTestDB(t))
 	require.NoError(t, err)
 
-	srv := server.ServeTCP(local, lis, l)
+	srv := server.ServeTCP(local, lis, l, srvOpts...)
 
 	// start the actual gossipping
-	mgr := NewManager(local, loadTestMessage, l)
+	mgr := NewManager(local, loadTestMessage, l, optionalOptions...)
 	mgr.Start(srv)
 
 	detach := func() {
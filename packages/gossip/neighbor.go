@@ -1,6 +1,8 @@
 package gossip
 
 import (
+	"encoding/binary"
+	"hash/crc32"
 	"io"
 	"net"
 	"strings"
@@ -12,12 +14,17 @@ import (
 	"github.com/iotaledger/hive.go/netutil"
 	"github.com/iotaledger/hive.go/netutil/buffconn"
 	"go.uber.org/atomic"
+
+	"github.com/iotaledger/goshimmer/packages/gossip/server"
 )
 
 const (
 	neighborQueueSize        = 5000
 	maxNumReadErrors         = 10
 	droppedMessagesThreshold = 1000
+
+	// checksumSize is the size of the CRC32 checksum appended to every packet, if negotiated.
+	checksumSize = crc32.Size
 )
 
 // Neighbor describes the established gossip connection to another peer.
@@ -28,6 +35,34 @@ type Neighbor struct {
 	log             *logger.Logger
 	queue           chan []byte
 	messagesDropped atomic.Int32
+	packetsSent     atomic.Uint64
+
+	// limits the bytes written per second, nil if the bandwidth is not limited.
+	sendLimiter *byteRateLimiter
+	sendRate    rateMeter
+
+	checksum         bool
+	checksumFailures atomic.Uint64
+
+	// whether packets are compressed using gzip.
+	compression bool
+	bytesSaved  atomic.Uint64
+
+	// whether heartbeat packets are sent to the neighbor.
+	heartbeats bool
+	heartbeat  heartbeatState
+
+	// counters of the message requests and responses exchanged with the neighbor.
+	requestsSent      atomic.Uint64
+	requestsReceived  atomic.Uint64
+	responsesSent     atomic.Uint64
+	responsesReceived atomic.Uint64
+
+	// number of received messages which were already stored.
+	duplicateMessages atomic.Uint64
+
+	// whether the connection was initiated by the peer.
+	inbound bool
 
 	wg             sync.WaitGroup
 	closing        chan struct{}
@@ -49,16 +84,40 @@ func NewNeighbor(peer *peer.Peer, conn net.Conn, log *logger.Logger) *Neighbor {
 		"addr", conn.RemoteAddr().String(),
 	)
 
+	// use checksums if they were negotiated during the handshake
+	var checksum bool
+	if c, ok := conn.(checksumConn); ok {
+		checksum = c.Checksum()
+	}
+	// compress packets if it was negotiated during the handshake
+	var compression bool
+	if c, ok := conn.(compressionConn); ok {
+		compression = c.Compression() == server.CompressionGzip
+	}
+	// send heartbeats if it was negotiated during the handshake
+	var heartbeats bool
+	if c, ok := conn.(heartbeatConn); ok {
+		heartbeats = c.Heartbeat()
+	}
+
 	return &Neighbor{
 		Peer:                  peer,
 		BufferedConnection:    buffconn.NewBufferedConnection(conn, maxPacketSize),
 		log:                   log,
 		queue:                 make(chan []byte, neighborQueueSize),
+		checksum:              checksum,
+		compression:           compression,
+		heartbeats:            heartbeats,
 		closing:               make(chan struct{}),
 		connectionEstablished: time.Now(),
 	}
 }
 
+// checksumConn is implemented by connections which negotiated whether packet checksums are used.
+type checksumConn interface {
+	Checksum() bool
+}
+
 // ConnectionEstablished returns the connection established.
 func (n *Neighbor) ConnectionEstablished() time.Time {
 	return n.connectionEstablished
@@ -83,6 +142,41 @@ func (n *Neighbor) Close() error {
 	return err
 }
 
+// ChecksumFailures returns the number of received packets which were dropped due to a checksum mismatch.
+func (n *Neighbor) ChecksumFailures() uint64 {
+	return n.checksumFailures.Load()
+}
+
+// BytesSaved returns the number of bytes saved by compressing the packets written to the neighbor.
+func (n *Neighbor) BytesSaved() uint64 {
+	return n.bytesSaved.Load()
+}
+
+// DuplicateMessages returns the number of messages received from the neighbor which were already stored.
+func (n *Neighbor) DuplicateMessages() uint64 {
+	return n.duplicateMessages.Load()
+}
+
+// PacketsSent returns the number of packets written to the neighbor.
+func (n *Neighbor) PacketsSent() uint64 {
+	return n.packetsSent.Load()
+}
+
+// RequestStats returns the number of message requests and responses exchanged with the neighbor.
+func (n *Neighbor) RequestStats() RequestStats {
+	return RequestStats{
+		RequestsSent:      n.requestsSent.Load(),
+		RequestsReceived:  n.requestsReceived.Load(),
+		ResponsesSent:     n.responsesSent.Load(),
+		ResponsesReceived: n.responsesReceived.Load(),
+	}
+}
+
+// SendRate returns the number of bytes per second currently written to the neighbor.
+func (n *Neighbor) SendRate() float64 {
+	return n.sendRate.rate(time.Now())
+}
+
 // IsOutbound returns true if the neighbor is an outbound neighbor.
 func (n *Neighbor) IsOutbound() bool {
 	return GetAddress(n.Peer) == n.RemoteAddr().String()
@@ -105,17 +199,42 @@ func (n *Neighbor) writeLoop() {
 			if len(msg) == 0 {
 				continue
 			}
+			if !n.waitForBandwidth(len(msg)) {
+				return
+			}
 			if _, err := n.BufferedConnection.Write(msg); err != nil {
 				n.log.Warnw("Write error", "err", err)
 				_ = n.BufferedConnection.Close()
 				return
 			}
+			n.packetsSent.Inc()
+			n.sendRate.add(len(msg), time.Now())
 		case <-n.closing:
 			return
 		}
 	}
 }
 
+// waitForBandwidth blocks until the packet of the given size can be written without exceeding the bandwidth limit.
+// It returns false, if the neighbor was closed while waiting.
+func (n *Neighbor) waitForBandwidth(size int) bool {
+	if n.sendLimiter == nil {
+		return true
+	}
+	delay := n.sendLimiter.reserve(size, time.Now())
+	if delay <= 0 {
+		return true
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-n.closing:
+		return false
+	}
+}
+
 func (n *Neighbor) readLoop() {
 	defer n.wg.Done()
 
@@ -146,8 +265,17 @@ func (n *Neighbor) readLoop() {
 
 func (n *Neighbor) Write(b []byte) (int, error) {
 	l := len(b)
-	if l > maxPacketSize {
-		n.log.Panicw("message too large", "len", l, "max", maxPacketSize)
+	if n.compression {
+		var saved int
+		b, saved = compressPacket(b)
+		n.bytesSaved.Add(uint64(saved))
+	}
+	if n.checksum {
+		// the same packet might be written to multiple neighbors, thus the checksum is appended to a copy
+		b = appendChecksum(b)
+	}
+	if len(b) > maxPacketSize {
+		n.log.Panicw("message too large", "len", len(b), "max", maxPacketSize)
 	}
 
 	// add to queue
@@ -164,3 +292,56 @@ func (n *Neighbor) Write(b []byte) (int, error) {
 		return 0, nil
 	}
 }
+
+// trySend queues the packet, if the send queue of the neighbor is not full.
+func (n *Neighbor) trySend(b []byte) bool {
+	if len(n.queue) >= cap(n.queue) {
+		return false
+	}
+	_, _ = n.Write(b)
+	return true
+}
+
+// isClosed returns whether the connection to the neighbor is closed.
+func (n *Neighbor) isClosed() bool {
+	select {
+	case <-n.closing:
+		return true
+	default:
+		return false
+	}
+}
+
+// verifyChecksum checks and removes the checksum of a received packet, if checksums are used.
+// Packets with a missing or wrong checksum are counted and ErrChecksumMismatch is returned.
+func (n *Neighbor) verifyChecksum(data []byte) ([]byte, error) {
+	if !n.checksum {
+		return data, nil
+	}
+	if len(data) < checksumSize {
+		n.checksumFailures.Inc()
+		return nil, ErrChecksumMismatch
+	}
+	packet, checksum := data[:len(data)-checksumSize], data[len(data)-checksumSize:]
+	if crc32.ChecksumIEEE(packet) != binary.BigEndian.Uint32(checksum) {
+		n.checksumFailures.Inc()
+		return nil, ErrChecksumMismatch
+	}
+	return packet, nil
+}
+
+// decompress removes the compression of a received packet, if compression is used.
+func (n *Neighbor) decompress(data []byte) ([]byte, error) {
+	if !n.compression {
+		return data, nil
+	}
+	return decompressPacket(data)
+}
+
+// appendChecksum returns a copy of the given packet with its CRC32 checksum appended.
+func appendChecksum(b []byte) []byte {
+	result := make([]byte, len(b)+checksumSize)
+	copy(result, b)
+	binary.BigEndian.PutUint32(result[len(b):], crc32.ChecksumIEEE(b))
+	return result
+}
@@ -11,6 +11,7 @@ type PacketType uint
 const (
 	PacketMessage PacketType = 20 + iota
 	PacketMessageRequest
+	PacketHeartbeat
 )
 
 // Packet extends the proto.Message interface with additional util functions.
@@ -34,3 +35,9 @@ func (m *MessageRequest) Name() string { return "message_request" }
 
 // Type returns the packet type id of the message request packet.
 func (m *MessageRequest) Type() PacketType { return PacketMessageRequest }
+
+// Name returns the name of the heartbeat packet.
+func (m *Heartbeat) Name() string { return "heartbeat" }
+
+// Type returns the packet type id of the heartbeat packet.
+func (m *Heartbeat) Type() PacketType { return PacketHeartbeat }
@@ -0,0 +1,32 @@
+package gossip
+
+// RequestStats contains the number of message requests and responses exchanged with a neighbor.
+type RequestStats struct {
+	// RequestsSent is the number of message requests sent to the neighbor.
+	RequestsSent uint64
+	// RequestsReceived is the number of message requests received from the neighbor.
+	RequestsReceived uint64
+	// ResponsesSent is the number of requested messages sent to the neighbor.
+	ResponsesSent uint64
+	// ResponsesReceived is the number of requested messages received from the neighbor.
+	ResponsesReceived uint64
+}
+
+// ResponseRatio returns the fraction of the requests sent to the neighbor that were answered.
+// It returns 0 if no requests have been sent.
+func (s RequestStats) ResponseRatio() float64 {
+	return ratio(s.ResponsesReceived, s.RequestsSent)
+}
+
+// ServedRatio returns the fraction of the requests received from the neighbor that were answered.
+// It returns 0 if no requests have been received.
+func (s RequestStats) ServedRatio() float64 {
+	return ratio(s.ResponsesSent, s.RequestsReceived)
+}
+
+func ratio(a, b uint64) float64 {
+	if b == 0 {
+		return 0
+	}
+	return float64(a) / float64(b)
+}
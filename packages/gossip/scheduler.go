@@ -0,0 +1,141 @@
+package gossip
+
+import (
+	"sync"
+
+	"github.com/iotaledger/hive.go/identity"
+)
+
+// sendTarget is a destination of packets distributed by the sendScheduler.
+type sendTarget interface {
+	ID() identity.ID
+	// trySend hands the packet over for sending. It returns false, if the target can not take it right now.
+	trySend(b []byte) bool
+	// isClosed returns whether the target is closed and does not take any more packets.
+	isClosed() bool
+}
+
+// sendQueue contains the packets waiting to be handed over to a single target.
+type sendQueue struct {
+	target  sendTarget
+	packets [][]byte
+}
+
+// sendScheduler distributes the outbound packets over their targets in round-robin order: each target is handed
+// over at most one packet per pass. This way, a target with many queued packets can not starve the others when the
+// outbound bandwidth is saturated.
+type sendScheduler struct {
+	queueSize int
+
+	mu     sync.Mutex
+	queues map[identity.ID]*sendQueue
+	// the order in which the queues are served.
+	ring []identity.ID
+	// the position in ring at which the next pass starts.
+	next int
+
+	// signal is notified whenever a packet is enqueued.
+	signal chan struct{}
+}
+
+func newSendScheduler(queueSize int) *sendScheduler {
+	return &sendScheduler{
+		queueSize: queueSize,
+		queues:    make(map[identity.ID]*sendQueue),
+		signal:    make(chan struct{}, 1),
+	}
+}
+
+// enqueue adds the packet to the queue of the given target. It returns false, if the queue is full and the packet
+// was dropped.
+func (s *sendScheduler) enqueue(target sendTarget, b []byte) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queue, ok := s.queues[target.ID()]
+	if ok && queue.target != target {
+		// the queue still belongs to a previous connection of the same peer, its packets are stale
+		s.removeQueue(target.ID())
+		ok = false
+	}
+	if !ok {
+		queue = &sendQueue{target: target}
+		s.queues[target.ID()] = queue
+		s.ring = append(s.ring, target.ID())
+	}
+	if len(queue.packets) >= s.queueSize {
+		return false
+	}
+	queue.packets = append(queue.packets, b)
+
+	select {
+	case s.signal <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// dispatch performs a single pass over all queues and hands the first packet of each queue over to its target.
+// It returns the number of dispatched packets. Queues of closed targets are removed.
+func (s *sendScheduler) dispatch() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.ring) == 0 {
+		return 0
+	}
+
+	// rotate the starting point, so that no target is always served first
+	start := s.next % len(s.ring)
+	s.next = start + 1
+	ring := append(append(make([]identity.ID, 0, len(s.ring)), s.ring[start:]...), s.ring[:start]...)
+
+	var dispatched int
+	for _, id := range ring {
+		queue := s.queues[id]
+		if queue.target.isClosed() {
+			s.removeQueue(id)
+			continue
+		}
+		if len(queue.packets) == 0 || !queue.target.trySend(queue.packets[0]) {
+			continue
+		}
+		queue.packets[0] = nil
+		queue.packets = queue.packets[1:]
+		dispatched++
+	}
+	return dispatched
+}
+
+// queued returns the number of packets waiting in the queue of the given target.
+func (s *sendScheduler) queued(id identity.ID) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if queue, ok := s.queues[id]; ok {
+		return len(queue.packets)
+	}
+	return 0
+}
+
+// remove drops the queue of the given target together with all its pending packets. A queue that already belongs to
+// a newer target with the same ID is kept.
+func (s *sendScheduler) remove(target sendTarget) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if queue, ok := s.queues[target.ID()]; ok && queue.target == target {
+		s.removeQueue(target.ID())
+	}
+}
+
+// removeQueue removes the queue of the given target. It must be called while holding mu.
+func (s *sendScheduler) removeQueue(id identity.ID) {
+	delete(s.queues, id)
+	for i := range s.ring {
+		if s.ring[i] == id {
+			s.ring = append(s.ring[:i], s.ring[i+1:]...)
+			break
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package gossip
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+// bandwidth limits the total number of packets all fakeTargets can take.
+type bandwidth struct {
+	remaining int
+}
+
+type fakeTarget struct {
+	id        identity.ID
+	bandwidth *bandwidth
+	sent      int
+	closed    bool
+}
+
+func (f *fakeTarget) ID() identity.ID { return f.id }
+func (f *fakeTarget) isClosed() bool  { return f.closed }
+
+func (f *fakeTarget) trySend([]byte) bool {
+	if f.bandwidth.remaining <= 0 {
+		return false
+	}
+	f.bandwidth.remaining--
+	f.sent++
+	return true
+}
+
+func TestSendSchedulerFairness(t *testing.T) {
+	const (
+		numTargets       = 4
+		packetsPerTarget = 100
+		totalBandwidth   = 120
+	)
+	bw := &bandwidth{remaining: totalBandwidth}
+	scheduler := newSendScheduler(packetsPerTarget)
+
+	targets := make([]*fakeTarget, numTargets)
+	for i := range targets {
+		targets[i] = &fakeTarget{id: identity.GenerateIdentity().ID(), bandwidth: bw}
+	}
+	// the packets are enqueued neighbor by neighbor, so a FIFO would only serve the first ones
+	for _, target := range targets {
+		for i := 0; i < packetsPerTarget; i++ {
+			assert.True(t, scheduler.enqueue(target, []byte{byte(i)}))
+		}
+		// the queue of each target is bounded
+		assert.False(t, scheduler.enqueue(target, []byte{0}))
+	}
+
+	for scheduler.dispatch() > 0 {
+	}
+
+	// the saturated bandwidth is shared evenly
+	for _, target := range targets {
+		assert.InDelta(t, totalBandwidth/numTargets, target.sent, 1)
+		assert.Equal(t, packetsPerTarget-target.sent, scheduler.queued(target.ID()))
+	}
+
+	// queues of closed targets are dropped, the remaining ones are served further
+	targets[0].closed = true
+	bw.remaining = numTargets
+	assert.Equal(t, numTargets-1, scheduler.dispatch())
+	assert.Zero(t, scheduler.queued(targets[0].ID()))
+}
+
+func TestSendSchedulerReconnect(t *testing.T) {
+	bw := &bandwidth{}
+	scheduler := newSendScheduler(10)
+	id := identity.GenerateIdentity().ID()
+
+	// packets queued for a neighbor that is closed before they could be sent
+	old := &fakeTarget{id: id, bandwidth: bw}
+	assert.True(t, scheduler.enqueue(old, []byte{0}))
+	assert.True(t, scheduler.enqueue(old, []byte{1}))
+	old.closed = true
+
+	// the same peer reconnects before the next dispatch
+	reconnected := &fakeTarget{id: id, bandwidth: bw}
+	assert.True(t, scheduler.enqueue(reconnected, []byte{2}))
+	assert.Equal(t, 1, scheduler.queued(id))
+
+	// removing the old neighbor must not affect the queue of the new one
+	scheduler.remove(old)
+	assert.Equal(t, 1, scheduler.queued(id))
+
+	bw.remaining = 10
+	assert.Equal(t, 1, scheduler.dispatch())
+	assert.Zero(t, old.sent)
+	assert.Equal(t, 1, reconnected.sent)
+
+	// the queue of a removed neighbor is dropped
+	assert.True(t, scheduler.enqueue(reconnected, []byte{3}))
+	scheduler.remove(reconnected)
+	assert.Zero(t, scheduler.queued(id))
+	assert.Zero(t, scheduler.dispatch())
+}
@@ -0,0 +1,35 @@
+package server
+
+import "net"
+
+// CompressionGzip is the name of the gzip packet compression algorithm.
+const CompressionGzip = "gzip"
+
+// Conn is an established gossip connection together with the options negotiated during the handshake.
+type Conn struct {
+	net.Conn
+	connOptions
+}
+
+// connOptions contains the options negotiated during the handshake.
+type connOptions struct {
+	checksum    bool
+	compression string
+	heartbeat   bool
+}
+
+// Checksum returns whether both peers agreed on appending a checksum to every packet.
+func (c *Conn) Checksum() bool {
+	return c.checksum
+}
+
+// Compression returns the packet compression algorithm both peers agreed on, or an empty string if packets are not
+// compressed.
+func (c *Conn) Compression() string {
+	return c.compression
+}
+
+// Heartbeat returns whether both peers agreed on exchanging heartbeat packets.
+func (c *Conn) Heartbeat() bool {
+	return c.heartbeat
+}
@@ -20,20 +20,57 @@ func isExpired(ts int64) bool {
 	return time.Since(time.Unix(ts, 0)) >= handshakeExpiration
 }
 
-func newHandshakeRequest(toAddr string) ([]byte, error) {
+// newHandshakeRequest creates a handshake request advertising whether checksums are supported, the given compression
+// algorithms and whether heartbeats are supported.
+func newHandshakeRequest(toAddr string, checksum bool, compression []string, heartbeat bool) ([]byte, error) {
 	m := &pb.HandshakeRequest{
-		Version:   versionNum,
-		To:        toAddr,
-		Timestamp: time.Now().Unix(),
+		Version:     versionNum,
+		To:          toAddr,
+		Timestamp:   time.Now().Unix(),
+		Checksum:    checksum,
+		Compression: compression,
+		Heartbeat:   heartbeat,
 	}
 	return proto.Marshal(m)
 }
 
-func newHandshakeResponse(reqData []byte) ([]byte, error) {
+// newHandshakeResponse creates the response to the given request.
+// Checksums are used if they are supported by both peers. The first compression algorithm advertised by the
+// requester which is contained in the given supported algorithms is used, if any. Heartbeats are used if they are
+// supported by both peers.
+// The negotiated options are returned along with the response.
+func newHandshakeResponse(reqData []byte, checksum bool, compression []string, heartbeat bool) ([]byte, connOptions, error) {
+	req := new(pb.HandshakeRequest)
+	if err := proto.Unmarshal(reqData, req); err != nil {
+		return nil, connOptions{}, err
+	}
 	m := &pb.HandshakeResponse{
-		ReqHash: server.PacketHash(reqData),
+		ReqHash:     server.PacketHash(reqData),
+		Checksum:    req.GetChecksum() && checksum,
+		Compression: selectCompression(req.GetCompression(), compression),
+		Heartbeat:   req.GetHeartbeat() && heartbeat,
 	}
-	return proto.Marshal(m)
+	data, err := proto.Marshal(m)
+	return data, connOptions{checksum: m.Checksum, compression: m.Compression, heartbeat: m.Heartbeat}, err
+}
+
+// selectCompression returns the first of the requested algorithms which is also supported, or an empty string.
+func selectCompression(requested, supported []string) string {
+	for _, algorithm := range requested {
+		if containsString(supported, algorithm) {
+			return algorithm
+		}
+	}
+	return ""
+}
+
+func containsString(list []string, s string) bool {
+	for i := range list {
+		if list[i] == s {
+			return true
+		}
+	}
+	return false
 }
 
 func (t *TCP) validateHandshakeRequest(reqData []byte) bool {
@@ -60,20 +97,45 @@ func (t *TCP) validateHandshakeRequest(reqData []byte) bool {
 	return true
 }
 
-func (t *TCP) validateHandshakeResponse(resData []byte, reqData []byte) bool {
+// validateHandshakeResponse checks the response to the given request.
+// It returns the negotiated connection options and whether the response is valid.
+func (t *TCP) validateHandshakeResponse(resData []byte, reqData []byte) (connOptions, bool) {
 	m := new(pb.HandshakeResponse)
 	if err := proto.Unmarshal(resData, m); err != nil {
 		t.log.Debugw("invalid handshake",
 			"err", err,
 		)
-		return false
+		return connOptions{}, false
 	}
 	if !bytes.Equal(m.GetReqHash(), server.PacketHash(reqData)) {
 		t.log.Debugw("invalid handshake",
 			"hash", m.GetReqHash(),
 		)
-		return false
+		return connOptions{}, false
+	}
+	// only an algorithm advertised in the request can be used
+	if m.GetCompression() != "" && !containsString(t.compression, m.GetCompression()) {
+		t.log.Debugw("invalid handshake",
+			"compression", m.GetCompression(),
+		)
+		return connOptions{}, false
 	}
 
-	return true
+	// checksums can only be used if they were advertised in the request
+	if m.GetChecksum() && !t.checksum {
+		t.log.Debugw("invalid handshake",
+			"checksum", m.GetChecksum(),
+		)
+		return connOptions{}, false
+	}
+
+	// heartbeats can only be used if they were advertised in the request
+	if m.GetHeartbeat() && !t.heartbeat {
+		t.log.Debugw("invalid handshake",
+			"heartbeat", m.GetHeartbeat(),
+		)
+		return connOptions{}, false
+	}
+
+	return connOptions{checksum: m.GetChecksum(), compression: m.GetCompression(), heartbeat: m.GetHeartbeat()}, true
 }
@@ -52,6 +52,12 @@ type TCP struct {
 	local    *peer.Local
 	listener *net.TCPListener
 	log      *zap.SugaredLogger
+	// the supported packet compression algorithms in order of preference.
+	compression []string
+	// whether heartbeat packets are supported.
+	heartbeat bool
+	// whether packet checksums are supported.
+	checksum bool
 
 	addAcceptMatcher chan *acceptMatcher
 	acceptReceived   chan accept
@@ -79,8 +85,35 @@ type accept struct {
 	conn   net.Conn    // the actual network connection
 }
 
+// Option is a function setting an option of the TCP server.
+type Option func(t *TCP)
+
+// Compression sets the packet compression algorithms supported by the server in order of preference.
+// The compression is only used with peers that support one of the algorithms as well.
+func Compression(algorithms ...string) Option {
+	return func(t *TCP) {
+		t.compression = algorithms
+	}
+}
+
+// Heartbeat sets whether the server advertises the support of heartbeat packets, which is the default.
+// Heartbeats are only exchanged with peers that support them as well.
+func Heartbeat(supported bool) Option {
+	return func(t *TCP) {
+		t.heartbeat = supported
+	}
+}
+
+// Checksum sets whether the server advertises the support of packet checksums, which is the default.
+// Checksums are only appended to the packets exchanged with peers that support them as well.
+func Checksum(supported bool) Option {
+	return func(t *TCP) {
+		t.checksum = supported
+	}
+}
+
 // ServeTCP creates the object and starts listening for incoming connections.
-func ServeTCP(local *peer.Local, listener *net.TCPListener, log *zap.SugaredLogger) *TCP {
+func ServeTCP(local *peer.Local, listener *net.TCPListener, log *zap.SugaredLogger, opts ...Option) *TCP {
 	t := &TCP{
 		local:            local,
 		listener:         listener,
@@ -88,6 +121,11 @@ func ServeTCP(local *peer.Local, listener *net.TCPListener, log *zap.SugaredLogg
 		addAcceptMatcher: make(chan *acceptMatcher),
 		acceptReceived:   make(chan accept),
 		closing:          make(chan struct{}),
+		heartbeat:        true,
+		checksum:         true,
+	}
+	for _, opt := range opts {
+		opt(t)
 	}
 
 	t.log.Debugw("server started",
@@ -127,6 +165,7 @@ func (t *TCP) DialPeer(p *peer.Peer) (net.Conn, error) {
 	}
 
 	var conn net.Conn
+	var options connOptions
 	if err := backoff.Retry(dialRetryPolicy, func() error {
 		var err error
 		address := net.JoinHostPort(p.IP().String(), strconv.Itoa(gossipEndpoint.Port()))
@@ -135,7 +174,7 @@ func (t *TCP) DialPeer(p *peer.Peer) (net.Conn, error) {
 			return fmt.Errorf("dial %s / %s failed: %w", address, p.ID(), err)
 		}
 
-		if err = t.doHandshake(p.PublicKey(), address, conn); err != nil {
+		if options, err = t.doHandshake(p.PublicKey(), address, conn); err != nil {
 			return fmt.Errorf("handshake %s / %s failed: %w", address, p.ID(), err)
 		}
 		return nil
@@ -146,8 +185,11 @@ func (t *TCP) DialPeer(p *peer.Peer) (net.Conn, error) {
 	t.log.Debugw("outgoing connection established",
 		"id", p.ID(),
 		"addr", conn.RemoteAddr(),
+		"checksum", options.checksum,
+		"compression", options.compression,
+		"heartbeat", options.heartbeat,
 	)
-	return conn, nil
+	return &Conn{Conn: conn, connOptions: options}, nil
 }
 
 // AcceptPeer awaits an incoming connection from the given peer.
@@ -261,12 +303,13 @@ func (t *TCP) matchAccept(m *acceptMatcher, req []byte, conn net.Conn) {
 	t.wg.Add(1)
 	defer t.wg.Done()
 
-	if err := t.writeHandshakeResponse(req, conn); err != nil {
+	options, err := t.writeHandshakeResponse(req, conn)
+	if err != nil {
 		m.connected <- connect{nil, fmt.Errorf("incoming handshake failed: %w", err)}
 		t.closeConnection(conn)
 		return
 	}
-	m.connected <- connect{conn, nil}
+	m.connected <- connect{&Conn{Conn: conn, connOptions: options}, nil}
 }
 
 func (t *TCP) listenLoop() {
@@ -307,10 +350,10 @@ func (t *TCP) listenLoop() {
 	}
 }
 
-func (t *TCP) doHandshake(key ed25519.PublicKey, remoteAddr string, conn net.Conn) error {
-	reqData, err := newHandshakeRequest(remoteAddr)
+func (t *TCP) doHandshake(key ed25519.PublicKey, remoteAddr string, conn net.Conn) (connOptions, error) {
+	reqData, err := newHandshakeRequest(remoteAddr, t.checksum, t.compression, t.heartbeat)
 	if err != nil {
-		return err
+		return connOptions{}, err
 	}
 
 	pkt := &pb.Packet{
@@ -320,46 +363,47 @@ func (t *TCP) doHandshake(key ed25519.PublicKey, remoteAddr string, conn net.Con
 	}
 	b, err := proto.Marshal(pkt)
 	if err != nil {
-		return err
+		return connOptions{}, err
 	}
 	if l := len(b); l > maxHandshakePacketSize {
-		return fmt.Errorf("handshake size too large: %d, max %d", l, maxHandshakePacketSize)
+		return connOptions{}, fmt.Errorf("handshake size too large: %d, max %d", l, maxHandshakePacketSize)
 	}
 
 	err = conn.SetWriteDeadline(time.Now().Add(handshakeTimeout))
 	if err != nil {
-		return err
+		return connOptions{}, err
 	}
 	_, err = conn.Write(b)
 	if err != nil {
-		return err
+		return connOptions{}, err
 	}
 
 	err = conn.SetReadDeadline(time.Now().Add(handshakeTimeout))
 	if err != nil {
-		return err
+		return connOptions{}, err
 	}
 	b = make([]byte, maxHandshakePacketSize)
 	n, err := conn.Read(b)
 	if err != nil {
-		return err
+		return connOptions{}, err
 	}
 
 	pkt = &pb.Packet{}
 	err = proto.Unmarshal(b[:n], pkt)
 	if err != nil {
-		return err
+		return connOptions{}, err
 	}
 
 	signer, err := peer.RecoverKeyFromSignedData(pkt)
 	if err != nil || !bytes.Equal(key.Bytes(), signer.Bytes()) {
-		return ErrInvalidHandshake
+		return connOptions{}, ErrInvalidHandshake
 	}
-	if !t.validateHandshakeResponse(pkt.GetData(), reqData) {
-		return ErrInvalidHandshake
+	options, ok := t.validateHandshakeResponse(pkt.GetData(), reqData)
+	if !ok {
+		return connOptions{}, ErrInvalidHandshake
 	}
 
-	return nil
+	return options, nil
 }
 
 func (t *TCP) readHandshakeRequest(conn net.Conn) (ed25519.PublicKey, []byte, error) {
@@ -390,10 +434,10 @@ func (t *TCP) readHandshakeRequest(conn net.Conn) (ed25519.PublicKey, []byte, er
 	return key, pkt.GetData(), nil
 }
 
-func (t *TCP) writeHandshakeResponse(reqData []byte, conn net.Conn) error {
-	data, err := newHandshakeResponse(reqData)
+func (t *TCP) writeHandshakeResponse(reqData []byte, conn net.Conn) (connOptions, error) {
+	data, options, err := newHandshakeResponse(reqData, t.checksum, t.compression, t.heartbeat)
 	if err != nil {
-		return err
+		return connOptions{}, err
 	}
 
 	pkt := &pb.Packet{
@@ -403,20 +447,20 @@ func (t *TCP) writeHandshakeResponse(reqData []byte, conn net.Conn) error {
 	}
 	b, err := proto.Marshal(pkt)
 	if err != nil {
-		return err
+		return connOptions{}, err
 	}
 	if l := len(b); l > maxHandshakePacketSize {
-		return fmt.Errorf("handshake size too large: %d, max %d", l, maxHandshakePacketSize)
+		return connOptions{}, fmt.Errorf("handshake size too large: %d, max %d", l, maxHandshakePacketSize)
 	}
 
 	err = conn.SetWriteDeadline(time.Now().Add(handshakeTimeout))
 	if err != nil {
-		return err
+		return connOptions{}, err
 	}
 	_, err = conn.Write(b)
 	if err != nil {
-		return err
+		return connOptions{}, err
 	}
 
-	return nil
+	return options, nil
 }
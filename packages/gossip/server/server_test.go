@@ -123,6 +123,8 @@ func TestConnect(t *testing.T) {
 		c, err := transA.AcceptPeer(getPeer(transB))
 		assert.NoError(t, err)
 		if assert.NotNil(t, c) {
+			assert.True(t, c.(*Conn).Checksum())
+			assert.True(t, c.(*Conn).Heartbeat())
 			_ = c.Close()
 		}
 	}()
@@ -132,6 +134,8 @@ func TestConnect(t *testing.T) {
 		c, err := transB.DialPeer(getPeer(transA))
 		assert.NoError(t, err)
 		if assert.NotNil(t, c) {
+			assert.True(t, c.(*Conn).Checksum())
+			assert.True(t, c.(*Conn).Heartbeat())
 			_ = c.Close()
 		}
 	}()
@@ -139,6 +143,52 @@ func TestConnect(t *testing.T) {
 	wg.Wait()
 }
 
+func TestChecksumNegotiation(t *testing.T) {
+	tests := []struct {
+		name                       string
+		acceptChecksum             bool
+		dialChecksum               bool
+		expectedChecksumNegotiated bool
+	}{
+		{"both supported", true, true, true},
+		{"accepting peer not supported", false, true, false},
+		{"dialing peer not supported", true, false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			transA, closeA := newTestServer(t, "A", Checksum(test.acceptChecksum))
+			defer closeA()
+			transB, closeB := newTestServer(t, "B", Checksum(test.dialChecksum))
+			defer closeB()
+
+			var wg sync.WaitGroup
+			wg.Add(2)
+
+			go func() {
+				defer wg.Done()
+				c, err := transA.AcceptPeer(getPeer(transB))
+				assert.NoError(t, err)
+				if assert.NotNil(t, c) {
+					assert.Equal(t, test.expectedChecksumNegotiated, c.(*Conn).Checksum())
+					_ = c.Close()
+				}
+			}()
+			time.Sleep(graceTime)
+			go func() {
+				defer wg.Done()
+				c, err := transB.DialPeer(getPeer(transA))
+				assert.NoError(t, err)
+				if assert.NotNil(t, c) {
+					assert.Equal(t, test.expectedChecksumNegotiated, c.(*Conn).Checksum())
+					_ = c.Close()
+				}
+			}()
+
+			wg.Wait()
+		})
+	}
+}
+
 func TestWrongConnect(t *testing.T) {
 	transA, closeA := newTestServer(t, "A")
 	defer closeA()
@@ -171,7 +221,7 @@ func newTestDB(t require.TestingT) *peer.DB {
 	return db
 }
 
-func newTestServer(t require.TestingT, name string) (*TCP, func()) {
+func newTestServer(t require.TestingT, name string, opts ...Option) (*TCP, func()) {
 	l := log.Named(name)
 
 	laddr, err := net.ResolveTCPAddr("tcp", "127.0.0.1:0")
@@ -186,7 +236,7 @@ func newTestServer(t require.TestingT, name string) (*TCP, func()) {
 	local, err := peer.NewLocal(lis.Addr().(*net.TCPAddr).IP, services, newTestDB(t))
 	require.NoError(t, err)
 
-	srv := ServeTCP(local, lis, l)
+	srv := ServeTCP(local, lis, l, opts...)
 
 	teardown := func() {
 		srv.Close()
@@ -1,8 +1,10 @@
 package ledgerstate
 
 import (
+	"bytes"
 	"container/list"
 	"fmt"
+	"sort"
 	"strconv"
 	"sync"
 
@@ -244,10 +246,29 @@ func (u *UTXODAG) Consumers(outputID OutputID) (cachedConsumers CachedConsumers)
 }
 
 // LoadSnapshot creates a set of outputs in the UTXO-DAG, that are forming the genesis for future transactions.
+// The transactions and addresses are processed in lexicographical order, so that every node assigns the same OutputIDs.
 func (u *UTXODAG) LoadSnapshot(snapshot map[TransactionID]map[Address]*ColoredBalances) {
+	transactionIDs := make([]TransactionID, 0, len(snapshot))
+	for transactionID := range snapshot {
+		transactionIDs = append(transactionIDs, transactionID)
+	}
+	sort.Slice(transactionIDs, func(i, j int) bool {
+		return bytes.Compare(transactionIDs[i][:], transactionIDs[j][:]) < 0
+	})
+
 	index := uint16(0)
-	for transactionID, addressBalance := range snapshot {
-		for address, balance := range addressBalance {
+	for _, transactionID := range transactionIDs {
+		addressBalance := snapshot[transactionID]
+		addresses := make([]Address, 0, len(addressBalance))
+		for address := range addressBalance {
+			addresses = append(addresses, address)
+		}
+		sort.Slice(addresses, func(i, j int) bool {
+			return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) < 0
+		})
+
+		for _, address := range addresses {
+			balance := addressBalance[address]
 			output := NewSigLockedColoredOutput(balance, address)
 			output.SetID(NewOutputID(transactionID, index))
 			cachedOutput, stored := u.outputStorage.StoreIfAbsent(output)
@@ -786,6 +786,39 @@ func TestAddressOutputMapping(t *testing.T) {
 	assert.Equal(t, 1, len(res))
 }
 
+func TestLoadSnapshotOutputIDs(t *testing.T) {
+	wallets := createWallets(10)
+	snapshot := map[TransactionID]map[Address]*ColoredBalances{
+		GenesisTransactionID: {},
+	}
+	for _, w := range wallets {
+		snapshot[GenesisTransactionID][w.address] = NewColoredBalances(map[Color]uint64{ColorIOTA: 100})
+	}
+
+	// every node has to assign the same OutputIDs to the snapshot outputs
+	outputIDs := func() map[string]OutputID {
+		branchDAG, utxoDAG := setupDependencies(t)
+		defer branchDAG.Shutdown()
+		defer utxoDAG.Shutdown()
+
+		utxoDAG.LoadSnapshot(snapshot)
+		result := make(map[string]OutputID)
+		for _, w := range wallets {
+			cachedMappings := utxoDAG.AddressOutputMapping(w.address)
+			cachedMappings.Consume(func(addressOutputMapping *AddressOutputMapping) {
+				result[w.address.Base58()] = addressOutputMapping.OutputID()
+			})
+		}
+		return result
+	}
+
+	expected := outputIDs()
+	assert.Len(t, expected, len(wallets))
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, expected, outputIDs())
+	}
+}
+
 func setupDependencies(t *testing.T) (*BranchDAG, *UTXODAG) {
 	store := mapdb.NewMapDB()
 	branchDAG := NewBranchDAG(store)
@@ -1,6 +1,7 @@
 package mana
 
 import (
+	"context"
 	"sort"
 	"sync"
 	"time"
@@ -39,6 +40,21 @@ func (a *AccessBaseManaVector) Has(nodeID identity.ID) bool {
 func (a *AccessBaseManaVector) Book(txInfo *TxInfo) {
 	a.Lock()
 	defer a.Unlock()
+	a.book(txInfo)
+}
+
+// BookBatch books mana for a batch of transactions under a single lock acquisition.
+// The transactions are booked in the given order, so the result equals calling Book for each of them.
+func (a *AccessBaseManaVector) BookBatch(txInfos []*TxInfo) {
+	a.Lock()
+	defer a.Unlock()
+	for _, txInfo := range txInfos {
+		a.book(txInfo)
+	}
+}
+
+// book books mana for a transaction. Not concurrency safe.
+func (a *AccessBaseManaVector) book(txInfo *TxInfo) {
 	pledgeNodeID := txInfo.PledgeID[a.Type()]
 	if _, exist := a.vector[pledgeNodeID]; !exist {
 		// first time we see this node
@@ -74,12 +90,25 @@ func (a *AccessBaseManaVector) Update(nodeID identity.ID, t time.Time) error {
 
 // UpdateAll updates all entries in the base mana vector wrt to `t`.
 func (a *AccessBaseManaVector) UpdateAll(t time.Time) error {
+	return a.UpdateAllCtx(context.Background(), t)
+}
+
+// UpdateAllCtx updates all entries in the base mana vector wrt to `t`. The context is checked periodically and, once it
+// is done, its error is returned. The entries updated until then stay updated.
+func (a *AccessBaseManaVector) UpdateAllCtx(ctx context.Context, t time.Time) error {
 	a.Lock()
 	defer a.Unlock()
+	var updated int
 	for nodeID := range a.vector {
+		if updated%updateAllCtxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
 		if err := a.update(nodeID, t); err != nil {
 			return err
 		}
+		updated++
 	}
 	return nil
 }
@@ -91,6 +120,34 @@ func (a *AccessBaseManaVector) GetMana(nodeID identity.ID, optionalUpdateTime ..
 	return a.getMana(nodeID, optionalUpdateTime...)
 }
 
+// GetManaWithWeights returns the mana of a node with the given access and consensus weights.
+// Access base mana vectors only keep track of Effective Base Mana 2, the consensus component is thus zero.
+func (a *AccessBaseManaVector) GetManaWithWeights(nodeID identity.ID, accessWeight, consensusWeight float64, optionalUpdateTime ...time.Time) (float64, time.Time, error) {
+	if err := validateWeights(accessWeight, consensusWeight); err != nil {
+		return 0.0, time.Now(), err
+	}
+	a.Lock()
+	defer a.Unlock()
+	effMana, t, err := a.getMana(nodeID, optionalUpdateTime...)
+	if err != nil {
+		return 0.0, t, err
+	}
+	return effMana * accessWeight, t, nil
+}
+
+// ProjectMana returns the effective mana of a node at the given time, applying the decay from the time of its last
+// update, without updating the vector. It returns ErrProjectionBeforeLastUpdate, if the time predates the last update.
+func (a *AccessBaseManaVector) ProjectMana(nodeID identity.ID, at time.Time) (float64, error) {
+	a.RLock()
+	defer a.RUnlock()
+	baseMana, exist := a.vector[nodeID]
+	if !exist {
+		return 0.0, ErrNodeNotFoundInBaseManaVector
+	}
+	projected := *baseMana
+	return projectMana(&projected, at)
+}
+
 // GetManaMap returns mana perception of the node..
 func (a *AccessBaseManaVector) GetManaMap(optionalUpdateTime ...time.Time) (res NodeMap, t time.Time, err error) {
 	a.Lock()
@@ -111,6 +168,34 @@ func (a *AccessBaseManaVector) GetManaMap(optionalUpdateTime ...time.Time) (res
 	return
 }
 
+// GetManaMapParallel returns mana perception of the node, computed by the given number of workers. A single worker
+// computes the map serially like GetManaMap. The workers only project the mana, the vector is updated and the Updated
+// events are triggered afterwards by the calling goroutine.
+func (a *AccessBaseManaVector) GetManaMapParallel(workers int, optionalUpdateTime ...time.Time) (res NodeMap, t time.Time, err error) {
+	a.Lock()
+	defer a.Unlock()
+	t = time.Now()
+	if len(optionalUpdateTime) > 0 {
+		t = optionalUpdateTime[0]
+	}
+	nodeIDs := make([]identity.ID, 0, len(a.vector))
+	for ID := range a.vector {
+		nodeIDs = append(nodeIDs, ID)
+	}
+	res, err = parallelManaMap(nodeIDs, workers, func(nodeID identity.ID) (projectedMana, error) {
+		projected := *a.vector[nodeID]
+		if err := projected.update(t); err != nil {
+			return projectedMana{mana: projected.EffectiveValue()}, nil
+		}
+		return projectedMana{mana: projected.EffectiveValue(), updated: &projected, updateSucceeded: true}, nil
+	}, func(nodeID identity.ID, updated BaseMana, _ bool) {
+		oldMana := *a.vector[nodeID]
+		*a.vector[nodeID] = *updated.(*AccessBaseMana)
+		Events().Updated.Trigger(&UpdatedEvent{nodeID, &oldMana, a.vector[nodeID], a.Type()})
+	})
+	return
+}
+
 // GetHighestManaNodes returns the n highest mana nodes in descending order.
 // It also updates the mana values for each node.
 // If n is zero, it returns all nodes.
@@ -232,8 +317,17 @@ func (a *AccessBaseManaVector) ToPersistables() []*PersistableBaseMana {
 	return result
 }
 
-// FromPersistable fills the AccessBaseManaVector from persistable mana objects.
-func (a *AccessBaseManaVector) FromPersistable(p *PersistableBaseMana) (err error) {
+// FromPersistable fills the AccessBaseManaVector from persistable mana objects, replacing existing entries.
+func (a *AccessBaseManaVector) FromPersistable(p *PersistableBaseMana) error {
+	return a.FromPersistableWithStrategy(p, Replace)
+}
+
+// FromPersistableWithStrategy fills the AccessBaseManaVector from persistable mana objects, merging existing entries with the
+// given strategy.
+func (a *AccessBaseManaVector) FromPersistableWithStrategy(p *PersistableBaseMana, strategy MergeStrategy) (err error) {
+	if err = validateMergeStrategy(strategy); err != nil {
+		return
+	}
 	if p.ManaType != AccessMana {
 		err = xerrors.Errorf("persistable mana object has type %s instead of %s", p.ManaType.String(), AccessMana.String())
 		return
@@ -246,13 +340,25 @@ func (a *AccessBaseManaVector) FromPersistable(p *PersistableBaseMana) (err erro
 		err = xerrors.Errorf("persistable mana object has %d effective values instead of 1", len(p.EffectiveValues))
 		return
 	}
-	a.Lock()
-	defer a.Unlock()
-	a.vector[p.NodeID] = &AccessBaseMana{
+	imported := &AccessBaseMana{
 		BaseMana2:          p.BaseValues[0],
 		EffectiveBaseMana2: p.EffectiveValues[0],
 		LastUpdated:        p.LastUpdated,
 	}
+	a.Lock()
+	defer a.Unlock()
+	existing, ok := a.vector[p.NodeID]
+	if !ok || strategy == Replace {
+		a.vector[p.NodeID] = imported
+		return
+	}
+	if strategy == Sum {
+		if err = alignUpdateTimes(existing, imported); err != nil {
+			return
+		}
+		existing.BaseMana2 += imported.BaseMana2
+		existing.EffectiveBaseMana2 += imported.EffectiveBaseMana2
+	}
 	return
 }
 
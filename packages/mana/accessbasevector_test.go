@@ -430,6 +430,83 @@ func TestAccessBaseManaVector_GetMana(t *testing.T) {
 	assert.InDelta(t, 1.0, mana, delta)
 }
 
+func TestAccessBaseManaVector_ProjectMana(t *testing.T) {
+	bmv, err := NewBaseManaVector(AccessMana)
+	assert.NoError(t, err)
+	randID := randNodeID()
+	_, err = bmv.ProjectMana(randID, time.Now())
+	assert.ErrorIs(t, err, ErrNodeNotFoundInBaseManaVector)
+
+	now := time.Now()
+	lastUpdated := now.Add(-time.Hour)
+	bmv.SetMana(randID, &AccessBaseMana{
+		BaseMana2:          1.0,
+		EffectiveBaseMana2: 1.0,
+		LastUpdated:        lastUpdated,
+	})
+	expected := func(at time.Time) float64 {
+		bm := &AccessBaseMana{BaseMana2: 1.0, EffectiveBaseMana2: 1.0, LastUpdated: lastUpdated}
+		assert.NoError(t, bm.update(at))
+		return bm.EffectiveValue()
+	}
+
+	// at the last update, the mana is not decayed
+	mana, err := bmv.ProjectMana(randID, lastUpdated)
+	assert.NoError(t, err)
+	assert.InDelta(t, 1.0, mana, delta)
+
+	// past, present and future projections decay the mana further
+	past, err := bmv.ProjectMana(randID, now.Add(-30*time.Minute))
+	assert.NoError(t, err)
+	assert.InDelta(t, expected(now.Add(-30*time.Minute)), past, delta)
+	present, err := bmv.ProjectMana(randID, now)
+	assert.NoError(t, err)
+	assert.InDelta(t, expected(now), present, delta)
+	future, err := bmv.ProjectMana(randID, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.InDelta(t, expected(now.Add(time.Hour)), future, delta)
+	assert.Greater(t, past, present)
+	assert.Greater(t, present, future)
+
+	// projecting does not update the vector
+	bmv.ForEach(func(ID identity.ID, bm BaseMana) bool {
+		assert.Equal(t, lastUpdated, bm.LastUpdate())
+		return true
+	})
+	mana, _, err = bmv.GetMana(randID, now)
+	assert.NoError(t, err)
+	assert.InDelta(t, present, mana, delta)
+
+	_, err = bmv.ProjectMana(randID, lastUpdated.Add(-time.Second))
+	assert.ErrorIs(t, err, ErrProjectionBeforeLastUpdate)
+}
+
+func TestAccessBaseManaVector_GetManaWithWeights(t *testing.T) {
+	bmv, err := NewBaseManaVector(AccessMana)
+	assert.NoError(t, err)
+	randID := randNodeID()
+	now := time.Now()
+	bmv.SetMana(randID, &AccessBaseMana{
+		BaseMana2:          2.0,
+		EffectiveBaseMana2: 2.0,
+		LastUpdated:        now,
+	})
+
+	defaultMana, _, err := bmv.GetMana(randID, now)
+	assert.NoError(t, err)
+	mana, _, err := bmv.GetManaWithWeights(randID, 1, 0, now)
+	assert.NoError(t, err)
+	assert.InDelta(t, defaultMana, mana, delta)
+
+	// there is no consensus component in an access vector
+	mana, _, err = bmv.GetManaWithWeights(randID, 0.25, 0.75, now)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.5, mana, delta)
+
+	_, _, err = bmv.GetManaWithWeights(randID, 0.5, 0.4, now)
+	assert.ErrorIs(t, err, ErrInvalidWeightParameter)
+}
+
 func TestAccessBaseManaVector_ForEach(t *testing.T) {
 	bmv, err := NewBaseManaVector(AccessMana)
 	assert.NoError(t, err)
@@ -1,12 +1,17 @@
 package mana
 
 import (
+	"context"
+	"math"
 	"time"
 
 	"github.com/iotaledger/hive.go/identity"
 	"golang.org/x/xerrors"
 )
 
+// updateAllCtxCheckInterval defines after how many updated entries UpdateAllCtx checks whether its context is done.
+const updateAllCtxCheckInterval = 1000
+
 // BaseManaVector is an interface for vectors that store base mana values of nodes in the network.
 type BaseManaVector interface {
 	// Type returns the type of the base mana vector (access/consensus).
@@ -17,14 +22,24 @@ type BaseManaVector interface {
 	Has(identity.ID) bool
 	// Book books mana into the base mana vector.
 	Book(*TxInfo)
+	// BookBatch books mana for multiple transactions in the given order.
+	BookBatch([]*TxInfo)
 	// Update updates the mana entries for a particular node wrt time.
 	Update(identity.ID, time.Time) error
 	// UpdateAll updates all entries in the base mana vector wrt to time.
 	UpdateAll(time.Time) error
+	// UpdateAllCtx updates all entries in the base mana vector wrt to time, aborting once the context is done.
+	UpdateAllCtx(context.Context, time.Time) error
 	// GetMana returns the mana value of a node with default weights.
 	GetMana(identity.ID, ...time.Time) (float64, time.Time, error)
+	// GetManaWithWeights returns the mana value of a node with the given access and consensus weights.
+	GetManaWithWeights(id identity.ID, accessWeight, consensusWeight float64, optionalUpdateTime ...time.Time) (float64, time.Time, error)
+	// ProjectMana returns the mana value of a node at the given time without updating the vector.
+	ProjectMana(id identity.ID, at time.Time) (float64, error)
 	// GetManaMap returns the map derived from the vector.
 	GetManaMap(...time.Time) (NodeMap, time.Time, error)
+	// GetManaMapParallel returns the map derived from the vector, computed by the given number of workers.
+	GetManaMapParallel(int, ...time.Time) (NodeMap, time.Time, error)
 	// GetHighestManaNodes returns the n highest mana nodes in descending order.
 	GetHighestManaNodes(uint) ([]Node, time.Time, error)
 	// GetHighestManaNodesFraction returns the highest mana that own 'p' percent of total mana.
@@ -35,8 +50,11 @@ type BaseManaVector interface {
 	ForEach(func(identity.ID, BaseMana) bool)
 	// ToPersistables converts the BaseManaVector to a list of persistable mana objects.
 	ToPersistables() []*PersistableBaseMana
-	// FromPersistable fills the BaseManaVector from persistable mana objects.
+	// FromPersistable fills the BaseManaVector from persistable mana objects, replacing existing entries.
 	FromPersistable(*PersistableBaseMana) error
+	// FromPersistableWithStrategy fills the BaseManaVector from persistable mana objects, merging existing entries
+	// with the given strategy.
+	FromPersistableWithStrategy(*PersistableBaseMana, MergeStrategy) error
 	// RemoveZeroNodes removes all zero mana nodes from the mana vector.
 	RemoveZeroNodes()
 }
@@ -82,3 +100,54 @@ func NewResearchBaseManaVector(vectorType Type, targetMana Type, weight float64)
 		return nil, xerrors.Errorf("error while creating base mana vector with type %d: %w", vectorType, ErrUnknownManaType)
 	}
 }
+
+// weightSumTolerance is the tolerance allowed when checking that mana weights sum up to 1.
+const weightSumTolerance = 1e-9
+
+// validateWeights checks that the access and consensus weights are within [0,1] and sum up to 1.
+func validateWeights(accessWeight, consensusWeight float64) error {
+	if accessWeight < 0 || accessWeight > 1 || consensusWeight < 0 || consensusWeight > 1 {
+		return xerrors.Errorf("access weight %f and consensus weight %f must be within [0,1]: %w", accessWeight, consensusWeight, ErrInvalidWeightParameter)
+	}
+	if math.Abs(accessWeight+consensusWeight-1) > weightSumTolerance {
+		return xerrors.Errorf("access weight %f and consensus weight %f must sum up to 1: %w", accessWeight, consensusWeight, ErrInvalidWeightParameter)
+	}
+	return nil
+}
+
+// Equal tells whether the given base mana vectors contain the same nodes and whether the base mana values of each
+// node differ by at most the given tolerance.
+func Equal(a, b BaseManaVector, tolerance float64) bool {
+	if a.Size() != b.Size() {
+		return false
+	}
+	bValues := make(map[identity.ID]float64, b.Size())
+	b.ForEach(func(id identity.ID, bm BaseMana) bool {
+		bValues[id] = bm.BaseValue()
+		return true
+	})
+
+	equal := true
+	a.ForEach(func(id identity.ID, bm BaseMana) bool {
+		bValue, ok := bValues[id]
+		if !ok || math.Abs(bm.BaseValue()-bValue) > tolerance {
+			equal = false
+		}
+		return equal
+	})
+	return equal
+}
+
+// projectMana updates the given base mana to the given time and returns its effective value. The base mana must be a
+// copy, as it is modified. It returns ErrProjectionBeforeLastUpdate, if the time predates the last update.
+func projectMana(baseMana BaseMana, at time.Time) (float64, error) {
+	if at.Before(baseMana.LastUpdate()) {
+		return 0.0, xerrors.Errorf("%w: %s is before %s", ErrProjectionBeforeLastUpdate, at, baseMana.LastUpdate())
+	}
+	if at.After(baseMana.LastUpdate()) {
+		if err := baseMana.update(at); err != nil {
+			return 0.0, err
+		}
+	}
+	return baseMana.EffectiveValue(), nil
+}
@@ -0,0 +1,118 @@
+package mana
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func TestBaseManaVector_BookBatch(t *testing.T) {
+	nodeA, nodeB := randNodeID(), randNodeID()
+	now := time.Now()
+	genesisOutput := ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 0)
+	txInfos := []*TxInfo{
+		{
+			TimeStamp:     now,
+			TransactionID: randomTxID(),
+			TotalBalance:  10.0,
+			PledgeID:      map[Type]identity.ID{AccessMana: nodeA, ConsensusMana: nodeA},
+			InputInfos: []InputInfo{{
+				TimeStamp: now.Add(-time.Hour),
+				Amount:    10.0,
+				InputID:   genesisOutput,
+			}},
+		},
+		{
+			TimeStamp:     now.Add(time.Hour),
+			TransactionID: randomTxID(),
+			TotalBalance:  10.0,
+			PledgeID:      map[Type]identity.ID{AccessMana: nodeB, ConsensusMana: nodeB},
+			InputInfos: []InputInfo{{
+				TimeStamp: now,
+				Amount:    10.0,
+				PledgeID:  map[Type]identity.ID{AccessMana: nodeA, ConsensusMana: nodeA},
+				InputID:   ledgerstate.OutputID{1},
+			}},
+		},
+		{
+			TimeStamp:     now.Add(2 * time.Hour),
+			TransactionID: randomTxID(),
+			TotalBalance:  10.0,
+			PledgeID:      map[Type]identity.ID{AccessMana: nodeA, ConsensusMana: nodeA},
+			InputInfos: []InputInfo{{
+				TimeStamp: now.Add(time.Hour),
+				Amount:    10.0,
+				PledgeID:  map[Type]identity.ID{AccessMana: nodeB, ConsensusMana: nodeB},
+				InputID:   ledgerstate.OutputID{2},
+			}},
+		},
+	}
+
+	newVectors := map[string]func() (BaseManaVector, error){
+		"access":    func() (BaseManaVector, error) { return NewBaseManaVector(AccessMana) },
+		"consensus": func() (BaseManaVector, error) { return NewBaseManaVector(ConsensusMana) },
+		"weighted": func() (BaseManaVector, error) {
+			return NewResearchBaseManaVector(WeightedMana, ConsensusMana, Mixed)
+		},
+	}
+	for name, newVector := range newVectors {
+		t.Run(name, func(t *testing.T) {
+			sequential, err := newVector()
+			require.NoError(t, err)
+			batched, err := newVector()
+			require.NoError(t, err)
+
+			for _, txInfo := range txInfos {
+				sequential.Book(txInfo)
+			}
+			batched.BookBatch(txInfos)
+
+			assert.Equal(t, sequential.Size(), batched.Size())
+			assert.Equal(t, baseManaValues(sequential), baseManaValues(batched))
+		})
+	}
+}
+
+func baseManaValues(bmv BaseManaVector) map[identity.ID][3]float64 {
+	values := make(map[identity.ID][3]float64)
+	bmv.ForEach(func(id identity.ID, bm BaseMana) bool {
+		values[id] = [3]float64{bm.BaseValue(), bm.EffectiveValue(), float64(bm.LastUpdate().UnixNano())}
+		return true
+	})
+	return values
+}
+
+func TestEqual(t *testing.T) {
+	const tolerance = 1e-6
+	nodeA, nodeB := randNodeID(), randNodeID()
+	newVector := func(values map[identity.ID]float64) BaseManaVector {
+		bmv, err := NewBaseManaVector(ConsensusMana)
+		require.NoError(t, err)
+		for id, value := range values {
+			bmv.SetMana(id, &ConsensusBaseMana{BaseMana1: value})
+		}
+		return bmv
+	}
+	vector := newVector(map[identity.ID]float64{nodeA: 1, nodeB: 2})
+
+	t.Run("equal", func(t *testing.T) {
+		assert.True(t, Equal(vector, newVector(map[identity.ID]float64{nodeA: 1, nodeB: 2}), tolerance))
+		assert.True(t, Equal(vector, vector, tolerance))
+	})
+
+	t.Run("within tolerance", func(t *testing.T) {
+		assert.True(t, Equal(vector, newVector(map[identity.ID]float64{nodeA: 1 + tolerance/2, nodeB: 2 - tolerance/2}), tolerance))
+		assert.False(t, Equal(vector, newVector(map[identity.ID]float64{nodeA: 1 + 2*tolerance, nodeB: 2}), tolerance))
+	})
+
+	t.Run("mismatched node set", func(t *testing.T) {
+		assert.False(t, Equal(vector, newVector(map[identity.ID]float64{nodeA: 1}), tolerance))
+		assert.False(t, Equal(vector, newVector(map[identity.ID]float64{nodeA: 1, randNodeID(): 2}), tolerance))
+		assert.False(t, Equal(vector, newVector(map[identity.ID]float64{nodeA: 1, nodeB: 2, randNodeID(): 3}), tolerance))
+	})
+}
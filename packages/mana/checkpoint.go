@@ -0,0 +1,215 @@
+package mana
+
+import (
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/marshalutil"
+	"golang.org/x/xerrors"
+)
+
+const (
+	checkpointFilePrefix = "mana-checkpoint-"
+	checkpointFileSuffix = ".bin"
+	checkpointTempSuffix = ".tmp"
+)
+
+// CheckpointStore persists snapshots of the base mana vectors to a directory on disk.
+// Checkpoints are written atomically and only the most recent ones are kept.
+type CheckpointStore struct {
+	directory string
+	retention int
+	mu        sync.Mutex
+}
+
+// NewCheckpointStore creates a new CheckpointStore writing to the given directory and keeping at most retention
+// checkpoints. A retention smaller than 1 keeps only the latest checkpoint.
+func NewCheckpointStore(directory string, retention int) *CheckpointStore {
+	if retention < 1 {
+		retention = 1
+	}
+	return &CheckpointStore{
+		directory: directory,
+		retention: retention,
+	}
+}
+
+// Write writes a new checkpoint containing the given persistable mana objects taken at time t.
+// The checkpoint is first written to a temporary file that is then renamed, so that a crash never leaves a partially
+// written checkpoint behind. Checkpoints exceeding the retention are removed afterwards.
+func (c *CheckpointStore) Write(t time.Time, persistables []*PersistableBaseMana) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.MkdirAll(c.directory, 0o755); err != nil {
+		return xerrors.Errorf("failed to create checkpoint directory %s: %w", c.directory, err)
+	}
+
+	tmpFile, err := ioutil.TempFile(c.directory, checkpointFilePrefix+"*"+checkpointTempSuffix)
+	if err != nil {
+		return xerrors.Errorf("failed to create temporary checkpoint file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err = tmpFile.Write(checkpointBytes(t, persistables)); err != nil {
+		_ = tmpFile.Close()
+		return xerrors.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err = tmpFile.Sync(); err != nil {
+		_ = tmpFile.Close()
+		return xerrors.Errorf("failed to sync checkpoint: %w", err)
+	}
+	if err = tmpFile.Close(); err != nil {
+		return xerrors.Errorf("failed to close checkpoint: %w", err)
+	}
+	if err = os.Rename(tmpFile.Name(), filepath.Join(c.directory, checkpointFileName(t))); err != nil {
+		return xerrors.Errorf("failed to rename checkpoint: %w", err)
+	}
+
+	return c.prune()
+}
+
+// LoadLatest returns the content of the most recent valid checkpoint together with the time it was taken.
+// Checkpoints that cannot be read or fail verification are skipped in favor of older ones.
+func (c *CheckpointStore) LoadLatest() (persistables []*PersistableBaseMana, t time.Time, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	files, err := c.checkpointFiles()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	for i := len(files) - 1; i >= 0; i-- {
+		data, readErr := ioutil.ReadFile(filepath.Join(c.directory, files[i]))
+		if readErr != nil {
+			continue
+		}
+		if persistables, t, err = checkpointFromBytes(data); err == nil {
+			return persistables, t, nil
+		}
+	}
+	return nil, time.Time{}, ErrNoValidCheckpoint
+}
+
+// Checkpoints returns the file names of the stored checkpoints, ordered from oldest to newest.
+func (c *CheckpointStore) Checkpoints() ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.checkpointFiles()
+}
+
+// prune removes the oldest checkpoints exceeding the retention.
+func (c *CheckpointStore) prune() error {
+	files, err := c.checkpointFiles()
+	if err != nil {
+		return err
+	}
+	for len(files) > c.retention {
+		if err = os.Remove(filepath.Join(c.directory, files[0])); err != nil {
+			return xerrors.Errorf("failed to remove checkpoint %s: %w", files[0], err)
+		}
+		files = files[1:]
+	}
+	return nil
+}
+
+func (c *CheckpointStore) checkpointFiles() ([]string, error) {
+	entries, err := ioutil.ReadDir(c.directory)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, xerrors.Errorf("failed to read checkpoint directory %s: %w", c.directory, err)
+	}
+
+	timestamps := make(map[string]int64)
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ts, ok := checkpointTimestamp(entry.Name())
+		if !ok {
+			continue
+		}
+		timestamps[entry.Name()] = ts
+		files = append(files, entry.Name())
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return timestamps[files[i]] < timestamps[files[j]]
+	})
+	return files, nil
+}
+
+func checkpointFileName(t time.Time) string {
+	return checkpointFilePrefix + strconv.FormatInt(t.UnixNano(), 10) + checkpointFileSuffix
+}
+
+func checkpointTimestamp(fileName string) (int64, bool) {
+	if !strings.HasPrefix(fileName, checkpointFilePrefix) || !strings.HasSuffix(fileName, checkpointFileSuffix) {
+		return 0, false
+	}
+	ts, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(fileName, checkpointFilePrefix), checkpointFileSuffix), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return ts, true
+}
+
+// checkpointBytes encodes a checkpoint as a CRC32 checksum followed by the time, the number of entries and the
+// entries themselves.
+func checkpointBytes(t time.Time, persistables []*PersistableBaseMana) []byte {
+	payload := marshalutil.New()
+	payload.WriteTime(t)
+	payload.WriteUint32(uint32(len(persistables)))
+	for _, p := range persistables {
+		payload.WriteBytes(p.Bytes())
+	}
+
+	marshalUtil := marshalutil.New()
+	marshalUtil.WriteUint32(crc32.ChecksumIEEE(payload.Bytes()))
+	marshalUtil.WriteBytes(payload.Bytes())
+	return marshalUtil.Bytes()
+}
+
+func checkpointFromBytes(data []byte) (persistables []*PersistableBaseMana, t time.Time, err error) {
+	marshalUtil := marshalutil.New(data)
+	checksum, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, time.Time{}, xerrors.Errorf("failed to parse checksum (%v): %w", err, ErrCorruptCheckpoint)
+	}
+	if crc32.ChecksumIEEE(data[marshalutil.Uint32Size:]) != checksum {
+		return nil, time.Time{}, xerrors.Errorf("checksum mismatch: %w", ErrCorruptCheckpoint)
+	}
+
+	if t, err = marshalUtil.ReadTime(); err != nil {
+		return nil, time.Time{}, xerrors.Errorf("failed to parse time (%v): %w", err, ErrCorruptCheckpoint)
+	}
+	count, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, time.Time{}, xerrors.Errorf("failed to parse entry count (%v): %w", err, ErrCorruptCheckpoint)
+	}
+	// the entries are parsed from their own slices, as Parse caches the bytes read from the start of its input
+	offset := marshalUtil.ReadOffset()
+	persistables = make([]*PersistableBaseMana, 0, count)
+	for i := uint32(0); i < count; i++ {
+		p, consumedBytes, parseErr := FromBytes(data[offset:])
+		if parseErr != nil {
+			return nil, time.Time{}, xerrors.Errorf("failed to parse entry %d (%v): %w", i, parseErr, ErrCorruptCheckpoint)
+		}
+		persistables = append(persistables, p)
+		offset += consumedBytes
+	}
+	if offset != len(data) {
+		return nil, time.Time{}, xerrors.Errorf("unexpected trailing bytes: %w", ErrCorruptCheckpoint)
+	}
+	return persistables, t, nil
+}
@@ -0,0 +1,87 @@
+package mana
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointStore_LoadLatest(t *testing.T) {
+	store := NewCheckpointStore(t.TempDir(), 3)
+
+	now := time.Now()
+	first := []*PersistableBaseMana{newCheckpointPersistable(1, now)}
+	second := []*PersistableBaseMana{newCheckpointPersistable(1, now), newCheckpointPersistable(2, now)}
+	require.NoError(t, store.Write(now, first))
+	require.NoError(t, store.Write(now.Add(time.Minute), second))
+
+	persistables, checkpointTime, err := store.LoadLatest()
+	require.NoError(t, err)
+	assert.True(t, now.Add(time.Minute).Equal(checkpointTime))
+	require.Len(t, persistables, len(second))
+	for i := range second {
+		assert.Equal(t, second[i].Bytes(), persistables[i].Bytes())
+	}
+}
+
+func TestCheckpointStore_SkipCorrupt(t *testing.T) {
+	dir := t.TempDir()
+	store := NewCheckpointStore(dir, 3)
+
+	now := time.Now()
+	valid := []*PersistableBaseMana{newCheckpointPersistable(1, now)}
+	require.NoError(t, store.Write(now, valid))
+	require.NoError(t, store.Write(now.Add(time.Minute), []*PersistableBaseMana{newCheckpointPersistable(2, now)}))
+
+	// flip a byte in the latest checkpoint
+	files, err := store.Checkpoints()
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	latest := filepath.Join(dir, files[1])
+	data, err := ioutil.ReadFile(latest)
+	require.NoError(t, err)
+	data[len(data)-1] ^= 0xff
+	require.NoError(t, ioutil.WriteFile(latest, data, 0o600))
+
+	persistables, checkpointTime, err := store.LoadLatest()
+	require.NoError(t, err)
+	assert.True(t, now.Equal(checkpointTime))
+	require.Len(t, persistables, 1)
+	assert.Equal(t, valid[0].Bytes(), persistables[0].Bytes())
+
+	// corrupt the remaining one as well
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, files[0]), []byte{1, 2, 3}, 0o600))
+	_, _, err = store.LoadLatest()
+	assert.ErrorIs(t, err, ErrNoValidCheckpoint)
+}
+
+func TestCheckpointStore_Retention(t *testing.T) {
+	store := NewCheckpointStore(t.TempDir(), 2)
+
+	now := time.Now()
+	for i := 0; i < 4; i++ {
+		require.NoError(t, store.Write(now.Add(time.Duration(i)*time.Minute), nil))
+	}
+
+	files, err := store.Checkpoints()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		checkpointFileName(now.Add(2 * time.Minute)),
+		checkpointFileName(now.Add(3 * time.Minute)),
+	}, files)
+}
+
+func newCheckpointPersistable(id byte, t time.Time) *PersistableBaseMana {
+	return &PersistableBaseMana{
+		ManaType:        AccessMana,
+		BaseValues:      []float64{float64(id)},
+		EffectiveValues: []float64{float64(id) / 2},
+		LastUpdated:     t,
+		NodeID:          identity.ID{id},
+	}
+}
@@ -0,0 +1,69 @@
+package mana
+
+import (
+	"bytes"
+	"math/rand"
+	"sort"
+
+	"github.com/iotaledger/hive.go/identity"
+	"golang.org/x/xerrors"
+)
+
+// SelectCommittee selects size distinct nodes of the given base mana vector with a probability proportional to their
+// base mana. The selection is deterministic for the same vector and seed. Once the selected nodes hold all the mana,
+// the remaining members are selected uniformly among the nodes without mana.
+func SelectCommittee(v BaseManaVector, size int, seed int64) ([]identity.ID, error) {
+	if size <= 0 || size > v.Size() {
+		return nil, xerrors.Errorf("can't select a committee of %d out of %d nodes: %w", size, v.Size(), ErrInvalidCommitteeSize)
+	}
+
+	// the candidates are sorted by their ID, as the iteration order of the vector is random
+	candidates := make([]identity.ID, 0, v.Size())
+	candidatesMana := make(map[identity.ID]float64, v.Size())
+	// the number of candidates holding mana
+	var manaHolders int
+	v.ForEach(func(id identity.ID, bm BaseMana) bool {
+		candidates = append(candidates, id)
+		candidatesMana[id] = bm.BaseValue()
+		if bm.BaseValue() > 0 {
+			manaHolders++
+		}
+		return true
+	})
+	sort.Slice(candidates, func(i, j int) bool {
+		return bytes.Compare(candidates[i].Bytes(), candidates[j].Bytes()) < 0
+	})
+
+	rng := rand.New(rand.NewSource(seed))
+	committee := make([]identity.ID, 0, size)
+	for len(committee) < size {
+		var selectedIdx int
+		if manaHolders == 0 {
+			selectedIdx = rng.Intn(len(candidates))
+		} else {
+			remainingMana := 0.0
+			for _, id := range candidates {
+				remainingMana += candidatesMana[id]
+			}
+			// default to the last candidate in case of floating point inaccuracies
+			selectedIdx = len(candidates) - 1
+			rnd := rng.Float64() * remainingMana
+			cumulativeMana := 0.0
+			for idx, id := range candidates {
+				cumulativeMana += candidatesMana[id]
+				if rnd < cumulativeMana {
+					selectedIdx = idx
+					break
+				}
+			}
+		}
+
+		selected := candidates[selectedIdx]
+		committee = append(committee, selected)
+		if candidatesMana[selected] > 0 {
+			manaHolders--
+		}
+		candidates = append(candidates[:selectedIdx], candidates[selectedIdx+1:]...)
+	}
+	return committee, nil
+}
@@ -0,0 +1,59 @@
+package mana
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+)
+
+func TestSelectCommittee(t *testing.T) {
+	nodes := []identity.ID{randNodeID(), randNodeID(), randNodeID(), randNodeID()}
+	values := []float64{60, 30, 10, 0}
+	bmv, err := NewBaseManaVector(ConsensusMana)
+	require.NoError(t, err)
+	for i, id := range nodes {
+		bmv.SetMana(id, &ConsensusBaseMana{BaseMana1: values[i]})
+	}
+
+	t.Run("invalid size", func(t *testing.T) {
+		_, err := SelectCommittee(bmv, 0, 1)
+		assert.True(t, xerrors.Is(err, ErrInvalidCommitteeSize))
+		_, err = SelectCommittee(bmv, len(nodes)+1, 1)
+		assert.True(t, xerrors.Is(err, ErrInvalidCommitteeSize))
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		committee, err := SelectCommittee(bmv, 2, 42)
+		require.NoError(t, err)
+		assert.Len(t, committee, 2)
+		for i := 0; i < 10; i++ {
+			other, err := SelectCommittee(bmv, 2, 42)
+			require.NoError(t, err)
+			assert.Equal(t, committee, other)
+		}
+	})
+
+	t.Run("distinct", func(t *testing.T) {
+		// the node without mana is only selected once all others are
+		committee, err := SelectCommittee(bmv, len(nodes), 42)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, nodes, committee)
+		assert.Equal(t, nodes[3], committee[3])
+	})
+
+	t.Run("proportional", func(t *testing.T) {
+		const numSeeds = 10000
+		selected := make(map[identity.ID]int)
+		for seed := int64(0); seed < numSeeds; seed++ {
+			committee, err := SelectCommittee(bmv, 1, seed)
+			require.NoError(t, err)
+			selected[committee[0]]++
+		}
+		for i, id := range nodes {
+			assert.InDelta(t, values[i]/100, float64(selected[id])/numSeeds, 0.03)
+		}
+	})
+}
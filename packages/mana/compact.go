@@ -0,0 +1,264 @@
+package mana
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"math/bits"
+	"sort"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"golang.org/x/xerrors"
+)
+
+// compactFormatVersion is the version of the compact encoding of base mana vectors.
+const compactFormatVersion byte = 1
+
+// compactZeroHeader is the header of a value which is equal to the previous value of its column.
+const compactZeroHeader byte = 8 << 4
+
+// ExportCompact encodes the given base mana vector in a columnar format which is considerably smaller than its
+// persistable mana objects. The nodes are sorted by ID and stored in parallel columns: the IDs, each as the length of
+// the prefix shared with the previous ID followed by the remaining bytes, the last update times, each as the varint
+// difference to the previous time, and a column per base and effective value, each value XORed with the previous value
+// of the column and stored without its leading and trailing zero bytes.
+func ExportCompact(bmv BaseManaVector) ([]byte, error) {
+	persistables := bmv.ToPersistables()
+	sort.Slice(persistables, func(i, j int) bool {
+		return bytes.Compare(persistables[i].NodeID[:], persistables[j].NodeID[:]) < 0
+	})
+
+	var baseValuesCount, effectiveValuesCount int
+	if len(persistables) > 0 {
+		baseValuesCount, effectiveValuesCount = len(persistables[0].BaseValues), len(persistables[0].EffectiveValues)
+	}
+	for _, p := range persistables {
+		if len(p.BaseValues) != baseValuesCount || len(p.EffectiveValues) != effectiveValuesCount {
+			return nil, xerrors.Errorf("node %s has %d base and %d effective values instead of %d and %d",
+				p.NodeID, len(p.BaseValues), len(p.EffectiveValues), baseValuesCount, effectiveValuesCount)
+		}
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteByte(compactFormatVersion)
+	buffer.WriteByte(byte(bmv.Type()))
+	if weighted, ok := bmv.(*WeightedBaseManaVector); ok {
+		weighted.RLock()
+		buffer.WriteByte(byte(weighted.target))
+		writeCompactUint64(&buffer, math.Float64bits(weighted.weight))
+		weighted.RUnlock()
+	}
+	writeCompactUvarint(&buffer, uint64(len(persistables)))
+	writeCompactUvarint(&buffer, uint64(baseValuesCount))
+	writeCompactUvarint(&buffer, uint64(effectiveValuesCount))
+
+	var previousID identity.ID
+	for _, p := range persistables {
+		shared := 0
+		for shared < len(previousID) && p.NodeID[shared] == previousID[shared] {
+			shared++
+		}
+		buffer.WriteByte(byte(shared))
+		buffer.Write(p.NodeID[shared:])
+		previousID = p.NodeID
+	}
+
+	var previousTime int64
+	for _, p := range persistables {
+		unixNano := p.LastUpdated.UnixNano()
+		writeCompactVarint(&buffer, unixNano-previousTime)
+		previousTime = unixNano
+	}
+
+	for column := 0; column < baseValuesCount; column++ {
+		var previousValue uint64
+		for _, p := range persistables {
+			previousValue = writeCompactFloat64(&buffer, p.BaseValues[column], previousValue)
+		}
+	}
+	for column := 0; column < effectiveValuesCount; column++ {
+		var previousValue uint64
+		for _, p := range persistables {
+			previousValue = writeCompactFloat64(&buffer, p.EffectiveValues[column], previousValue)
+		}
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// ImportCompact decodes a base mana vector encoded with ExportCompact.
+func ImportCompact(data []byte) (bmv BaseManaVector, err error) {
+	reader := bytes.NewReader(data)
+
+	version, err := reader.ReadByte()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read version (%v): %w", err, ErrCorruptCompactVector)
+	}
+	if version != compactFormatVersion {
+		return nil, xerrors.Errorf("unsupported version %d: %w", version, ErrCorruptCompactVector)
+	}
+	vectorType, err := reader.ReadByte()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read type (%v): %w", err, ErrCorruptCompactVector)
+	}
+	if Type(vectorType) == WeightedMana {
+		target, readErr := reader.ReadByte()
+		if readErr != nil {
+			return nil, xerrors.Errorf("failed to read target (%v): %w", readErr, ErrCorruptCompactVector)
+		}
+		weight, readErr := readCompactUint64(reader)
+		if readErr != nil {
+			return nil, xerrors.Errorf("failed to read weight (%v): %w", readErr, ErrCorruptCompactVector)
+		}
+		bmv, err = NewResearchBaseManaVector(WeightedMana, Type(target), math.Float64frombits(weight))
+	} else {
+		bmv, err = NewBaseManaVector(Type(vectorType))
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create base mana vector (%v): %w", err, ErrCorruptCompactVector)
+	}
+
+	count, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read node count (%v): %w", err, ErrCorruptCompactVector)
+	}
+	baseValuesCount, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read base values count (%v): %w", err, ErrCorruptCompactVector)
+	}
+	effectiveValuesCount, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read effective values count (%v): %w", err, ErrCorruptCompactVector)
+	}
+	// every node takes at least one byte per column, which bounds the allocations for corrupt counts
+	if count > uint64(reader.Len()) || baseValuesCount > uint64(reader.Len()) || effectiveValuesCount > uint64(reader.Len()) {
+		return nil, xerrors.Errorf("counts exceed the encoded data: %w", ErrCorruptCompactVector)
+	}
+
+	persistables := make([]*PersistableBaseMana, count)
+	var previousID identity.ID
+	for i := range persistables {
+		shared, readErr := reader.ReadByte()
+		if readErr != nil || int(shared) > len(previousID) {
+			return nil, xerrors.Errorf("failed to read ID of node %d: %w", i, ErrCorruptCompactVector)
+		}
+		nodeID := previousID
+		if _, readErr = io.ReadFull(reader, nodeID[shared:]); readErr != nil {
+			return nil, xerrors.Errorf("failed to read ID of node %d (%v): %w", i, readErr, ErrCorruptCompactVector)
+		}
+		persistables[i] = &PersistableBaseMana{
+			ManaType:        Type(vectorType),
+			BaseValues:      make([]float64, baseValuesCount),
+			EffectiveValues: make([]float64, effectiveValuesCount),
+			NodeID:          nodeID,
+		}
+		previousID = nodeID
+	}
+
+	var previousTime int64
+	for i, p := range persistables {
+		delta, readErr := binary.ReadVarint(reader)
+		if readErr != nil {
+			return nil, xerrors.Errorf("failed to read last update of node %d (%v): %w", i, readErr, ErrCorruptCompactVector)
+		}
+		previousTime += delta
+		p.LastUpdated = time.Unix(0, previousTime)
+	}
+
+	for column := 0; column < int(baseValuesCount); column++ {
+		var previousValue uint64
+		for i, p := range persistables {
+			if p.BaseValues[column], previousValue, err = readCompactFloat64(reader, previousValue); err != nil {
+				return nil, xerrors.Errorf("failed to read base value %d of node %d (%v): %w", column, i, err, ErrCorruptCompactVector)
+			}
+		}
+	}
+	for column := 0; column < int(effectiveValuesCount); column++ {
+		var previousValue uint64
+		for i, p := range persistables {
+			if p.EffectiveValues[column], previousValue, err = readCompactFloat64(reader, previousValue); err != nil {
+				return nil, xerrors.Errorf("failed to read effective value %d of node %d (%v): %w", column, i, err, ErrCorruptCompactVector)
+			}
+		}
+	}
+	if reader.Len() != 0 {
+		return nil, xerrors.Errorf("unexpected trailing bytes: %w", ErrCorruptCompactVector)
+	}
+
+	for _, p := range persistables {
+		if err = bmv.FromPersistable(p); err != nil {
+			return nil, xerrors.Errorf("failed to import node %s (%v): %w", p.NodeID, err, ErrCorruptCompactVector)
+		}
+	}
+	return bmv, nil
+}
+
+func writeCompactUvarint(buffer *bytes.Buffer, value uint64) {
+	var encoded [binary.MaxVarintLen64]byte
+	buffer.Write(encoded[:binary.PutUvarint(encoded[:], value)])
+}
+
+func writeCompactVarint(buffer *bytes.Buffer, value int64) {
+	var encoded [binary.MaxVarintLen64]byte
+	buffer.Write(encoded[:binary.PutVarint(encoded[:], value)])
+}
+
+func writeCompactUint64(buffer *bytes.Buffer, value uint64) {
+	var encoded [8]byte
+	binary.BigEndian.PutUint64(encoded[:], value)
+	buffer.Write(encoded[:])
+}
+
+func readCompactUint64(reader *bytes.Reader) (uint64, error) {
+	var encoded [8]byte
+	if _, err := io.ReadFull(reader, encoded[:]); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(encoded[:]), nil
+}
+
+// writeCompactFloat64 writes the given value XORed with the bits of the previous value as a header byte holding the
+// number of leading and trailing zero bytes followed by the remaining bytes. It returns the bits of the value.
+func writeCompactFloat64(buffer *bytes.Buffer, value float64, previous uint64) uint64 {
+	valueBits := math.Float64bits(value)
+	xor := valueBits ^ previous
+	if xor == 0 {
+		buffer.WriteByte(compactZeroHeader)
+		return valueBits
+	}
+
+	leading, trailing := bits.LeadingZeros64(xor)/8, bits.TrailingZeros64(xor)/8
+	buffer.WriteByte(byte(leading<<4 | trailing))
+	for i := 7 - leading; i >= trailing; i-- {
+		buffer.WriteByte(byte(xor >> (8 * i)))
+	}
+	return valueBits
+}
+
+// readCompactFloat64 reads a value written by writeCompactFloat64 and returns it together with its bits.
+func readCompactFloat64(reader *bytes.Reader, previous uint64) (float64, uint64, error) {
+	header, err := reader.ReadByte()
+	if err != nil {
+		return 0, 0, err
+	}
+	if header == compactZeroHeader {
+		return math.Float64frombits(previous), previous, nil
+	}
+
+	leading, trailing := int(header>>4), int(header&0x0f)
+	if leading+trailing > 7 {
+		return 0, 0, xerrors.Errorf("invalid value header %#x", header)
+	}
+	var xor uint64
+	for i := 7 - leading; i >= trailing; i-- {
+		b, readErr := reader.ReadByte()
+		if readErr != nil {
+			return 0, 0, readErr
+		}
+		xor |= uint64(b) << (8 * i)
+	}
+	valueBits := xor ^ previous
+	return math.Float64frombits(valueBits), valueBits, nil
+}
@@ -0,0 +1,123 @@
+package mana
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImportCompact(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+
+	accessVector, err := NewBaseManaVector(AccessMana)
+	require.NoError(t, err)
+	consensusVector, err := NewBaseManaVector(ConsensusMana)
+	require.NoError(t, err)
+	weightedVector, err := NewResearchBaseManaVector(WeightedMana, AccessMana, 0.3)
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		lastUpdated := baseTime.Add(time.Duration(rng.Int63n(int64(time.Hour))))
+		accessVector.SetMana(randNodeID(), &AccessBaseMana{
+			BaseMana2:          rng.Float64() * 1000,
+			EffectiveBaseMana2: rng.Float64() * 1000,
+			LastUpdated:        lastUpdated,
+		})
+		consensusVector.SetMana(randNodeID(), &ConsensusBaseMana{
+			BaseMana1:          float64(rng.Intn(1000)),
+			EffectiveBaseMana1: rng.Float64() * 1000,
+			LastUpdated:        lastUpdated,
+		})
+		nodeID := randNodeID()
+		weightedVector.(*WeightedBaseManaVector).SetMana1(nodeID, &ConsensusBaseMana{
+			BaseMana1:          float64(rng.Intn(1000)),
+			EffectiveBaseMana1: rng.Float64() * 1000,
+			LastUpdated:        lastUpdated,
+		})
+		weightedVector.(*WeightedBaseManaVector).SetMana2(nodeID, &AccessBaseMana{
+			BaseMana2:          rng.Float64() * 1000,
+			EffectiveBaseMana2: 0,
+			LastUpdated:        lastUpdated,
+		})
+	}
+	emptyVector, err := NewBaseManaVector(ConsensusMana)
+	require.NoError(t, err)
+
+	for _, bmv := range []BaseManaVector{accessVector, consensusVector, weightedVector, emptyVector} {
+		data, err := ExportCompact(bmv)
+		require.NoError(t, err)
+
+		imported, err := ImportCompact(data)
+		require.NoError(t, err)
+		assert.Equal(t, bmv.Type(), imported.Type())
+		assertPersistablesEqual(t, bmv.ToPersistables(), imported.ToPersistables())
+		if weighted, ok := bmv.(*WeightedBaseManaVector); ok {
+			assert.Equal(t, weighted.target, imported.(*WeightedBaseManaVector).target)
+			assert.Equal(t, weighted.weight, imported.(*WeightedBaseManaVector).weight)
+		}
+	}
+}
+
+func TestImportCompact_Corrupt(t *testing.T) {
+	bmv, err := NewBaseManaVector(AccessMana)
+	require.NoError(t, err)
+	bmv.SetMana(randNodeID(), &AccessBaseMana{BaseMana2: 1, EffectiveBaseMana2: 1, LastUpdated: baseTime})
+	data, err := ExportCompact(bmv)
+	require.NoError(t, err)
+
+	for i := range data {
+		_, err = ImportCompact(data[:i])
+		assert.ErrorIs(t, err, ErrCorruptCompactVector)
+	}
+	_, err = ImportCompact(append(data, 0))
+	assert.ErrorIs(t, err, ErrCorruptCompactVector)
+}
+
+func TestExportCompact_Size(t *testing.T) {
+	const nodeCount = 100000
+	rng := rand.New(rand.NewSource(42))
+
+	bmv, err := NewBaseManaVector(ConsensusMana)
+	require.NoError(t, err)
+	for i := 0; i < nodeCount; i++ {
+		baseMana := float64(rng.Intn(1000000000) + 1)
+		bmv.SetMana(randNodeID(), &ConsensusBaseMana{
+			BaseMana1:          baseMana,
+			EffectiveBaseMana1: baseMana * rng.Float64(),
+			LastUpdated:        baseTime,
+		})
+	}
+
+	persistablesSize := 0
+	for _, p := range bmv.ToPersistables() {
+		persistablesSize += len(p.Bytes())
+	}
+	data, err := ExportCompact(bmv)
+	require.NoError(t, err)
+	t.Logf("persistables: %d bytes, compact: %d bytes (%.1f%%)", persistablesSize, len(data), 100*float64(len(data))/float64(persistablesSize))
+	// the random node IDs can't be compacted, so the savings are limited to the remaining fields
+	assert.Less(t, len(data), persistablesSize*4/5)
+
+	imported, err := ImportCompact(data)
+	require.NoError(t, err)
+	assert.Equal(t, nodeCount, imported.Size())
+}
+
+func assertPersistablesEqual(t *testing.T, expected, actual []*PersistableBaseMana) {
+	require.Len(t, actual, len(expected))
+	actualByID := make(map[identity.ID]*PersistableBaseMana, len(actual))
+	for _, p := range actual {
+		actualByID[p.NodeID] = p
+	}
+	for _, e := range expected {
+		a, ok := actualByID[e.NodeID]
+		require.True(t, ok, "node %s missing", e.NodeID)
+		assert.Equal(t, e.ManaType, a.ManaType)
+		assert.Equal(t, e.BaseValues, a.BaseValues)
+		assert.Equal(t, e.EffectiveValues, a.EffectiveValues)
+		assert.True(t, e.LastUpdated.Equal(a.LastUpdated), "last update of node %s: %s != %s", e.NodeID, e.LastUpdated, a.LastUpdated)
+	}
+}
@@ -2,6 +2,7 @@ package mana
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"sort"
 	"sync"
@@ -97,6 +98,21 @@ func txInfoFromPledgeEvent(ev *PledgedEvent) *TxInfo {
 func (c *ConsensusBaseManaVector) Book(txInfo *TxInfo) {
 	c.Lock()
 	defer c.Unlock()
+	c.book(txInfo)
+}
+
+// BookBatch books mana for a batch of transactions under a single lock acquisition.
+// The transactions are booked in the given order, so the result equals calling Book for each of them.
+func (c *ConsensusBaseManaVector) BookBatch(txInfos []*TxInfo) {
+	c.Lock()
+	defer c.Unlock()
+	for _, txInfo := range txInfos {
+		c.book(txInfo)
+	}
+}
+
+// book books mana for a transaction. Not concurrency safe.
+func (c *ConsensusBaseManaVector) book(txInfo *TxInfo) {
 	// first, revoke mana from previous owners
 	for _, inputInfo := range txInfo.InputInfos {
 		// and there was the genesis once
@@ -159,12 +175,25 @@ func (c *ConsensusBaseManaVector) Update(nodeID identity.ID, t time.Time) error
 
 // UpdateAll updates all entries in the base mana vector wrt to `t`.
 func (c *ConsensusBaseManaVector) UpdateAll(t time.Time) error {
+	return c.UpdateAllCtx(context.Background(), t)
+}
+
+// UpdateAllCtx updates all entries in the base mana vector wrt to `t`. The context is checked periodically and, once it
+// is done, its error is returned. The entries updated until then stay updated.
+func (c *ConsensusBaseManaVector) UpdateAllCtx(ctx context.Context, t time.Time) error {
 	c.Lock()
 	defer c.Unlock()
+	var updated int
 	for nodeID := range c.vector {
+		if updated%updateAllCtxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
 		if err := c.update(nodeID, t); err != nil {
 			return err
 		}
+		updated++
 	}
 	return nil
 }
@@ -176,6 +205,34 @@ func (c *ConsensusBaseManaVector) GetMana(nodeID identity.ID, optionalUpdateTime
 	return c.getMana(nodeID, optionalUpdateTime...)
 }
 
+// GetManaWithWeights returns the mana of a node with the given access and consensus weights.
+// Consensus base mana vectors only keep track of Effective Base Mana 1, the access component is thus zero.
+func (c *ConsensusBaseManaVector) GetManaWithWeights(nodeID identity.ID, accessWeight, consensusWeight float64, optionalUpdateTime ...time.Time) (float64, time.Time, error) {
+	if err := validateWeights(accessWeight, consensusWeight); err != nil {
+		return 0.0, time.Now(), err
+	}
+	c.Lock()
+	defer c.Unlock()
+	effMana, t, err := c.getMana(nodeID, optionalUpdateTime...)
+	if err != nil {
+		return 0.0, t, err
+	}
+	return effMana * consensusWeight, t, nil
+}
+
+// ProjectMana returns the effective mana of a node at the given time, applying the decay from the time of its last
+// update, without updating the vector. It returns ErrProjectionBeforeLastUpdate, if the time predates the last update.
+func (c *ConsensusBaseManaVector) ProjectMana(nodeID identity.ID, at time.Time) (float64, error) {
+	c.RLock()
+	defer c.RUnlock()
+	baseMana, exist := c.vector[nodeID]
+	if !exist {
+		return 0.0, ErrNodeNotFoundInBaseManaVector
+	}
+	projected := *baseMana
+	return projectMana(&projected, at)
+}
+
 // GetManaMap returns mana perception of the node.
 func (c *ConsensusBaseManaVector) GetManaMap(optionalUpdateTime ...time.Time) (res NodeMap, t time.Time, err error) {
 	c.Lock()
@@ -196,6 +253,34 @@ func (c *ConsensusBaseManaVector) GetManaMap(optionalUpdateTime ...time.Time) (r
 	return
 }
 
+// GetManaMapParallel returns mana perception of the node, computed by the given number of workers. A single worker
+// computes the map serially like GetManaMap. The workers only project the mana, the vector is updated and the Updated
+// events are triggered afterwards by the calling goroutine.
+func (c *ConsensusBaseManaVector) GetManaMapParallel(workers int, optionalUpdateTime ...time.Time) (res NodeMap, t time.Time, err error) {
+	c.Lock()
+	defer c.Unlock()
+	t = time.Now()
+	if len(optionalUpdateTime) > 0 {
+		t = optionalUpdateTime[0]
+	}
+	nodeIDs := make([]identity.ID, 0, len(c.vector))
+	for ID := range c.vector {
+		nodeIDs = append(nodeIDs, ID)
+	}
+	res, err = parallelManaMap(nodeIDs, workers, func(nodeID identity.ID) (projectedMana, error) {
+		projected := *c.vector[nodeID]
+		if err := projected.update(t); err != nil {
+			return projectedMana{mana: projected.EffectiveBaseMana1}, nil
+		}
+		return projectedMana{mana: projected.EffectiveBaseMana1, updated: &projected, updateSucceeded: true}, nil
+	}, func(nodeID identity.ID, updated BaseMana, _ bool) {
+		oldMana := *c.vector[nodeID]
+		*c.vector[nodeID] = *updated.(*ConsensusBaseMana)
+		Events().Updated.Trigger(&UpdatedEvent{nodeID, &oldMana, c.vector[nodeID], c.Type()})
+	})
+	return
+}
+
 // GetHighestManaNodes return the n highest mana nodes in descending order.
 // It also updates the mana values for each node.
 // If n is zero, it returns all nodes.
@@ -323,8 +408,17 @@ func (c *ConsensusBaseManaVector) ToPersistables() []*PersistableBaseMana {
 	return result
 }
 
-// FromPersistable fills the ConsensusBaseManaVector from persistable mana objects.
-func (c *ConsensusBaseManaVector) FromPersistable(p *PersistableBaseMana) (err error) {
+// FromPersistable fills the ConsensusBaseManaVector from persistable mana objects, replacing existing entries.
+func (c *ConsensusBaseManaVector) FromPersistable(p *PersistableBaseMana) error {
+	return c.FromPersistableWithStrategy(p, Replace)
+}
+
+// FromPersistableWithStrategy fills the ConsensusBaseManaVector from persistable mana objects, merging existing entries with the
+// given strategy.
+func (c *ConsensusBaseManaVector) FromPersistableWithStrategy(p *PersistableBaseMana, strategy MergeStrategy) (err error) {
+	if err = validateMergeStrategy(strategy); err != nil {
+		return
+	}
 	if p.ManaType != ConsensusMana {
 		err = xerrors.Errorf("persistable mana object has type %s instead of %s", p.ManaType.String(), ConsensusMana.String())
 		return
@@ -337,13 +431,25 @@ func (c *ConsensusBaseManaVector) FromPersistable(p *PersistableBaseMana) (err e
 		err = xerrors.Errorf("persistable mana object has %d effective values instead of 1", len(p.EffectiveValues))
 		return
 	}
-	c.Lock()
-	defer c.Unlock()
-	c.vector[p.NodeID] = &ConsensusBaseMana{
+	imported := &ConsensusBaseMana{
 		BaseMana1:          p.BaseValues[0],
 		EffectiveBaseMana1: p.EffectiveValues[0],
 		LastUpdated:        p.LastUpdated,
 	}
+	c.Lock()
+	defer c.Unlock()
+	existing, ok := c.vector[p.NodeID]
+	if !ok || strategy == Replace {
+		c.vector[p.NodeID] = imported
+		return
+	}
+	if strategy == Sum {
+		if err = alignUpdateTimes(existing, imported); err != nil {
+			return
+		}
+		existing.BaseMana1 += imported.BaseMana1
+		existing.EffectiveBaseMana1 += imported.EffectiveBaseMana1
+	}
 	return
 }
 
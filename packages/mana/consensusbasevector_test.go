@@ -1,12 +1,15 @@
 package mana
 
 import (
+	"context"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 )
@@ -360,6 +363,61 @@ func TestConsensusBaseManaVector_UpdateAll(t *testing.T) {
 	assert.Empty(t, updatedNodeIds)
 }
 
+// cancelAfterContext is a context which is cancelled once its error has been checked the given number of times.
+type cancelAfterContext struct {
+	context.Context
+	checks int32
+}
+
+func (c *cancelAfterContext) Err() error {
+	if atomic.AddInt32(&c.checks, -1) < 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestConsensusBaseManaVector_UpdateAllCtx(t *testing.T) {
+	const size = 50000
+	lastUpdated := time.Now().Add(-time.Hour)
+	updateTime := lastUpdated.Add(30 * time.Minute)
+	bmv, _ := newLargeConsensusVectors(size, lastUpdated)
+
+	// cancel the update after three checks of the context
+	const checks = 3
+	start := time.Now()
+	err := bmv.UpdateAllCtx(&cancelAfterContext{Context: context.Background(), checks: checks}, updateTime)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start).Milliseconds(), int64(time.Second/time.Millisecond))
+
+	// the entries updated before the cancellation stay updated, the others are untouched
+	var updated int
+	for _, baseMana := range bmv.(*ConsensusBaseManaVector).vector {
+		if baseMana.LastUpdated.Equal(updateTime) {
+			updated++
+			continue
+		}
+		assert.Equal(t, lastUpdated, baseMana.LastUpdated)
+	}
+	assert.Equal(t, checks*updateAllCtxCheckInterval, updated)
+
+	// an update with a cancelled context does not update any entry
+	bmv, _ = newLargeConsensusVectors(size, lastUpdated)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, bmv.UpdateAllCtx(ctx, updateTime), context.Canceled)
+	bmv.ForEach(func(_ identity.ID, baseMana BaseMana) bool {
+		assert.Equal(t, lastUpdated, baseMana.(*ConsensusBaseMana).LastUpdated)
+		return true
+	})
+
+	// the remaining entries are updated once the update is resumed
+	require.NoError(t, bmv.UpdateAllCtx(context.Background(), updateTime))
+	bmv.ForEach(func(_ identity.ID, baseMana BaseMana) bool {
+		assert.Equal(t, updateTime, baseMana.(*ConsensusBaseMana).LastUpdated)
+		return true
+	})
+}
+
 func TestConsensusBaseManaVector_GetMana(t *testing.T) {
 	bmv, err := NewBaseManaVector(ConsensusMana)
 	assert.NoError(t, err)
@@ -384,6 +442,41 @@ func TestConsensusBaseManaVector_GetMana(t *testing.T) {
 	assert.InDelta(t, 10.0, mana, delta)
 }
 
+func TestConsensusBaseManaVector_ProjectMana(t *testing.T) {
+	bmv, err := NewBaseManaVector(ConsensusMana)
+	assert.NoError(t, err)
+	randID := randNodeID()
+	_, err = bmv.ProjectMana(randID, time.Now())
+	assert.ErrorIs(t, err, ErrNodeNotFoundInBaseManaVector)
+
+	// freshly pledged mana, the effective mana grows towards the base mana
+	now := time.Now()
+	lastUpdated := now.Add(-time.Hour)
+	bmv.SetMana(randID, &ConsensusBaseMana{
+		BaseMana1:   10.0,
+		LastUpdated: lastUpdated,
+	})
+
+	past, err := bmv.ProjectMana(randID, now.Add(-30*time.Minute))
+	assert.NoError(t, err)
+	present, err := bmv.ProjectMana(randID, now)
+	assert.NoError(t, err)
+	future, err := bmv.ProjectMana(randID, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Greater(t, past, 0.0)
+	assert.Greater(t, present, past)
+	assert.Greater(t, future, present)
+	assert.LessOrEqual(t, future, 10.0)
+
+	// projecting does not update the vector
+	mana, _, err := bmv.GetMana(randID, now)
+	assert.NoError(t, err)
+	assert.InDelta(t, present, mana, delta)
+
+	_, err = bmv.ProjectMana(randID, lastUpdated.Add(-time.Second))
+	assert.ErrorIs(t, err, ErrProjectionBeforeLastUpdate)
+}
+
 func TestConsensusBaseManaVector_ForEach(t *testing.T) {
 	bmv, err := NewBaseManaVector(ConsensusMana)
 	assert.NoError(t, err)
@@ -677,6 +770,69 @@ func TestConsensusBaseManaVector_FromPersistable(t *testing.T) {
 	})
 }
 
+func TestConsensusBaseManaVector_FromPersistableWithStrategy(t *testing.T) {
+	id := randNodeID()
+	// base and effective values are equal, so that they do not change when updated
+	newPrepopulatedVector := func(t *testing.T) BaseManaVector {
+		bmv, err := NewBaseManaVector(ConsensusMana)
+		assert.NoError(t, err)
+		bmv.SetMana(id, &ConsensusBaseMana{BaseMana1: 10, EffectiveBaseMana1: 10, LastUpdated: baseTime})
+		return bmv
+	}
+	newPersistable := func(nodeID identity.ID, lastUpdated time.Time) *PersistableBaseMana {
+		return &PersistableBaseMana{
+			ManaType:        ConsensusMana,
+			BaseValues:      []float64{5},
+			EffectiveValues: []float64{5},
+			LastUpdated:     lastUpdated,
+			NodeID:          nodeID,
+		}
+	}
+
+	t.Run("CASE: Replace", func(t *testing.T) {
+		bmv := newPrepopulatedVector(t)
+		assert.NoError(t, bmv.FromPersistableWithStrategy(newPersistable(id, baseTime), Replace))
+		bmValue := bmv.(*ConsensusBaseManaVector).vector[id]
+		assert.Equal(t, 5.0, bmValue.BaseValue())
+		assert.Equal(t, 5.0, bmValue.EffectiveValue())
+
+		// FromPersistable replaces existing entries as well
+		bmv = newPrepopulatedVector(t)
+		assert.NoError(t, bmv.FromPersistable(newPersistable(id, baseTime)))
+		assert.Equal(t, 5.0, bmv.(*ConsensusBaseManaVector).vector[id].BaseValue())
+	})
+
+	t.Run("CASE: KeepExisting", func(t *testing.T) {
+		bmv := newPrepopulatedVector(t)
+		assert.NoError(t, bmv.FromPersistableWithStrategy(newPersistable(id, baseTime), KeepExisting))
+		bmValue := bmv.(*ConsensusBaseManaVector).vector[id]
+		assert.Equal(t, 10.0, bmValue.BaseValue())
+		assert.Equal(t, 10.0, bmValue.EffectiveValue())
+
+		// new nodes are still imported
+		otherID := randNodeID()
+		assert.NoError(t, bmv.FromPersistableWithStrategy(newPersistable(otherID, baseTime), KeepExisting))
+		assert.Equal(t, 5.0, bmv.(*ConsensusBaseManaVector).vector[otherID].BaseValue())
+	})
+
+	t.Run("CASE: Sum", func(t *testing.T) {
+		bmv := newPrepopulatedVector(t)
+		// the existing entry is updated to the time of the imported one first
+		assert.NoError(t, bmv.FromPersistableWithStrategy(newPersistable(id, baseTime.Add(time.Hour)), Sum))
+		bmValue := bmv.(*ConsensusBaseManaVector).vector[id]
+		assert.Equal(t, 15.0, bmValue.BaseValue())
+		assert.Equal(t, 15.0, bmValue.EffectiveValue())
+		assert.Equal(t, baseTime.Add(time.Hour), bmValue.LastUpdate())
+	})
+
+	t.Run("CASE: Unknown strategy", func(t *testing.T) {
+		bmv := newPrepopulatedVector(t)
+		err := bmv.FromPersistableWithStrategy(newPersistable(randNodeID(), baseTime), MergeStrategy(42))
+		assert.ErrorIs(t, err, ErrUnknownMergeStrategy)
+		assert.Equal(t, 1, bmv.Size())
+	})
+}
+
 func TestConsensusBaseManaVector_ToAndFromPersistable(t *testing.T) {
 	bmv, err := NewBaseManaVector(ConsensusMana)
 	assert.NoError(t, err)
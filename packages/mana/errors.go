@@ -19,4 +19,16 @@ var (
 	ErrInvalidTargetManaType = errors.New("invalid target mana type")
 	// ErrUnknownManaEvent is returned if mana event type could not be identified.
 	ErrUnknownManaEvent = errors.New("unknown mana event")
+	// ErrCorruptCheckpoint is returned if a mana checkpoint can't be decoded or fails verification.
+	ErrCorruptCheckpoint = errors.New("corrupt mana checkpoint")
+	// ErrUnknownMergeStrategy is returned if a merge strategy could not be identified.
+	ErrUnknownMergeStrategy = errors.New("unknown merge strategy")
+	// ErrNoValidCheckpoint is returned if no valid mana checkpoint could be found.
+	ErrNoValidCheckpoint = errors.New("no valid mana checkpoint found")
+	// ErrInvalidCommitteeSize is returned if a committee can't be selected with the requested size.
+	ErrInvalidCommitteeSize = errors.New("invalid committee size")
+	// ErrProjectionBeforeLastUpdate is returned if mana is projected to a time before its last update.
+	ErrProjectionBeforeLastUpdate = errors.New("projection time predates the last mana update")
+	// ErrCorruptCompactVector is returned if a compactly encoded base mana vector can't be decoded.
+	ErrCorruptCompactVector = errors.New("corrupt compact mana vector")
 )
@@ -0,0 +1,51 @@
+package mana
+
+import "golang.org/x/xerrors"
+
+// MergeStrategy defines how an imported base mana entry is merged with an existing entry of the same node.
+type MergeStrategy byte
+
+const (
+	// Replace replaces the existing entry with the imported one.
+	Replace MergeStrategy = iota
+	// KeepExisting keeps the existing entry and discards the imported one.
+	KeepExisting
+	// Sum adds the imported values to the existing ones. The older of both entries is updated to the time of the
+	// newer one first, so that values of the same time are summed up.
+	Sum
+)
+
+// String returns a string representation of the merge strategy.
+func (m MergeStrategy) String() string {
+	switch m {
+	case Replace:
+		return "Replace"
+	case KeepExisting:
+		return "KeepExisting"
+	case Sum:
+		return "Sum"
+	default:
+		return "Unknown"
+	}
+}
+
+// validateMergeStrategy returns an error if the given merge strategy is unknown.
+func validateMergeStrategy(strategy MergeStrategy) error {
+	if strategy > Sum {
+		return xerrors.Errorf("merge strategy %d: %w", strategy, ErrUnknownMergeStrategy)
+	}
+	return nil
+}
+
+// alignUpdateTimes updates the older of the given base mana entries to the last update time of the newer one.
+func alignUpdateTimes(a, b BaseMana) error {
+	aTime, bTime := a.LastUpdate(), b.LastUpdate()
+	switch {
+	case aTime.Before(bTime):
+		return a.update(bTime)
+	case bTime.Before(aTime):
+		return b.update(aTime)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,78 @@
+package mana
+
+import (
+	"sync"
+
+	"github.com/iotaledger/hive.go/identity"
+)
+
+// projectedMana is the mana of a single node computed by a worker of parallelManaMap.
+type projectedMana struct {
+	// the effective mana of the node.
+	mana float64
+	// a copy of the base mana of the node updated to the time of the map, nil if nothing needs to be written back.
+	updated BaseMana
+	// whether the update succeeded, i.e. whether the Updated event must be triggered for the node.
+	updateSucceeded bool
+}
+
+// parallelManaMap computes the mana of the given nodes with the given number of workers. The nodes are split into one
+// shard per worker and each worker projects the mana of its shard. As the mana of every node is computed
+// independently, the result is identical to computing it serially.
+// project is called concurrently and must neither modify the vector nor trigger any events. Once all workers are done,
+// apply is called serially for every node with an updated base mana in the order of nodeIDs, so that the vector is
+// only modified and the Updated events are only triggered by the calling goroutine. If project fails for several
+// nodes, the error of the first shard is returned and nothing is applied.
+func parallelManaMap(nodeIDs []identity.ID, workers int, project func(nodeID identity.ID) (projectedMana, error), apply func(nodeID identity.ID, updated BaseMana, updateSucceeded bool)) (NodeMap, error) {
+	if workers > len(nodeIDs) {
+		workers = len(nodeIDs)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	projections := make([]projectedMana, len(nodeIDs))
+	shardSize := (len(nodeIDs) + workers - 1) / workers
+	errs := make([]error, workers)
+	if workers == 1 {
+		errs[0] = projectShard(nodeIDs, projections, project)
+	} else {
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			start, end := i*shardSize, (i+1)*shardSize
+			if end > len(nodeIDs) {
+				end = len(nodeIDs)
+			}
+			wg.Add(1)
+			go func(i int, shard []identity.ID, shardProjections []projectedMana) {
+				defer wg.Done()
+				errs[i] = projectShard(shard, shardProjections, project)
+			}(i, nodeIDs[start:end], projections[start:end])
+		}
+		wg.Wait()
+	}
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res := make(NodeMap, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		res[nodeID] = projections[i].mana
+		if projections[i].updated != nil {
+			apply(nodeID, projections[i].updated, projections[i].updateSucceeded)
+		}
+	}
+	return res, nil
+}
+
+// projects the mana of the given nodes serially into the given projections.
+func projectShard(nodeIDs []identity.ID, projections []projectedMana, project func(nodeID identity.ID) (projectedMana, error)) (err error) {
+	for i, nodeID := range nodeIDs {
+		if projections[i], err = project(nodeID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package mana
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLargeConsensusVectors returns two identical consensus mana vectors with the given number of nodes.
+func newLargeConsensusVectors(size int, lastUpdated time.Time) (BaseManaVector, BaseManaVector) {
+	a, _ := NewBaseManaVector(ConsensusMana)
+	b, _ := NewBaseManaVector(ConsensusMana)
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i < size; i++ {
+		var nodeID identity.ID
+		rng.Read(nodeID[:])
+		baseMana := rng.Float64() * 1000
+		effectiveMana := rng.Float64() * baseMana
+		a.SetMana(nodeID, &ConsensusBaseMana{BaseMana1: baseMana, EffectiveBaseMana1: effectiveMana, LastUpdated: lastUpdated})
+		b.SetMana(nodeID, &ConsensusBaseMana{BaseMana1: baseMana, EffectiveBaseMana1: effectiveMana, LastUpdated: lastUpdated})
+	}
+	return a, b
+}
+
+func TestGetManaMapParallel(t *testing.T) {
+	const size = 50000
+	lastUpdated := time.Now().Add(-time.Hour)
+	updateTime := lastUpdated.Add(30 * time.Minute)
+
+	for _, workers := range []int{0, 1, 7, 16} {
+		serialVector, parallelVector := newLargeConsensusVectors(size, lastUpdated)
+
+		serial, tSerial, err := serialVector.GetManaMap(updateTime)
+		require.NoError(t, err)
+		parallel, tParallel, err := parallelVector.GetManaMapParallel(workers, updateTime)
+		require.NoError(t, err)
+
+		assert.Len(t, parallel, size)
+		assert.Equal(t, serial, parallel, "workers: %d", workers)
+		assert.Equal(t, tSerial, tParallel)
+	}
+
+	// an empty vector results in an empty map
+	bmv, err := NewBaseManaVector(AccessMana)
+	require.NoError(t, err)
+	res, _, err := bmv.GetManaMapParallel(4)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+}
+
+func TestGetManaMapParallelUpdated(t *testing.T) {
+	const size = 1000
+	lastUpdated := time.Now().Add(-time.Hour)
+	updateTime := lastUpdated.Add(30 * time.Minute)
+	serialVector, parallelVector := newLargeConsensusVectors(size, lastUpdated)
+
+	// the handler is not synchronized, so the race detector reports concurrent calls
+	updated := make(map[identity.ID]*UpdatedEvent)
+	closure := events.NewClosure(func(ev *UpdatedEvent) {
+		updated[ev.NodeID] = ev
+	})
+	Events().Updated.Attach(closure)
+	_, _, err := parallelVector.GetManaMapParallel(8, updateTime)
+	Events().Updated.Detach(closure)
+	require.NoError(t, err)
+	assert.Len(t, updated, size)
+
+	// the vector is updated like by the serial computation
+	_, _, err = serialVector.GetManaMap(updateTime)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, serialVector.ToPersistables(), parallelVector.ToPersistables())
+	for _, ev := range updated {
+		assert.Equal(t, lastUpdated, ev.OldMana.LastUpdate())
+		assert.Equal(t, updateTime, ev.NewMana.LastUpdate())
+	}
+
+	// an up to date vector is not updated again
+	updated = make(map[identity.ID]*UpdatedEvent)
+	Events().Updated.Attach(closure)
+	_, _, err = parallelVector.GetManaMapParallel(8, updateTime)
+	Events().Updated.Detach(closure)
+	require.NoError(t, err)
+	assert.Empty(t, updated)
+}
+
+func BenchmarkGetManaMap(b *testing.B) {
+	const size = 50000
+	lastUpdated := time.Now().Add(-time.Hour)
+	serialVector, parallelVector := newLargeConsensusVectors(size, lastUpdated)
+
+	b.Run("serial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			// every iteration has to decay the mana further
+			if _, _, err := serialVector.GetManaMap(lastUpdated.Add(time.Duration(i+1) * time.Second)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, _, err := parallelVector.GetManaMapParallel(8, lastUpdated.Add(time.Duration(i+1)*time.Second)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package mana
+
+import (
+	"sync"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+)
+
+// RankChangedEvent is the struct that is passed along with triggering a RankChanged event.
+type RankChangedEvent struct {
+	NodeID identity.ID
+	// OldRank is the rank before the change, 0 if the node had no mana.
+	OldRank int
+	// NewRank is the rank after the change, 0 if the node has no mana.
+	NewRank int
+	// Threshold is the rank threshold that was crossed.
+	Threshold int
+}
+
+// RankTracker keeps track of the mana rank of a single node and triggers an event whenever the rank crosses the
+// configured threshold, i.e. when the node enters or leaves the top threshold nodes.
+type RankTracker struct {
+	// Fired when the rank of the tracked node crossed the threshold.
+	RankChanged *events.Event
+
+	nodeID    identity.ID
+	threshold int
+
+	mu       sync.Mutex
+	lastRank int
+}
+
+// NewRankTracker creates a new RankTracker for the given node and rank threshold.
+func NewRankTracker(nodeID identity.ID, threshold int) *RankTracker {
+	return &RankTracker{
+		RankChanged: events.NewEvent(rankChangedEventCaller),
+		nodeID:      nodeID,
+		threshold:   threshold,
+	}
+}
+
+// Rank returns the last rank of the tracked node, 0 if the node had no mana.
+func (r *RankTracker) Rank() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.lastRank
+}
+
+// Update computes the rank of the tracked node from the given nodes, which must be sorted by mana in descending
+// order, and triggers RankChanged if the threshold was crossed since the last update.
+func (r *RankTracker) Update(nodes []Node) {
+	newRank := 0
+	for i, n := range nodes {
+		if n.ID == r.nodeID {
+			newRank = i + 1
+			break
+		}
+	}
+
+	r.mu.Lock()
+	oldRank := r.lastRank
+	r.lastRank = newRank
+	r.mu.Unlock()
+
+	if r.withinThreshold(oldRank) == r.withinThreshold(newRank) {
+		return
+	}
+	r.RankChanged.Trigger(&RankChangedEvent{
+		NodeID:    r.nodeID,
+		OldRank:   oldRank,
+		NewRank:   newRank,
+		Threshold: r.threshold,
+	})
+}
+
+func (r *RankTracker) withinThreshold(rank int) bool {
+	return rank > 0 && rank <= r.threshold
+}
+
+func rankChangedEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(ev *RankChangedEvent))(params[0].(*RankChangedEvent))
+}
@@ -0,0 +1,43 @@
+package mana
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankTracker_Update(t *testing.T) {
+	const threshold = 2
+	ownID := randNodeID()
+	others := []Node{{ID: randNodeID(), Mana: 30}, {ID: randNodeID(), Mana: 20}}
+
+	tracker := NewRankTracker(ownID, threshold)
+	var triggered []*RankChangedEvent
+	tracker.RankChanged.Attach(events.NewClosure(func(ev *RankChangedEvent) {
+		triggered = append(triggered, ev)
+	}))
+
+	// ranked third, outside the threshold
+	tracker.Update(append(others, Node{ID: ownID, Mana: 10}))
+	assert.Equal(t, 3, tracker.Rank())
+	assert.Empty(t, triggered)
+
+	// moves into the top 2
+	tracker.Update([]Node{others[0], {ID: ownID, Mana: 25}, others[1]})
+	assert.Equal(t, 2, tracker.Rank())
+	// moving within the top 2 does not trigger
+	tracker.Update([]Node{{ID: ownID, Mana: 40}, others[0], others[1]})
+	assert.Equal(t, 1, tracker.Rank())
+	if assert.Len(t, triggered, 1) {
+		assert.Equal(t, &RankChangedEvent{NodeID: ownID, OldRank: 3, NewRank: 2, Threshold: threshold}, triggered[0])
+	}
+
+	// losing all mana drops out of the top 2
+	tracker.Update(others)
+	tracker.Update(others)
+	assert.Equal(t, 0, tracker.Rank())
+	if assert.Len(t, triggered, 2) {
+		assert.Equal(t, &RankChangedEvent{NodeID: ownID, OldRank: 1, NewRank: 0, Threshold: threshold}, triggered[1])
+	}
+}
@@ -0,0 +1,102 @@
+package mana
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+)
+
+// TimelinePoint is the mana of a single node at the time of a snapshot.
+type TimelinePoint struct {
+	Time time.Time
+	Mana map[Type]float64
+}
+
+// timelineSnapshot holds the mana maps at a point in time.
+type timelineSnapshot struct {
+	time     time.Time
+	manaMaps map[Type]NodeMap
+}
+
+// Timeline retains the mana maps of a bounded number of the most recent snapshots, so that the past mana of nodes can
+// be returned exactly without recomputing the decay.
+type Timeline struct {
+	mu        sync.RWMutex
+	snapshots []timelineSnapshot
+	// the index of the oldest snapshot once the capacity is reached.
+	start int
+	size  int
+}
+
+// NewTimeline creates a new Timeline retaining the given positive number of snapshots.
+func NewTimeline(size int) *Timeline {
+	return &Timeline{
+		snapshots: make([]timelineSnapshot, 0, size),
+		size:      size,
+	}
+}
+
+// Add adds the mana maps taken at the given time as a snapshot, evicting the oldest snapshot if the timeline is full.
+// Snapshots must be added in chronological order.
+func (t *Timeline) Add(snapshotTime time.Time, manaMaps map[Type]NodeMap) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	snapshot := timelineSnapshot{time: snapshotTime, manaMaps: manaMaps}
+	if len(t.snapshots) < t.size {
+		t.snapshots = append(t.snapshots, snapshot)
+		return
+	}
+	t.snapshots[t.start] = snapshot
+	t.start = (t.start + 1) % t.size
+}
+
+// Len returns the number of retained snapshots.
+func (t *Timeline) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return len(t.snapshots)
+}
+
+// NodeMana returns the mana of the given node at each retained snapshot, oldest first.
+// The mana of a node missing from a mana map of a snapshot is zero.
+func (t *Timeline) NodeMana(nodeID identity.ID) []TimelinePoint {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	points := make([]TimelinePoint, len(t.snapshots))
+	for i := range t.snapshots {
+		snapshot := t.snapshots[(t.start+i)%len(t.snapshots)]
+		points[i] = TimelinePoint{
+			Time: snapshot.time,
+			Mana: make(map[Type]float64, len(snapshot.manaMaps)),
+		}
+		for manaType, manaMap := range snapshot.manaMaps {
+			points[i].Mana[manaType] = manaMap[nodeID]
+		}
+	}
+	return points
+}
+
+// ManaMapAt returns the mana map of the given type of the most recent snapshot taken at or before the given time,
+// together with the time of that snapshot. If all snapshots were taken after the given time, the oldest one is used.
+// It returns false, if no snapshot is retained.
+func (t *Timeline) ManaMapAt(manaType Type, at time.Time) (NodeMap, time.Time, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if len(t.snapshots) == 0 {
+		return nil, time.Time{}, false
+	}
+	snapshot := t.snapshots[t.start]
+	for i := 1; i < len(t.snapshots); i++ {
+		next := t.snapshots[(t.start+i)%len(t.snapshots)]
+		if next.time.After(at) {
+			break
+		}
+		snapshot = next
+	}
+	return snapshot.manaMaps[manaType], snapshot.time, true
+}
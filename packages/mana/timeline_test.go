@@ -0,0 +1,71 @@
+package mana
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeline(t *testing.T) {
+	nodeID, otherID := randNodeID(), randNodeID()
+	timeline := NewTimeline(3)
+	assert.Empty(t, timeline.NodeMana(nodeID))
+
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		timeline.Add(now.Add(time.Duration(i)*time.Minute), map[Type]NodeMap{
+			AccessMana:    {nodeID: float64(i)},
+			ConsensusMana: {nodeID: float64(10 * i), otherID: 1},
+		})
+	}
+	assert.Equal(t, 3, timeline.Len())
+
+	// only the most recent snapshots are retained, oldest first
+	points := timeline.NodeMana(nodeID)
+	assert.Len(t, points, 3)
+	for i, point := range points {
+		assert.True(t, now.Add(time.Duration(i+2)*time.Minute).Equal(point.Time))
+		assert.Equal(t, map[Type]float64{AccessMana: float64(i + 2), ConsensusMana: float64(10 * (i + 2))}, point.Mana)
+	}
+
+	// nodes missing from a mana map have zero mana
+	for _, point := range timeline.NodeMana(otherID) {
+		assert.Equal(t, map[Type]float64{AccessMana: 0, ConsensusMana: 1}, point.Mana)
+	}
+	for _, point := range timeline.NodeMana(identity.GenerateIdentity().ID()) {
+		assert.Equal(t, map[Type]float64{AccessMana: 0, ConsensusMana: 0}, point.Mana)
+	}
+}
+
+func TestTimeline_ManaMapAt(t *testing.T) {
+	nodeID := randNodeID()
+	timeline := NewTimeline(3)
+	_, _, ok := timeline.ManaMapAt(AccessMana, time.Now())
+	assert.False(t, ok)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		timeline.Add(start.Add(time.Duration(i)*time.Minute), map[Type]NodeMap{
+			AccessMana: {nodeID: float64(i)},
+		})
+	}
+
+	// the most recent snapshot at or before the given time is used
+	manaMap, snapshotTime, ok := timeline.ManaMapAt(AccessMana, start.Add(3*time.Minute+time.Second))
+	assert.True(t, ok)
+	assert.True(t, start.Add(3*time.Minute).Equal(snapshotTime))
+	assert.Equal(t, NodeMap{nodeID: 3}, manaMap)
+
+	// the oldest retained snapshot is used for earlier times
+	manaMap, snapshotTime, ok = timeline.ManaMapAt(AccessMana, start)
+	assert.True(t, ok)
+	assert.True(t, start.Add(2*time.Minute).Equal(snapshotTime))
+	assert.Equal(t, NodeMap{nodeID: 2}, manaMap)
+
+	// mana types missing from the snapshot have no mana map
+	manaMap, _, ok = timeline.ManaMapAt(ConsensusMana, start.Add(time.Hour))
+	assert.True(t, ok)
+	assert.Empty(t, manaMap)
+}
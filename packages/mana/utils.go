@@ -47,6 +47,24 @@ func (n NodeMap) ToNodeStrList() []NodeStr {
 	return list
 }
 
+// Normalize returns a copy of the NodeMap in which each mana value is divided by the total mana, i.e. the share of the
+// total mana of each node. If the total mana is zero, all shares are zero.
+func (n NodeMap) Normalize() NodeMap {
+	totalMana := 0.0
+	for _, val := range n {
+		totalMana += val
+	}
+	normalized := make(NodeMap, len(n))
+	for ID, val := range n {
+		if totalMana == 0 {
+			normalized[ID] = 0
+			continue
+		}
+		normalized[ID] = val / totalMana
+	}
+	return normalized
+}
+
 // GetPercentile returns the top percentile the node belongs to relative to the network in terms of mana.
 func (n NodeMap) GetPercentile(node identity.ID) (float64, error) {
 	if len(n) == 0 {
@@ -65,3 +83,44 @@ func (n NodeMap) GetPercentile(node identity.ID) (float64, error) {
 
 	return (nBelow / float64(len(n))) * 100, nil
 }
+
+// GetManaWeightedPercentile returns the share of the total network mana (in percent) held by nodes with mana less than
+// or equal to the mana of the given node. Contrary to GetPercentile, it is weighted by stake rather than by node count.
+func (n NodeMap) GetManaWeightedPercentile(node identity.ID) (float64, error) {
+	if len(n) == 0 {
+		return 0, nil
+	}
+	value, ok := n[node]
+	if !ok {
+		return 0, ErrNodeNotFoundInBaseManaVector
+	}
+	totalMana := 0.0
+	manaBelow := 0.0
+	for _, val := range n {
+		totalMana += val
+		if val <= value {
+			manaBelow += val
+		}
+	}
+	if totalMana == 0 {
+		return 0, nil
+	}
+
+	return (manaBelow / totalMana) * 100, nil
+}
+
+// GetRank returns the rank of the node relative to the network in terms of mana, i.e. the node with the highest mana has
+// rank 1. Nodes with equal mana share the same rank.
+func (n NodeMap) GetRank(node identity.ID) (int, error) {
+	value, ok := n[node]
+	if !ok {
+		return 0, ErrNodeNotFoundInBaseManaVector
+	}
+	rank := 1
+	for _, val := range n {
+		if val > value {
+			rank++
+		}
+	}
+	return rank, nil
+}
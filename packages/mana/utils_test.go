@@ -18,3 +18,68 @@ func TestNodeMap_GetPercentile(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 75.0, percentile)
 }
+
+func TestNodeMap_GetManaWeightedPercentile(t *testing.T) {
+	nodes := make(NodeMap)
+	nodes[identity.GenerateIdentity().ID()] = 1
+	nodes[identity.GenerateIdentity().ID()] = 1
+	nodes[identity.GenerateIdentity().ID()] = 1
+	nodes[identity.GenerateIdentity().ID()] = 1
+	richID := identity.GenerateIdentity().ID()
+	nodes[richID] = 96
+	poorID := identity.GenerateIdentity().ID()
+	nodes[poorID] = 0
+
+	// the rich node holds 96% of the mana but ranks only in the 83rd percentile by count
+	percentile, err := nodes.GetPercentile(richID)
+	assert.NoError(t, err)
+	assert.InDelta(t, 83.33, percentile, 0.01)
+	weighted, err := nodes.GetManaWeightedPercentile(richID)
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, weighted)
+
+	weighted, err = nodes.GetManaWeightedPercentile(poorID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, weighted)
+
+	_, err = nodes.GetManaWeightedPercentile(identity.GenerateIdentity().ID())
+	assert.Error(t, err)
+}
+
+func TestNodeMap_Normalize(t *testing.T) {
+	nodes := make(NodeMap)
+	idA, idB, idC := identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID()
+	nodes[idA] = 1
+	nodes[idB] = 3
+	nodes[idC] = 0
+
+	normalized := nodes.Normalize()
+	assert.Equal(t, NodeMap{idA: 0.25, idB: 0.75, idC: 0}, normalized)
+	// the original map is not modified
+	assert.Equal(t, 3.0, nodes[idB])
+
+	zero := NodeMap{idA: 0, idB: 0}
+	assert.Equal(t, NodeMap{idA: 0, idB: 0}, zero.Normalize())
+	assert.Empty(t, NodeMap{}.Normalize())
+}
+
+func TestNodeMap_GetRank(t *testing.T) {
+	nodes := make(NodeMap)
+	idA, idB, idC := identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID()
+	nodes[idA] = 5
+	nodes[idB] = 10
+	nodes[idC] = 5
+
+	rank, err := nodes.GetRank(idB)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, rank)
+	rank, err = nodes.GetRank(idA)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, rank)
+	rank, err = nodes.GetRank(idC)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, rank)
+
+	_, err = nodes.GetRank(identity.GenerateIdentity().ID())
+	assert.ErrorIs(t, err, ErrNodeNotFoundInBaseManaVector)
+}
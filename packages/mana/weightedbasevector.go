@@ -1,6 +1,7 @@
 package mana
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"sync"
@@ -59,6 +60,21 @@ func (w *WeightedBaseManaVector) Has(nodeID identity.ID) bool {
 func (w *WeightedBaseManaVector) Book(txInfo *TxInfo) {
 	w.Lock()
 	defer w.Unlock()
+	w.book(txInfo)
+}
+
+// BookBatch books mana for a batch of transactions under a single lock acquisition.
+// The transactions are booked in the given order, so the result equals calling Book for each of them.
+func (w *WeightedBaseManaVector) BookBatch(txInfos []*TxInfo) {
+	w.Lock()
+	defer w.Unlock()
+	for _, txInfo := range txInfos {
+		w.book(txInfo)
+	}
+}
+
+// book books mana for a transaction. Not concurrency safe.
+func (w *WeightedBaseManaVector) book(txInfo *TxInfo) {
 	// first, revoke mana from previous owners
 	for _, inputInfo := range txInfo.InputInfos {
 		// which node did the input pledge mana to?
@@ -121,12 +137,25 @@ func (w *WeightedBaseManaVector) Update(nodeID identity.ID, t time.Time) error {
 
 // UpdateAll updates all entries in the base mana vector wrt to `t`.
 func (w *WeightedBaseManaVector) UpdateAll(t time.Time) error {
+	return w.UpdateAllCtx(context.Background(), t)
+}
+
+// UpdateAllCtx updates all entries in the base mana vector wrt to `t`. The context is checked periodically and, once it
+// is done, its error is returned. The entries updated until then stay updated.
+func (w *WeightedBaseManaVector) UpdateAllCtx(ctx context.Context, t time.Time) error {
 	w.Lock()
 	defer w.Unlock()
+	var updated int
 	for nodeID := range w.vector {
+		if updated%updateAllCtxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
 		if err := w.update(nodeID, t); err != nil {
 			return err
 		}
+		updated++
 	}
 	return nil
 }
@@ -138,6 +167,44 @@ func (w *WeightedBaseManaVector) GetMana(nodeID identity.ID, optionalUpdateTime
 	return w.getMana(nodeID, optionalUpdateTime...)
 }
 
+// GetManaWithWeights returns the mana of a node with the given access and consensus weights.
+// Effective Base Mana 1 is weighted with the consensus weight and Effective Base Mana 2 with the access weight.
+func (w *WeightedBaseManaVector) GetManaWithWeights(nodeID identity.ID, accessWeight, consensusWeight float64, optionalUpdateTime ...time.Time) (float64, time.Time, error) {
+	if err := validateWeights(accessWeight, consensusWeight); err != nil {
+		return 0.0, time.Now(), err
+	}
+	w.Lock()
+	defer w.Unlock()
+	_, t, err := w.getMana(nodeID, optionalUpdateTime...)
+	if err != nil {
+		return 0.0, t, err
+	}
+	baseMana := w.vector[nodeID]
+	return baseMana.mana1.EffectiveValue()*consensusWeight + baseMana.mana2.EffectiveValue()*accessWeight, t, nil
+}
+
+// ProjectMana returns the effective mana of a node at the given time, applying the decay from the time of its last
+// update, without updating the vector. It returns ErrProjectionBeforeLastUpdate, if the time predates the last update.
+func (w *WeightedBaseManaVector) ProjectMana(nodeID identity.ID, at time.Time) (float64, error) {
+	w.RLock()
+	defer w.RUnlock()
+	baseMana, exist := w.vector[nodeID]
+	if !exist {
+		return 0.0, ErrNodeNotFoundInBaseManaVector
+	}
+	// the components are projected separately, as revoking may leave them with different update times
+	projected1, projected2 := *baseMana.mana1, *baseMana.mana2
+	mana1, err := projectMana(&projected1, at)
+	if err != nil {
+		return 0.0, err
+	}
+	mana2, err := projectMana(&projected2, at)
+	if err != nil {
+		return 0.0, err
+	}
+	return mana1*baseMana.weight + mana2*(1-baseMana.weight), nil
+}
+
 // GetManaMap returns mana perception of the node..
 func (w *WeightedBaseManaVector) GetManaMap(optionalUpdateTime ...time.Time) (res NodeMap, t time.Time, err error) {
 	w.Lock()
@@ -158,6 +225,38 @@ func (w *WeightedBaseManaVector) GetManaMap(optionalUpdateTime ...time.Time) (re
 	return
 }
 
+// GetManaMapParallel returns mana perception of the node, computed by the given number of workers. A single worker
+// computes the map serially like GetManaMap. The workers only project the mana, the vector is updated and the Updated
+// events are triggered afterwards by the calling goroutine.
+func (w *WeightedBaseManaVector) GetManaMapParallel(workers int, optionalUpdateTime ...time.Time) (res NodeMap, t time.Time, err error) {
+	w.Lock()
+	defer w.Unlock()
+	t = time.Now()
+	if len(optionalUpdateTime) > 0 {
+		t = optionalUpdateTime[0]
+	}
+	nodeIDs := make([]identity.ID, 0, len(w.vector))
+	for ID := range w.vector {
+		nodeIDs = append(nodeIDs, ID)
+	}
+	res, err = parallelManaMap(nodeIDs, workers, func(nodeID identity.ID) (projectedMana, error) {
+		mana1, mana2 := *w.vector[nodeID].mana1, *w.vector[nodeID].mana2
+		projected := &WeightedBaseMana{mana1: &mana1, mana2: &mana2, weight: w.vector[nodeID].weight}
+		// like update, a failed update of mana2 keeps the update of mana1
+		err := projected.update(t)
+		return projectedMana{mana: projected.EffectiveValue(), updated: projected, updateSucceeded: err == nil}, nil
+	}, func(nodeID identity.ID, updated BaseMana, updateSucceeded bool) {
+		oldMana1, oldMana2 := *w.vector[nodeID].mana1, *w.vector[nodeID].mana2
+		*w.vector[nodeID].mana1 = *updated.(*WeightedBaseMana).mana1
+		*w.vector[nodeID].mana2 = *updated.(*WeightedBaseMana).mana2
+		if updateSucceeded {
+			oldMana := &WeightedBaseMana{mana1: &oldMana1, mana2: &oldMana2, weight: w.vector[nodeID].weight}
+			Events().Updated.Trigger(&UpdatedEvent{nodeID, oldMana, w.vector[nodeID], w.Type()})
+		}
+	})
+	return
+}
+
 // GetHighestManaNodes returns the n highest mana nodes in descending order.
 // It also updates the mana values for each node.
 // If n is zero, it returns all nodes.
@@ -299,8 +398,17 @@ func (w *WeightedBaseManaVector) ToPersistables() []*PersistableBaseMana {
 	return result
 }
 
-// FromPersistable fills the WeightedBaseManaVector from persistable mana objects.
-func (w *WeightedBaseManaVector) FromPersistable(p *PersistableBaseMana) (err error) {
+// FromPersistable fills the WeightedBaseManaVector from persistable mana objects, replacing existing entries.
+func (w *WeightedBaseManaVector) FromPersistable(p *PersistableBaseMana) error {
+	return w.FromPersistableWithStrategy(p, Replace)
+}
+
+// FromPersistableWithStrategy fills the WeightedBaseManaVector from persistable mana objects, merging existing entries with the
+// given strategy.
+func (w *WeightedBaseManaVector) FromPersistableWithStrategy(p *PersistableBaseMana, strategy MergeStrategy) (err error) {
+	if err = validateMergeStrategy(strategy); err != nil {
+		return
+	}
 	if p.ManaType != WeightedMana {
 		err = xerrors.Errorf("persistable mana object has type %s instead of %s", p.ManaType.String(), WeightedMana.String())
 		return
@@ -315,7 +423,7 @@ func (w *WeightedBaseManaVector) FromPersistable(p *PersistableBaseMana) (err er
 	}
 	w.Lock()
 	defer w.Unlock()
-	w.vector[p.NodeID] = &WeightedBaseMana{
+	imported := &WeightedBaseMana{
 		mana1: &ConsensusBaseMana{
 			BaseMana1:          p.BaseValues[0],
 			EffectiveBaseMana1: p.EffectiveValues[0],
@@ -328,6 +436,24 @@ func (w *WeightedBaseManaVector) FromPersistable(p *PersistableBaseMana) (err er
 		},
 		weight: w.weight,
 	}
+	existing, ok := w.vector[p.NodeID]
+	if !ok || strategy == Replace {
+		w.vector[p.NodeID] = imported
+		return
+	}
+	if strategy == Sum {
+		// the components are aligned separately, as their update times can differ after a revoke
+		if err = alignUpdateTimes(existing.mana1, imported.mana1); err != nil {
+			return
+		}
+		if err = alignUpdateTimes(existing.mana2, imported.mana2); err != nil {
+			return
+		}
+		existing.mana1.BaseMana1 += imported.mana1.BaseMana1
+		existing.mana1.EffectiveBaseMana1 += imported.mana1.EffectiveBaseMana1
+		existing.mana2.BaseMana2 += imported.mana2.BaseMana2
+		existing.mana2.EffectiveBaseMana2 += imported.mana2.EffectiveBaseMana2
+	}
 	return
 }
 
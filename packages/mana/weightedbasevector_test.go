@@ -565,6 +565,49 @@ func TestWeightedBaseManaVector_GetMana(t *testing.T) {
 	})
 }
 
+func TestWeightedBaseManaVector_GetManaWithWeights(t *testing.T) {
+	bmv, err := NewResearchBaseManaVector(WeightedMana, AccessMana, Mixed)
+	assert.NoError(t, err)
+	randID := randNodeID()
+	_, _, err = bmv.GetManaWithWeights(randID, 0.5, 0.5)
+	assert.Error(t, err)
+
+	now := time.Now()
+	bmv.SetMana(randID, &WeightedBaseMana{
+		mana1: &ConsensusBaseMana{
+			BaseMana1:          10,
+			EffectiveBaseMana1: 10,
+			LastUpdated:        now,
+		},
+		mana2: &AccessBaseMana{
+			BaseMana2:          1,
+			EffectiveBaseMana2: 1,
+			LastUpdated:        now,
+		},
+		weight: Mixed,
+	})
+
+	// the default weights of the vector yield the same result as GetMana
+	defaultMana, _, err := bmv.GetMana(randID, now)
+	assert.NoError(t, err)
+	mana, _, err := bmv.GetManaWithWeights(randID, 1-Mixed, Mixed, now)
+	assert.NoError(t, err)
+	assert.InDelta(t, defaultMana, mana, delta)
+
+	mana, _, err = bmv.GetManaWithWeights(randID, 0.2, 0.8, now)
+	assert.NoError(t, err)
+	assert.InDelta(t, 8.2, mana, delta)
+
+	mana, _, err = bmv.GetManaWithWeights(randID, 1, 0, now)
+	assert.NoError(t, err)
+	assert.InDelta(t, 1.0, mana, delta)
+
+	_, _, err = bmv.GetManaWithWeights(randID, 0.6, 0.6, now)
+	assert.ErrorIs(t, err, ErrInvalidWeightParameter)
+	_, _, err = bmv.GetManaWithWeights(randID, -0.5, 1.5, now)
+	assert.ErrorIs(t, err, ErrInvalidWeightParameter)
+}
+
 func TestWeightedBaseManaVector_ForEach(t *testing.T) {
 	bmv, err := NewResearchBaseManaVector(WeightedMana, AccessMana, Mixed)
 	assert.NoError(t, err)
@@ -922,6 +965,29 @@ func TestWeightedBaseManaVector_FromPersistable(t *testing.T) {
 	})
 }
 
+func TestWeightedBaseManaVector_FromPersistableWithStrategy_Sum(t *testing.T) {
+	id := randNodeID()
+	p := &PersistableBaseMana{
+		ManaType:        WeightedMana,
+		BaseValues:      []float64{10, 50},
+		EffectiveValues: []float64{100, 500},
+		LastUpdated:     baseTime,
+		NodeID:          id,
+	}
+
+	bmv, err := NewResearchBaseManaVector(WeightedMana, AccessMana, Mixed)
+	assert.NoError(t, err)
+	assert.NoError(t, bmv.FromPersistable(p))
+	// both components are summed up
+	assert.NoError(t, bmv.FromPersistableWithStrategy(p, Sum))
+	bmValue := bmv.(*WeightedBaseManaVector).vector[id]
+	assert.Equal(t, 20.0, bmValue.mana1.BaseValue())
+	assert.Equal(t, 100.0, bmValue.mana2.BaseValue())
+	assert.Equal(t, 60.0, bmValue.BaseValue())
+	assert.Equal(t, 600.0, bmValue.EffectiveValue())
+	assert.Equal(t, baseTime, bmValue.LastUpdate())
+}
+
 func TestWeightedBaseManaVector_ToAndFromPersistable(t *testing.T) {
 	bmv, err := NewResearchBaseManaVector(WeightedMana, AccessMana, Mixed)
 	assert.NoError(t, err)
@@ -3,6 +3,7 @@ package tangle
 import (
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/iotaledger/hive.go/autopeering/peer"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
@@ -13,6 +14,7 @@ import (
 	"github.com/mr-tron/base58"
 	"golang.org/x/xerrors"
 
+	"github.com/iotaledger/goshimmer/packages/clock"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/markers"
 	"github.com/iotaledger/goshimmer/packages/tangle/payload"
@@ -36,9 +38,10 @@ type Tangle struct {
 	Utils            *Utils
 	Events           *Events
 
-	setupParserOnce sync.Once
-	syncedMutex     sync.RWMutex
-	synced          bool
+	setupParserOnce   sync.Once
+	syncedMutex       sync.RWMutex
+	synced            bool
+	syncedChangedTime time.Time
 }
 
 // New is the constructor for the Tangle.
@@ -47,6 +50,7 @@ func New(options ...Option) (tangle *Tangle) {
 		Events: &Events{
 			MessageEligible: events.NewEvent(MessageIDCaller),
 			MessageInvalid:  events.NewEvent(MessageIDCaller),
+			SyncChanged:     events.NewEvent(SyncChangedEventCaller),
 			Error:           events.NewEvent(events.ErrorCaller),
 		},
 	}
@@ -148,20 +152,34 @@ func (t *Tangle) Synced() (synced bool) {
 }
 
 // SetSynced allows to set a boolean value that indicates if the Tangle has solidified all messages until the genesis.
+// It triggers the SyncChanged event if the value was modified.
 func (t *Tangle) SetSynced(synced bool) (modified bool) {
 	t.syncedMutex.Lock()
-	defer t.syncedMutex.Unlock()
-
 	if t.synced == synced {
+		t.syncedMutex.Unlock()
 		return
 	}
 
 	t.synced = synced
+	t.syncedChangedTime = clock.SyncedTime()
 	modified = true
+	syncChangedEvent := &SyncChangedEvent{Synced: synced, Time: t.syncedChangedTime}
+	t.syncedMutex.Unlock()
+
+	t.Events.SyncChanged.Trigger(syncChangedEvent)
 
 	return
 }
 
+// SyncedChangedTime returns the time of the last transition of the synced state.
+// It returns the zero time if the synced state has never changed.
+func (t *Tangle) SyncedChangedTime() time.Time {
+	t.syncedMutex.RLock()
+	defer t.syncedMutex.RUnlock()
+
+	return t.syncedChangedTime
+}
+
 // Prune resets the database and deletes all stored objects (good for testing or "node resets").
 func (t *Tangle) Prune() (err error) {
 	return t.Storage.Prune()
@@ -192,6 +210,9 @@ type Events struct {
 	// Fired when a message has been eligible.
 	MessageEligible *events.Event
 
+	// SyncChanged is triggered when the synced state of the Tangle changes.
+	SyncChanged *events.Event
+
 	// Error is triggered when the Tangle faces an error from which it can not recover.
 	Error *events.Event
 }
@@ -201,6 +222,19 @@ func MessageIDCaller(handler interface{}, params ...interface{}) {
 	handler.(func(MessageID))(params[0].(MessageID))
 }
 
+// SyncChangedEvent holds information about a change of the synced state of the Tangle.
+type SyncChangedEvent struct {
+	// Synced is the new synced state.
+	Synced bool
+	// Time is the time at which the synced state changed.
+	Time time.Time
+}
+
+// SyncChangedEventCaller is the caller function for events that hand over a SyncChangedEvent.
+func SyncChangedEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*SyncChangedEvent))(params[0].(*SyncChangedEvent))
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region Options //////////////////////////////////////////////////////////////////////////////////////////////////////
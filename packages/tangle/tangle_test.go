@@ -208,6 +208,38 @@ func TestTangle_StoreMessage(t *testing.T) {
 	messageTangle.Storage.StoreMessage(newMessageOne)
 }
 
+func TestTangle_SyncChanged(t *testing.T) {
+	messageTangle := New()
+	defer messageTangle.Shutdown()
+
+	var syncChangedEvents []*SyncChangedEvent
+	messageTangle.Events.SyncChanged.Attach(events.NewClosure(func(ev *SyncChangedEvent) {
+		syncChangedEvents = append(syncChangedEvents, ev)
+	}))
+
+	assert.False(t, messageTangle.Synced())
+	assert.True(t, messageTangle.SyncedChangedTime().IsZero())
+
+	// setting the same value again does not trigger the event
+	assert.False(t, messageTangle.SetSynced(false))
+	assert.Empty(t, syncChangedEvents)
+
+	assert.True(t, messageTangle.SetSynced(true))
+	require.Len(t, syncChangedEvents, 1)
+	assert.True(t, syncChangedEvents[0].Synced)
+	assert.Equal(t, syncChangedEvents[0].Time, messageTangle.SyncedChangedTime())
+	assert.False(t, messageTangle.SyncedChangedTime().IsZero())
+
+	assert.False(t, messageTangle.SetSynced(true))
+	require.Len(t, syncChangedEvents, 1)
+
+	assert.True(t, messageTangle.SetSynced(false))
+	require.Len(t, syncChangedEvents, 2)
+	assert.False(t, syncChangedEvents[1].Synced)
+	assert.Equal(t, syncChangedEvents[1].Time, messageTangle.SyncedChangedTime())
+	assert.False(t, syncChangedEvents[1].Time.Before(syncChangedEvents[0].Time))
+}
+
 func TestTangle_MissingMessages(t *testing.T) {
 	const (
 		messageCount = 20000
@@ -0,0 +1,59 @@
+package vote
+
+import (
+	"sync"
+
+	"github.com/iotaledger/hive.go/events"
+)
+
+// HandlerCountingEvent is an events.Event which keeps track of its attached handlers. This allows the trigger side to
+// skip collecting the event data if nobody is interested in it.
+type HandlerCountingEvent struct {
+	*events.Event
+
+	mu       sync.RWMutex
+	handlers map[uintptr]struct{}
+}
+
+// NewHandlerCountingEvent creates a new HandlerCountingEvent using the given caller to invoke its handlers.
+func NewHandlerCountingEvent(caller func(handler interface{}, params ...interface{})) *HandlerCountingEvent {
+	return &HandlerCountingEvent{
+		Event:    events.NewEvent(caller),
+		handlers: make(map[uintptr]struct{}),
+	}
+}
+
+// Attach attaches the given closure as a handler of the event.
+func (e *HandlerCountingEvent) Attach(closure *events.Closure) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.handlers[closure.Id] = struct{}{}
+	e.Event.Attach(closure)
+}
+
+// Detach detaches the given closure from the event.
+func (e *HandlerCountingEvent) Detach(closure *events.Closure) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	delete(e.handlers, closure.Id)
+	e.Event.Detach(closure)
+}
+
+// DetachAll detaches all handlers from the event.
+func (e *HandlerCountingEvent) DetachAll() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.handlers = make(map[uintptr]struct{})
+	e.Event.DetachAll()
+}
+
+// HasHandlers returns whether at least one handler is attached to the event.
+func (e *HandlerCountingEvent) HasHandlers() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return len(e.handlers) > 0
+}
@@ -0,0 +1,21 @@
+package fpc
+
+import (
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/clock"
+)
+
+// Clock provides the current time to FPC.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// syncedClock is the default Clock which is backed by the synced time.
+type syncedClock struct{}
+
+// Now returns the synced time.
+func (syncedClock) Now() time.Time {
+	return clock.SyncedTime()
+}
@@ -7,25 +7,35 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"go.uber.org/atomic"
 
-	"github.com/iotaledger/goshimmer/packages/clock"
 	"github.com/iotaledger/goshimmer/packages/vote"
 	"github.com/iotaledger/goshimmer/packages/vote/opinion"
 )
 
-const (
-	toleranceTotalMana = 0.001
-)
-
 var (
 	// ErrVoteAlreadyOngoing is returned if a vote is already going on for the given ID.
 	ErrVoteAlreadyOngoing = errors.New("a vote is already ongoing for the given ID")
 	// ErrNoOpinionGiversAvailable is returned if a round cannot be performed as no opinion gives are available.
 	ErrNoOpinionGiversAvailable = errors.New("can't perform round as no opinion givers are available")
+	// ErrInvalidSeed is returned if a vote context can not be seeded with the given state.
+	ErrInvalidSeed = errors.New("invalid vote context seed")
+	// ErrInsufficientResponses is triggered on the Error event if too few of the sampled opinion givers responded
+	// in a round to form opinions on its results.
+	ErrInsufficientResponses = errors.New("insufficient opinion giver responses")
+	// ErrOwnManaUnavailable is triggered on the Error event if the own mana could not be retrieved and the last known
+	// own mana is used instead.
+	ErrOwnManaUnavailable = errors.New("own mana unavailable")
+	// ErrUnverifiedResponse is returned if the response of an opinion giver could not be verified with VerifyResponse.
+	ErrUnverifiedResponse = errors.New("unverified opinion giver response")
+	// ErrInvalidResponse is returned if the response of an opinion giver failed the validation of ValidateResponse.
+	ErrInvalidResponse = errors.New("invalid opinion giver response")
 )
 
 // New creates a new FPC instance.
@@ -34,20 +44,36 @@ func New(opinionGiverFunc opinion.OpinionGiverFunc, ownWeightRetrieverFunc opini
 		opinionGiverFunc:       opinionGiverFunc,
 		ownWeightRetrieverFunc: ownWeightRetrieverFunc,
 		paras:                  DefaultParameters(),
-		opinionGiverRng:        rand.New(rand.NewSource(clock.SyncedTime().UnixNano())),
+		clock:                  syncedClock{},
 		ctxs:                   make(map[string]*vote.Context),
 		queue:                  list.New(),
 		queueSet:               make(map[string]struct{}),
+		seenOpinionGivers:      make(map[identity.ID]struct{}),
+		deferredVoteCtxs:       make(map[string]struct{}),
+		voteParas:              make(map[string]*Parameters),
 		events: vote.Events{
 			Finalized:     events.NewEvent(vote.OpinionCaller),
 			Failed:        events.NewEvent(vote.OpinionCaller),
-			RoundExecuted: events.NewEvent(vote.RoundStatsCaller),
+			RoundExecuted: vote.NewHandlerCountingEvent(vote.RoundStatsCaller),
 			Error:         events.NewEvent(events.ErrorCaller),
 		},
 	}
 	if len(paras) > 0 {
 		f.paras = paras[0]
 	}
+	f.opinionGiverRng = rand.New(rand.NewSource(f.clock.Now().UnixNano()))
+	if f.paras.RoundStatsBufferSize > 0 {
+		f.recentRounds = newRoundStatsBuffer(f.paras.RoundStatsBufferSize)
+	}
+	if f.paras.OpinionGiverHistorySize > 0 {
+		f.opinionHistory = newOpinionHistory(f.paras.OpinionGiverHistorySize)
+	}
+	if f.paras.HealthWindow > 0 {
+		f.giverHealth = newGiverHealth(f.paras.HealthWindow)
+	}
+	if f.paras.QuorumCertificates {
+		f.quorumCertificates = make(map[string]*vote.QuorumCertificate)
+	}
 	return f
 }
 
@@ -67,26 +93,117 @@ type FPC struct {
 	ctxsMu sync.RWMutex
 	// parameters to use within FPC.
 	paras *Parameters
-	// indicates whether the last round was performed successfully.
-	lastRoundCompletedSuccessfully bool
+	// the parameters overriding paras for single vote contexts, guarded by ctxsMu.
+	voteParas map[string]*Parameters
+	// indicates whether the most recent round was performed successfully.
+	lastRoundSuccessful atomic.Bool
+	// indicates whether the voting is paused.
+	paused atomic.Bool
+	// the distinct opinion givers seen across rounds until the warm-up is completed.
+	seenOpinionGivers map[identity.ID]struct{}
+	// indicates whether enough distinct opinion givers were seen to form opinions.
+	warmedUp bool
+	// the vote contexts which were not queried in the last round as they exceeded MaxQueriesPerRound.
+	deferredVoteCtxs map[string]struct{}
 	// used to randomly select opinion givers.
 	opinionGiverRng *rand.Rand
+	// the source of all time reads.
+	clock Clock
+	// cumulative counters of the vote contexts finalized as Like, finalized as Dislike and failed.
+	likeFinalizedCount    atomic.Uint64
+	dislikeFinalizedCount atomic.Uint64
+	failedCount           atomic.Uint64
+	// running means of the rounds it took to finalize vote contexts as Like and as Dislike, guarded by ctxsMu.
+	avgRoundsToFinalizeLike    float64
+	avgRoundsToFinalizeDislike float64
+	// cumulative counters of the failed queries per failure class.
+	queryFailureCounts [numQueryFailures]atomic.Uint64
+	// records the executed rounds if a recording is ongoing.
+	recorder recorder
+	// the stats of the most recent rounds, nil if RoundStatsBufferSize is zero.
+	recentRounds *roundStatsBuffer
+	// the recent opinions of the opinion givers, nil if OpinionGiverHistorySize is zero.
+	opinionHistory *opinionHistory
+	// the recent query timeouts of the opinion givers, nil if HealthWindow is zero.
+	giverHealth *giverHealth
+	// the quorum certificates of the last rounds in which the vote contexts received enough opinions, nil if
+	// QuorumCertificates is disabled. Guarded by ctxsMu.
+	quorumCertificates map[string]*vote.QuorumCertificate
+	// the time of the first round, used for the startup grace period.
+	startTime time.Time
+	// the own mana cached for OwnManaCacheTTL or kept for TolerateOwnManaError and the time it was retrieved.
+	cachedOwnMana       float64
+	cachedOwnManaUpdate time.Time
+	// the opinion givers cached for OpinionGiverRefreshInterval and the time they were retrieved.
+	cachedOpinionGivers       []opinion.OpinionGiver
+	cachedOpinionGiversUpdate time.Time
+	cachedOpinionGiversMu     sync.Mutex
 }
 
 // Vote sets an initial opinion on the vote context and enqueues the vote context.
 func (f *FPC) Vote(id string, objectType vote.ObjectType, initOpn opinion.Opinion) error {
+	return f.VoteWithParams(id, objectType, initOpn, nil)
+}
+
+// VoteWithParams sets an initial opinion on the vote context and enqueues the vote context, which is voted on using
+// the given parameters instead of the ones of FPC, e.g. to finalize urgent conflicts on a tighter schedule.
+// Only the thresholds, the round schedule, i.e. the cooling off period, finalization rounds, fixed threshold rounds,
+// max rounds and immediate finalization proportion, and the finalization quorum, i.e. the minimum opinions received,
+// the minimum mana share and the max re-votes, are overridden. If paras is nil, the parameters of FPC are used.
+func (f *FPC) VoteWithParams(id string, objectType vote.ObjectType, initOpn opinion.Opinion, paras *Parameters) error {
 	f.queueMu.Lock()
 	defer f.queueMu.Unlock()
-	f.ctxsMu.RLock()
-	defer f.ctxsMu.RUnlock()
+	f.ctxsMu.Lock()
+	defer f.ctxsMu.Unlock()
 	if _, alreadyQueued := f.queueSet[id]; alreadyQueued {
 		return fmt.Errorf("%w: %s", ErrVoteAlreadyOngoing, id)
 	}
 	if _, alreadyOngoing := f.ctxs[id]; alreadyOngoing {
 		return fmt.Errorf("%w: %s", ErrVoteAlreadyOngoing, id)
 	}
-	f.queue.PushBack(vote.NewContext(id, objectType, initOpn))
+	voteCtx := vote.NewContext(id, objectType, initOpn)
+	voteCtx.EnqueueTime = f.clock.Now()
+	f.queue.PushBack(voteCtx)
 	f.queueSet[id] = struct{}{}
+	if paras != nil {
+		f.voteParas[id] = paras
+	}
+	return nil
+}
+
+// returns the parameters used for the vote context with the given ID. It must be called while holding ctxsMu.
+func (f *FPC) parasFor(id string) *Parameters {
+	if paras, ok := f.voteParas[id]; ok {
+		return paras
+	}
+	return f.paras
+}
+
+// SeedOpinion installs a vote context which already progressed elsewhere, e.g. on the node an operator migrates from.
+// In contrast to Vote, the vote context is not enqueued but directly continues with the given opinion history after
+// the given number of rounds.
+func (f *FPC) SeedOpinion(id string, objectType vote.ObjectType, history []opinion.Opinion, rounds int) error {
+	if len(history) == 0 || rounds < 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidSeed, id)
+	}
+
+	f.queueMu.Lock()
+	defer f.queueMu.Unlock()
+	f.ctxsMu.Lock()
+	defer f.ctxsMu.Unlock()
+	if _, alreadyQueued := f.queueSet[id]; alreadyQueued {
+		return fmt.Errorf("%w: %s", ErrVoteAlreadyOngoing, id)
+	}
+	if _, alreadyOngoing := f.ctxs[id]; alreadyOngoing {
+		return fmt.Errorf("%w: %s", ErrVoteAlreadyOngoing, id)
+	}
+	voteCtx := vote.NewContext(id, objectType, history[0])
+	for _, opn := range history[1:] {
+		voteCtx.AddOpinion(opn)
+	}
+	voteCtx.Rounds = rounds
+	voteCtx.EnqueueTime = f.clock.Now()
+	f.ctxs[id] = voteCtx
 	return nil
 }
 
@@ -102,6 +219,151 @@ func (f *FPC) IntermediateOpinion(id string) (opinion.Opinion, error) {
 	return voteCtx.LastOpinion(), nil
 }
 
+// ActiveVoteContexts returns a snapshot of the currently active vote contexts.
+// The returned vote contexts are copies which are not modified by subsequent rounds.
+func (f *FPC) ActiveVoteContexts() map[string]*vote.Context {
+	f.ctxsMu.RLock()
+	defer f.ctxsMu.RUnlock()
+
+	voteCtxs := make(map[string]*vote.Context, len(f.ctxs))
+	for id, voteCtx := range f.ctxs {
+		voteCtxCopy := *voteCtx
+		voteCtxCopy.Opinions = append([]opinion.Opinion(nil), voteCtx.Opinions...)
+		voteCtxs[id] = &voteCtxCopy
+	}
+	return voteCtxs
+}
+
+// QueueLength returns the amount of vote contexts which are enqueued to be voted on in the next round.
+func (f *FPC) QueueLength() int {
+	f.queueMu.Lock()
+	defer f.queueMu.Unlock()
+	return f.queue.Len()
+}
+
+// LastRoundSuccessful returns whether the most recent round was performed successfully.
+func (f *FPC) LastRoundSuccessful() bool {
+	return f.lastRoundSuccessful.Load()
+}
+
+// Counters returns the cumulative amount of vote contexts which were finalized as Like, finalized as Dislike
+// and which failed to be finalized.
+func (f *FPC) Counters() (likeFinal, dislikeFinal, failed uint64) {
+	return f.likeFinalizedCount.Load(), f.dislikeFinalizedCount.Load(), f.failedCount.Load()
+}
+
+// AvgRoundsToFinalize returns the average amount of rounds it took to finalize vote contexts as Like
+// and as Dislike.
+func (f *FPC) AvgRoundsToFinalize() (like, dislike float64) {
+	f.ctxsMu.RLock()
+	defer f.ctxsMu.RUnlock()
+	return f.avgRoundsToFinalizeLike, f.avgRoundsToFinalizeDislike
+}
+
+// QueryFailures returns the cumulative amount of failed opinion giver queries of the given failure class.
+func (f *FPC) QueryFailures(failure QueryFailure) uint64 {
+	if failure >= numQueryFailures {
+		return 0
+	}
+	return f.queryFailureCounts[failure].Load()
+}
+
+// RecentRounds returns the stats of up to limit of the most recent rounds, newest first.
+// A limit of zero returns all retained rounds. No rounds are retained if RoundStatsBufferSize is zero.
+func (f *FPC) RecentRounds(limit int) []*vote.RoundStats {
+	if f.recentRounds == nil {
+		return nil
+	}
+	return f.recentRounds.recent(limit)
+}
+
+// OpinionGiverHistory returns the most recent opinions the opinion giver with the given ID provided on each active
+// vote context, oldest first. No opinions are retained if OpinionGiverHistorySize is zero.
+func (f *FPC) OpinionGiverHistory(id string) map[string][]opinion.Opinion {
+	if f.opinionHistory == nil {
+		return nil
+	}
+	return f.opinionHistory.get(id)
+}
+
+// OpinionGiverTimeoutRate returns the share of timed out queries among the last HealthWindow queries of the opinion
+// giver with the given ID. The sampling weight of the opinion giver is scaled down by this rate. It is always zero if
+// HealthWindow is zero.
+func (f *FPC) OpinionGiverTimeoutRate(id identity.ID) float64 {
+	if f.giverHealth == nil {
+		return 0
+	}
+	return f.giverHealth.timeoutRate(id)
+}
+
+// FinalizationBlocker returns a human-readable reason why the vote context with the given ID is not finalized yet,
+// based on its current state and the parameters. If the vote is not found for the specified ID, it returns with error
+// ErrVotingNotFound.
+func (f *FPC) FinalizationBlocker(id string) (string, error) {
+	f.ctxsMu.RLock()
+	voteCtx, has := f.ctxs[id]
+	var blocker string
+	if has {
+		blocker = f.finalizationBlocker(voteCtx)
+	}
+	f.ctxsMu.RUnlock()
+	if has {
+		return blocker, nil
+	}
+
+	f.queueMu.Lock()
+	defer f.queueMu.Unlock()
+	if _, queued := f.queueSet[id]; queued {
+		return "enqueued, not voted on yet", nil
+	}
+	return "", fmt.Errorf("%w: %s", vote.ErrVotingNotFound, id)
+}
+
+// returns the reason why the given vote context is not finalized. It must be called while holding ctxsMu.
+func (f *FPC) finalizationBlocker(voteCtx *vote.Context) string {
+	paras := f.parasFor(voteCtx.ID)
+	if voteCtx.Rounds > 0 && voteCtx.OpinionsReceived < paras.MinOpinionsReceived {
+		return fmt.Sprintf("insufficient opinions in the last round: received %d, need %d", voteCtx.OpinionsReceived, paras.MinOpinionsReceived)
+	}
+	if voteCtx.IsNew() {
+		return "no opinions received yet"
+	}
+
+	// the first opinion is the initial one, all further ones were formed in a round
+	formedOpinions := len(voteCtx.Opinions) - 1
+	if formedOpinions < paras.TotalRoundsCoolingOffPeriod {
+		return fmt.Sprintf("in cooling off period: %d of %d rounds", formedOpinions, paras.TotalRoundsCoolingOffPeriod)
+	}
+	lastOpinion := voteCtx.LastOpinion()
+	if lastOpinion == opinion.Abstain {
+		return fmt.Sprintf("abstained in the last round: liked proportion %.2f is too close to the threshold", voteCtx.ProportionLiked)
+	}
+
+	// count the rounds after the cooling off period for which the last opinion was held
+	held := 0
+	for i := len(voteCtx.Opinions) - 1; i > paras.TotalRoundsCoolingOffPeriod && voteCtx.Opinions[i] == lastOpinion; i-- {
+		held++
+	}
+	if held < paras.TotalRoundsFinalization {
+		return fmt.Sprintf("proportion unstable: opinion %s held for %d of %d rounds", lastOpinion, held, paras.TotalRoundsFinalization)
+	}
+	if !hasManaShareForFinalization(voteCtx, paras) {
+		var manaShare float64
+		if voteCtx.Weights.TotalWeights > 0 {
+			manaShare = (voteCtx.Weights.RespondedWeights + voteCtx.Weights.OwnWeight) / voteCtx.Weights.TotalWeights
+		}
+		return fmt.Sprintf("below mana share quorum: %.2f responded, need %.2f", manaShare, paras.MinManaShareForFinalization)
+	}
+	return "none, finalized in the next round"
+}
+
+// SetClock sets the clock used for all time reads of FPC and reseeds the random selection of opinion givers from it.
+// It must not be called concurrently to Round.
+func (f *FPC) SetClock(c Clock) {
+	f.clock = c
+	f.opinionGiverRng = rand.New(rand.NewSource(c.Now().UnixNano()))
+}
+
 // Events returns the events which happen on a vote.
 func (f *FPC) Events() vote.Events {
 	return f.events
@@ -110,45 +372,141 @@ func (f *FPC) Events() vote.Events {
 // Round enqueues new items, sets opinions on active vote contexts, finalizes them and then
 // queries for opinions.
 func (f *FPC) Round(rand float64) error {
-	start := time.Now()
+	start := f.clock.Now()
+	if f.startTime.IsZero() {
+		f.startTime = start
+	}
 	// enqueue new voting contexts
 	f.enqueue()
+	// during the warm-up the vote contexts are kept in their cooling off period
+	warmedUp := f.isWarmedUp()
+
+	// while paused, only the rounds are counted. The opinions of the rounds before the pause are outdated afterwards,
+	// therefore the first round after resuming only queries opinions again.
+	if f.paused.Load() {
+		f.lastRoundSuccessful.Store(false)
+		if warmedUp {
+			f.countRound()
+		}
+		return nil
+	}
+
 	// we can only form opinions when the last round was actually executed successfully
-	if f.lastRoundCompletedSuccessfully {
+	if f.lastRoundSuccessful.Load() && warmedUp {
 		// form opinions by using the random number supplied for this new round
 		f.formOpinions(rand)
 		// clean opinions on vote contexts where an opinion was reached in TotalRoundFinalization
 		// number of rounds and clear those who failed to be finalized in MaxRoundsPerVoteContext.
-		f.finalizeOpinions()
+		f.finalizeOpinions(start)
 	}
 
-	// mark a round being done, even though there's no opinion,
-	// so this voting context will be cleared eventually
-	f.ctxsMu.Lock()
-	for voteObjectID := range f.ctxs {
-		f.ctxs[voteObjectID].Rounds++
+	if warmedUp {
+		f.countRound()
 	}
-	f.ctxsMu.Unlock()
 
 	// query for opinions on the current vote contexts
-	queriedOpinions, err := f.queryOpinions()
+	queriedOpinions, agreementRate, err := f.queryOpinions()
+	if errors.Is(err, ErrInsufficientResponses) {
+		// the round failed, but only the opinions are not formed on its results
+		f.lastRoundSuccessful.Store(false)
+		f.events.Error.Trigger(err)
+		return nil
+	}
+	f.lastRoundSuccessful.Store(err == nil)
 	if err == nil {
-		f.lastRoundCompletedSuccessfully = true
-		// execute a round executed event
-		roundStats := &vote.RoundStats{
-			Duration:           time.Since(start),
-			RandUsed:           rand,
-			ActiveVoteContexts: f.ctxs,
-			QueriedOpinions:    queriedOpinions,
+		// the round stats are only collected if somebody is interested in them
+		if hasHandlers := f.events.RoundExecuted.HasHandlers(); hasHandlers || f.recentRounds != nil {
+			roundStats := &vote.RoundStats{
+				Duration:           f.clock.Now().Sub(start),
+				RandUsed:           rand,
+				ActiveVoteContexts: f.ctxs,
+				QueriedOpinions:    queriedOpinions,
+				AgreementRate:      agreementRate,
+			}
+			if hasHandlers {
+				f.events.RoundExecuted.Trigger(roundStats)
+			}
+			if f.recentRounds != nil {
+				f.recentRounds.add(roundStats)
+			}
+		}
+		if f.opinionHistory != nil {
+			f.opinionHistory.add(queriedOpinions)
 		}
-		// TODO: add possibility to check whether an event handler is registered
-		// in order to prevent the collection of the round stats data if not needed
-		f.events.RoundExecuted.Trigger(roundStats)
 	}
 
 	return err
 }
 
+// marks a round being done on the active vote contexts, even though there's no opinion,
+// so that they will be cleared eventually.
+func (f *FPC) countRound() {
+	f.ctxsMu.Lock()
+	defer f.ctxsMu.Unlock()
+	for voteObjectID := range f.ctxs {
+		// deferred vote contexts were not queried, so their round did not take place
+		if _, deferred := f.deferredVoteCtxs[voteObjectID]; deferred {
+			continue
+		}
+		f.ctxs[voteObjectID].Rounds++
+	}
+}
+
+// Pause pauses the voting, e.g. during a known bad network period. While paused, rounds still enqueue new vote
+// contexts and count their rounds, but neither form opinions, nor finalize vote contexts, nor query opinions.
+func (f *FPC) Pause() {
+	f.paused.Store(true)
+}
+
+// Resume resumes the voting after Pause. The first round after resuming queries the opinions before any opinions are
+// formed again.
+func (f *FPC) Resume() {
+	f.paused.Store(false)
+}
+
+// IsPaused returns whether the voting is paused.
+func (f *FPC) IsPaused() bool {
+	return f.paused.Load()
+}
+
+// Run executes a round every interval until the given context is cancelled, using the random numbers provided by
+// randSource. Errors of the rounds are triggered on the Error event. Run blocks until the context is cancelled and
+// must not be called concurrently to Round.
+func (f *FPC) Run(ctx context.Context, interval time.Duration, randSource func() float64) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := f.Round(randSource()); err != nil {
+				f.events.Error.Trigger(err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// checks whether enough distinct opinion givers were seen to form opinions.
+func (f *FPC) isWarmedUp() bool {
+	return f.warmedUp || f.paras.WarmupMinGivers <= 0
+}
+
+// tracks the distinct opinion givers until the warm-up is completed.
+func (f *FPC) trackOpinionGivers(opinionGivers []opinion.OpinionGiver) {
+	if f.isWarmedUp() {
+		return
+	}
+	for _, opinionGiver := range opinionGivers {
+		f.seenOpinionGivers[opinionGiver.ID()] = struct{}{}
+	}
+	if len(f.seenOpinionGivers) >= f.paras.WarmupMinGivers {
+		f.warmedUp = true
+		f.seenOpinionGivers = nil
+	}
+}
+
 // enqueues items for voting
 func (f *FPC) enqueue() {
 	f.queueMu.Lock()
@@ -173,11 +531,29 @@ func (f *FPC) formOpinions(rand float64) {
 		if voteCtx.IsNew() {
 			continue
 		}
+		// a deferred vote context received no new opinions to form its opinion with
+		if _, deferred := f.deferredVoteCtxs[voteCtx.ID]; deferred {
+			continue
+		}
 		lowerThreshold, upperThreshold := f.setThreshold(voteCtx)
 
 		eta := f.biasTowardsOwnOpinion(voteCtx)
+		threshold := RandUniformThreshold(rand, lowerThreshold, upperThreshold)
 
-		if eta >= RandUniformThreshold(rand, lowerThreshold, upperThreshold) {
+		if f.paras.AbstainBand > 0 && math.Abs(eta-threshold) < f.paras.AbstainBand {
+			voteCtx.AddOpinion(opinion.Abstain)
+			continue
+		}
+		// the last opinion is only flipped if the threshold is crossed by the hysteresis margin
+		if f.paras.HysteresisMargin > 0 {
+			switch voteCtx.LastOpinion() {
+			case opinion.Like:
+				threshold -= f.paras.HysteresisMargin
+			case opinion.Dislike:
+				threshold += f.paras.HysteresisMargin
+			}
+		}
+		if eta >= threshold {
 			voteCtx.AddOpinion(opinion.Like)
 			continue
 		}
@@ -186,59 +562,160 @@ func (f *FPC) formOpinions(rand float64) {
 }
 
 // emits a Voted event for every finalized vote context (or Failed event if failed) and then removes it from FPC.
-func (f *FPC) finalizeOpinions() {
+// During the startup grace period, vote contexts are not failed.
+func (f *FPC) finalizeOpinions(now time.Time) {
+	inGracePeriod := f.paras.StartupGracePeriod > 0 && now.Sub(f.startTime) < f.paras.StartupGracePeriod
+
 	f.ctxsMu.Lock()
 	defer f.ctxsMu.Unlock()
 	for id, voteCtx := range f.ctxs {
-		if voteCtx.IsFinalized(f.paras.TotalRoundsCoolingOffPeriod, f.paras.TotalRoundsFinalization) {
-			f.events.Finalized.Trigger(&vote.OpinionEvent{ID: id, Opinion: voteCtx.LastOpinion(), Ctx: *voteCtx})
-			delete(f.ctxs, id)
+		paras := f.parasFor(id)
+		if (voteCtx.IsFinalized(paras.TotalRoundsCoolingOffPeriod, paras.TotalRoundsFinalization) || isOverwhelming(voteCtx, paras)) && hasManaShareForFinalization(voteCtx, paras) {
+			switch voteCtx.LastOpinion() {
+			case opinion.Like:
+				count := f.likeFinalizedCount.Inc()
+				f.avgRoundsToFinalizeLike += (float64(voteCtx.Rounds) - f.avgRoundsToFinalizeLike) / float64(count)
+			case opinion.Dislike:
+				count := f.dislikeFinalizedCount.Inc()
+				f.avgRoundsToFinalizeDislike += (float64(voteCtx.Rounds) - f.avgRoundsToFinalizeDislike) / float64(count)
+			}
+			voteCtx.FinalizationReason = finalizationReason(voteCtx, paras)
+			voteCtx.FinalizeTime = now
+			f.events.Finalized.Trigger(&vote.OpinionEvent{ID: id, Opinion: voteCtx.LastOpinion(), Ctx: *voteCtx, Reason: voteCtx.FinalizationReason, QuorumCertificate: f.quorumCertificates[id]})
+			f.removeVoteContext(id)
 			continue
 		}
-		if voteCtx.Rounds >= f.paras.MaxRoundsPerVoteContext {
-			f.events.Failed.Trigger(&vote.OpinionEvent{ID: id, Opinion: voteCtx.LastOpinion(), Ctx: *voteCtx})
-			delete(f.ctxs, id)
+		if voteCtx.Rounds >= paras.MaxRoundsPerVoteContext && !inGracePeriod {
+			// a vote context which never received enough opinions failed due to a transient failure,
+			// therefore it is voted on again by resetting its rounds.
+			if voteCtx.IsNew() && voteCtx.ReVotes < paras.MaxReVotes {
+				voteCtx.ReVotes++
+				voteCtx.Rounds = 0
+				continue
+			}
+			f.failedCount.Inc()
+			voteCtx.FinalizationReason = vote.MaxRoundsExceeded
+			f.events.Failed.Trigger(&vote.OpinionEvent{ID: id, Opinion: voteCtx.LastOpinion(), Ctx: *voteCtx, Reason: voteCtx.FinalizationReason})
+			f.removeVoteContext(id)
 		}
 	}
 }
 
+// removes the vote context with the given ID together with its opinion history. It must be called while holding ctxsMu.
+func (f *FPC) removeVoteContext(id string) {
+	delete(f.ctxs, id)
+	delete(f.voteParas, id)
+	delete(f.quorumCertificates, id)
+	if f.opinionHistory != nil {
+		f.opinionHistory.remove(id)
+	}
+}
+
+// returns why the given finalized vote context was finalized: immediately due to an overwhelming liked proportion,
+// or by holding its opinion, which was formed using either the fixed threshold or the random threshold.
+func finalizationReason(voteCtx *vote.Context, paras *Parameters) vote.FinalizationReason {
+	if !voteCtx.IsFinalized(paras.TotalRoundsCoolingOffPeriod, paras.TotalRoundsFinalization) {
+		return vote.OverwhelmingProportion
+	}
+	if paras.TotalRoundsFixedThreshold > 0 && voteCtx.HadFixedRound(paras.TotalRoundsCoolingOffPeriod, paras.TotalRoundsFinalization, paras.TotalRoundsFixedThreshold) {
+		return vote.FixedThreshold
+	}
+	return vote.StableProportion
+}
+
+// checks whether the given vote context can be finalized immediately under the given parameters as its liked
+// proportion is overwhelmingly one-sided.
+func isOverwhelming(voteCtx *vote.Context, paras *Parameters) bool {
+	if paras.ImmediateFinalizationProportion <= 0 {
+		return false
+	}
+	return voteCtx.IsOverwhelming(paras.TotalRoundsCoolingOffPeriod, paras.ImmediateFinalizationProportion)
+}
+
+// checks whether the opinion givers which responded to the last query of the given vote context,
+// together with the own mana, hold at least MinManaShareForFinalization of the given parameters of the total mana.
+func hasManaShareForFinalization(voteCtx *vote.Context, paras *Parameters) bool {
+	if paras.MinManaShareForFinalization <= 0 {
+		return true
+	}
+	if voteCtx.Weights.TotalWeights == 0 {
+		return false
+	}
+	return (voteCtx.Weights.RespondedWeights+voteCtx.Weights.OwnWeight)/voteCtx.Weights.TotalWeights >= paras.MinManaShareForFinalization
+}
+
 // queries the opinions of QuerySampleSize amount of OpinionGivers.
-func (f *FPC) queryOpinions() ([]opinion.QueriedOpinions, error) {
-	conflictIDs, timestampIDs := f.voteContextIDs()
+// It returns the queried opinions and the agreement rate with the majority opinion per vote context.
+func (f *FPC) queryOpinions() ([]opinion.QueriedOpinions, map[string]float64, error) {
+	objectIDs := f.voteContextIDs()
 
 	// nothing to vote on
-	if len(conflictIDs) == 0 && len(timestampIDs) == 0 {
-		return nil, nil
+	if objectIDs.Len() == 0 {
+		return nil, nil, nil
 	}
 
-	opinionGivers, err := f.opinionGiverFunc()
+	opinionGivers, err := f.opinionGivers()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if f.paras.ProbeBeforeRound {
+		opinionGivers = f.probeOpinionGivers(opinionGivers)
 	}
+	f.trackOpinionGivers(opinionGivers)
 
 	// nobody to query
 	if len(opinionGivers) == 0 {
-		return nil, ErrNoOpinionGiversAvailable
+		return nil, nil, ErrNoOpinionGiversAvailable
 	}
 
 	// select a random subset of opinion givers to query.
 	// if the same opinion giver is selected multiple times, we query it only once
 	// but use its opinion N selected times.
-	opinionGiversToQuery, totalOpinionGiversMana := ManaBasedSampling(opinionGivers, f.paras.MaxQuerySampleSize, f.paras.QuerySampleSize, f.opinionGiverRng)
+	opinionGiversToQuery, totalOpinionGiversMana := f.sampleOpinionGivers(opinionGivers)
+
+	// limit the vote contexts to query to the query budget of this round
+	objectIDs = f.applyQueryBudget(opinionGiversToQuery, objectIDs)
+	// the opinions are given in the order of the IDs
+	ids := objectIDs.IDs()
 
 	// get own mana and calculate total mana
-	ownMana, err := f.ownWeightRetrieverFunc()
+	ownMana, err := f.ownMana()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	totalMana := totalOpinionGiversMana + ownMana
 
-	// create vote Map for existing conflict ids and timestamps
-	voteMap := createVoteMapForConflicts(conflictIDs, timestampIDs)
+	// create vote Map for the queried vote contexts
+	voteMap := createVoteMap(ids)
 	var voteMapMu sync.Mutex
 
+	// mana and amount of the opinion givers which responded
+	respondedMana := 0.0
+	respondedCount := 0
+	// the mana of the opinion givers which responded by their IDs, only collected for the quorum certificates
+	var respondedGiverMana map[string]float64
+	if f.quorumCertificates != nil {
+		respondedGiverMana = make(map[string]float64)
+	}
+
 	// holds queried opinions
 	allQueriedOpinions := []opinion.QueriedOpinions{}
+	// holds the errors of the failed queries
+	var queryErrs []error
+
+	// no queries are started after the round budget elapsed, the round then proceeds with the responses so far
+	budgetCtx := context.Background()
+	if f.paras.RoundBudget > 0 {
+		var cancelBudget context.CancelFunc
+		budgetCtx, cancelBudget = context.WithTimeout(budgetCtx, f.paras.RoundBudget)
+		defer cancelBudget()
+	}
+	// set once the round stopped waiting for responses, the queries answering afterwards are ignored
+	budgetElapsed := false
+
+	// the retries of failed queries have to be started within the query timeout of the round
+	roundCtx, cancel := context.WithTimeout(budgetCtx, f.paras.QueryTimeout)
+	defer cancel()
 
 	// send queries
 	var wg sync.WaitGroup
@@ -247,15 +724,37 @@ func (f *FPC) queryOpinions() ([]opinion.QueriedOpinions, error) {
 		go func(opinionGiverToQuery opinion.OpinionGiver, selectedCount int) {
 			defer wg.Done()
 
-			queryCtx, cancel := context.WithTimeout(context.Background(), f.paras.QueryTimeout)
-			defer cancel()
-
 			// query
-			opinions, err := opinionGiverToQuery.Query(queryCtx, conflictIDs, timestampIDs)
-			if err != nil || len(opinions) != len(conflictIDs)+len(timestampIDs) {
+			opinions, err := f.queryOpinionGiverWithRetries(budgetCtx, roundCtx, opinionGiverToQuery, objectIDs)
+			if err != nil && budgetCtx.Err() != nil {
+				// the query was cut off by the round budget, which is no failure of the opinion giver
+				return
+			}
+			if f.giverHealth != nil {
+				f.giverHealth.record(opinionGiverToQuery.ID(), err != nil && classifyQueryError(err) == QueryFailureTimeout)
+			}
+			if err != nil {
 				// ignore opinions
+				voteMapMu.Lock()
+				defer voteMapMu.Unlock()
+				if budgetElapsed {
+					return
+				}
+				queryErrs = append(queryErrs, NewOpinionGiverError(opinionGiverToQuery.ID(), err))
 				return
 			}
+			if f.paras.ValidateResponse != nil {
+				if err := f.paras.ValidateResponse(opinionGiverToQuery, objectIDs, opinions); err != nil {
+					// ignore opinions
+					voteMapMu.Lock()
+					defer voteMapMu.Unlock()
+					if budgetElapsed {
+						return
+					}
+					queryErrs = append(queryErrs, NewOpinionGiverError(opinionGiverToQuery.ID(), fmt.Errorf("%w: %s", ErrInvalidResponse, err)))
+					return
+				}
+			}
 
 			queriedOpinions := opinion.QueriedOpinions{
 				OpinionGiverID: opinionGiverToQuery.ID().String(),
@@ -266,14 +765,16 @@ func (f *FPC) queryOpinions() ([]opinion.QueriedOpinions, error) {
 			// add opinions to vote map
 			voteMapMu.Lock()
 			defer voteMapMu.Unlock()
-			for i, id := range conflictIDs {
-				// reuse the opinion N times selected. Note this is always at least 1.
-				for j := 0; j < selectedCount; j++ {
-					voteMap[id] = append(voteMap[id], opinions[i])
-				}
-				queriedOpinions.Opinions[id] = opinions[i]
+			if budgetElapsed {
+				return
+			}
+			giverMana := opinionGiverToQuery.Mana()
+			respondedMana += giverMana
+			respondedCount++
+			if respondedGiverMana != nil {
+				respondedGiverMana[queriedOpinions.OpinionGiverID] = giverMana
 			}
-			for i, id := range timestampIDs {
+			for i, id := range ids {
 				// reuse the opinion N times selected. Note this is always at least 1.
 				for j := 0; j < selectedCount; j++ {
 					voteMap[id] = append(voteMap[id], opinions[i])
@@ -283,10 +784,37 @@ func (f *FPC) queryOpinions() ([]opinion.QueriedOpinions, error) {
 			allQueriedOpinions = append(allQueriedOpinions, queriedOpinions)
 		}(opinionGiverToQuery, selectedCount)
 	}
-	wg.Wait()
+	queriesDone := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(queriesDone)
+	}()
+	select {
+	case <-queriesDone:
+	case <-budgetCtx.Done():
+	}
+	voteMapMu.Lock()
+	budgetElapsed = true
+	voteMapMu.Unlock()
 
-	f.ctxsMu.RLock()
-	defer f.ctxsMu.RUnlock()
+	// count and surface the failed queries
+	for _, queryErr := range queryErrs {
+		var opinionGiverErr *OpinionGiverError
+		if errors.As(queryErr, &opinionGiverErr) {
+			f.queryFailureCounts[opinionGiverErr.Failure].Inc()
+		}
+		f.events.Error.Trigger(queryErr)
+	}
+
+	// do not form opinions on the responses of too few opinion givers
+	if responseFraction := float64(respondedCount) / float64(len(opinionGiversToQuery)); responseFraction < f.paras.MinResponseFraction {
+		return nil, nil, fmt.Errorf("%w: %d of %d sampled opinion givers responded", ErrInsufficientResponses, respondedCount, len(opinionGiversToQuery))
+	}
+
+	// the vote contexts and their quorum certificates are updated below
+	f.ctxsMu.Lock()
+	defer f.ctxsMu.Unlock()
+	agreementRate := make(map[string]float64, len(voteMap))
 	// compute liked proportion
 	for id, votes := range voteMap {
 		var likedSum float64
@@ -295,53 +823,365 @@ func (f *FPC) queryOpinions() ([]opinion.QueriedOpinions, error) {
 
 		for _, o := range votes {
 			switch o {
-			case opinion.Unknown:
+			case opinion.Unknown, opinion.Abstain:
 				votedCount--
 			case opinion.Like:
 				likedSum++
 			}
 		}
+		if votedCount > 0 {
+			agreementRate[id] = math.Max(likedSum, float64(votedCount)-likedSum) / float64(votedCount)
+		}
+		f.ctxs[id].OpinionsReceived = votedCount
 
-		if votedCount < f.paras.MinOpinionsReceived {
+		if votedCount < f.parasFor(id).MinOpinionsReceived {
 			continue
 		}
 		f.ctxs[id].Weights = vote.VotingWeights{
-			OwnWeight:    ownMana,
-			TotalWeights: totalMana,
+			OwnWeight:        ownMana,
+			TotalWeights:     totalMana,
+			RespondedWeights: respondedMana,
 		}
-		f.ctxs[id].ProportionLiked = likedSum / float64(votedCount)
+		if f.quorumCertificates != nil {
+			f.quorumCertificates[id] = newQuorumCertificate(id, allQueriedOpinions, respondedGiverMana)
+		}
+		proportionLiked := likedSum / float64(votedCount)
+		// dampen the noise of single rounds by blending in the previous proportion
+		if f.paras.ProportionEMAFactor > 0 && !f.ctxs[id].IsNew() {
+			proportionLiked = f.paras.ProportionEMAFactor*f.ctxs[id].ProportionLiked + (1-f.paras.ProportionEMAFactor)*proportionLiked
+		}
+		f.ctxs[id].ProportionLiked = proportionLiked
 	}
 
-	return allQueriedOpinions, nil
+	return allQueriedOpinions, agreementRate, nil
 }
 
-func (f *FPC) voteContextIDs() (conflictIDs []string, timestampIDs []string) {
-	f.ctxsMu.RLock()
-	defer f.ctxsMu.RUnlock()
-	for id, ctx := range f.ctxs {
-		switch ctx.Type {
+// creates the quorum certificate of the vote context with the given ID from the given queried opinions, only
+// including the opinion givers which liked or disliked it.
+func newQuorumCertificate(id string, queriedOpinions []opinion.QueriedOpinions, giverMana map[string]float64) *vote.QuorumCertificate {
+	certificate := &vote.QuorumCertificate{}
+	for _, queried := range queriedOpinions {
+		o := queried.Opinions[id]
+		if o != opinion.Like && o != opinion.Dislike {
+			continue
+		}
+		mana := giverMana[queried.OpinionGiverID]
+		certificate.Entries = append(certificate.Entries, vote.QuorumCertificateEntry{
+			OpinionGiverID: queried.OpinionGiverID,
+			Opinion:        o,
+			Mana:           mana,
+		})
+		certificate.TotalMana += mana
+	}
+	sort.Slice(certificate.Entries, func(i, j int) bool {
+		return certificate.Entries[i].OpinionGiverID < certificate.Entries[j].OpinionGiverID
+	})
+	return certificate
+}
+
+// returns the opinion givers. If OpinionGiverRefreshInterval is set, the retrieved list is reused until it is stale.
+// The returned list is a snapshot which is not modified by a later refresh.
+func (f *FPC) opinionGivers() ([]opinion.OpinionGiver, error) {
+	if f.paras.OpinionGiverRefreshInterval <= 0 {
+		return f.opinionGiverFunc()
+	}
+
+	f.cachedOpinionGiversMu.Lock()
+	defer f.cachedOpinionGiversMu.Unlock()
+
+	now := f.clock.Now()
+	if f.cachedOpinionGiversUpdate.IsZero() || now.Sub(f.cachedOpinionGiversUpdate) >= f.paras.OpinionGiverRefreshInterval {
+		opinionGivers, err := f.opinionGiverFunc()
+		if err != nil {
+			return nil, err
+		}
+		f.cachedOpinionGivers, f.cachedOpinionGiversUpdate = opinionGivers, now
+	}
+	return append([]opinion.OpinionGiver(nil), f.cachedOpinionGivers...), nil
+}
+
+// returns the own mana. If OwnManaCacheTTL is set, the retrieved mana is reused until the TTL expires.
+// If TolerateOwnManaError is set, the last retrieved mana is returned if the retrieval fails.
+func (f *FPC) ownMana() (float64, error) {
+	if f.paras.OwnManaCacheTTL <= 0 && !f.paras.TolerateOwnManaError {
+		return f.ownWeightRetrieverFunc()
+	}
+
+	now := f.clock.Now()
+	if f.paras.OwnManaCacheTTL > 0 && !f.cachedOwnManaUpdate.IsZero() && now.Sub(f.cachedOwnManaUpdate) < f.paras.OwnManaCacheTTL {
+		return f.cachedOwnMana, nil
+	}
+	ownMana, err := f.ownWeightRetrieverFunc()
+	if err != nil {
+		if f.paras.TolerateOwnManaError && !f.cachedOwnManaUpdate.IsZero() {
+			f.events.Error.Trigger(fmt.Errorf("%w, using the own mana retrieved at %s: %s", ErrOwnManaUnavailable, f.cachedOwnManaUpdate, err))
+			return f.cachedOwnMana, nil
+		}
+		return 0, err
+	}
+	f.cachedOwnMana, f.cachedOwnManaUpdate = ownMana, now
+	return ownMana, nil
+}
+
+// queries the opinions of the given opinion giver on the given IDs. A failed query is retried up to QueryRetries times
+// after waiting QueryRetryBackoff, unless the given round context is done before.
+func (f *FPC) queryOpinionGiverWithRetries(budgetCtx, roundCtx context.Context, opinionGiver opinion.OpinionGiver, objectIDs vote.ObjectIDs) (opinion.Opinions, error) {
+	opinions, err := f.queryOpinionGiver(budgetCtx, opinionGiver, objectIDs)
+	for retry := 0; err != nil && retry < f.paras.QueryRetries; retry++ {
+		timer := time.NewTimer(f.paras.QueryRetryBackoff)
+		select {
+		case <-timer.C:
+		case <-roundCtx.Done():
+			timer.Stop()
+			return nil, err
+		}
+		opinions, err = f.queryOpinionGiver(budgetCtx, opinionGiver, objectIDs)
+	}
+	return opinions, err
+}
+
+// queries the opinions of the given opinion giver on the given IDs. If there are more than MaxIDsPerQuery IDs, they are
+// split into shards which are queried one after another, each within QueryTimeout. The opinions of all shards are
+// merged in the order of the IDs, i.e. ordered by their object types. No shard is queried after the given budget
+// context is done.
+func (f *FPC) queryOpinionGiver(budgetCtx context.Context, opinionGiver opinion.OpinionGiver, objectIDs vote.ObjectIDs) (opinion.Opinions, error) {
+	opinions := make(opinion.Opinions, 0, objectIDs.Len())
+	for _, shard := range shardQueryIDs(objectIDs, f.paras.MaxIDsPerQuery) {
+		if err := budgetCtx.Err(); err != nil {
+			return nil, err
+		}
+		shardOpinions, err := func() (opinion.Opinions, error) {
+			queryCtx, cancel := context.WithTimeout(budgetCtx, f.paras.QueryTimeout)
+			defer cancel()
+			return queryObjects(queryCtx, opinionGiver, shard, f.paras.VerifyResponse)
+		}()
+		if err != nil {
+			return nil, err
+		}
+		if len(shardOpinions) != shard.Len() {
+			return nil, fmt.Errorf("%w: got %d, want %d", ErrInvalidOpinionCount, len(shardOpinions), shard.Len())
+		}
+		opinions = append(opinions, shardOpinions...)
+	}
+	return opinions, nil
+}
+
+// queries the opinions of the given opinion giver on the given IDs. Opinion givers which don't implement
+// vote.ObjectQuerier are queried for the conflicts and timestamps only, their opinion on other objects is Unknown.
+// If verify is set, the opinion giver is queried through opinion.SignedQuerier and its signed response is verified.
+func queryObjects(ctx context.Context, opinionGiver opinion.OpinionGiver, objectIDs vote.ObjectIDs, verify func(giverID string, response []byte, sig []byte) error) (opinion.Opinions, error) {
+	if verify != nil {
+		return querySignedObjects(ctx, opinionGiver, objectIDs, verify)
+	}
+	if querier, ok := opinionGiver.(vote.ObjectQuerier); ok {
+		return querier.QueryObjects(ctx, objectIDs)
+	}
+
+	conflictIDs, timestampIDs := objectIDs[vote.ConflictType], objectIDs[vote.TimestampType]
+	queried, err := opinionGiver.Query(ctx, conflictIDs, timestampIDs)
+	if err != nil {
+		return nil, err
+	}
+	return splitQueriedOpinions(queried, objectIDs)
+}
+
+// queries the opinions of the given opinion giver on the conflicts and timestamps of the given IDs and verifies the
+// signature of its response. Opinion givers which don't implement opinion.SignedQuerier can not be verified.
+func querySignedObjects(ctx context.Context, opinionGiver opinion.OpinionGiver, objectIDs vote.ObjectIDs, verify func(giverID string, response []byte, sig []byte) error) (opinion.Opinions, error) {
+	querier, ok := opinionGiver.(opinion.SignedQuerier)
+	if !ok {
+		return nil, fmt.Errorf("%w: opinion giver does not sign its responses", ErrUnverifiedResponse)
+	}
+
+	queried, response, sig, err := querier.QuerySigned(ctx, objectIDs[vote.ConflictType], objectIDs[vote.TimestampType])
+	if err != nil {
+		return nil, err
+	}
+	if err := verify(opinionGiver.ID().String(), response, sig); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnverifiedResponse, err)
+	}
+	return splitQueriedOpinions(queried, objectIDs)
+}
+
+// orders the given opinions, which were queried for the conflicts followed by the timestamps, by the object types of
+// the given IDs. The opinion on any other object is Unknown.
+func splitQueriedOpinions(queried opinion.Opinions, objectIDs vote.ObjectIDs) (opinion.Opinions, error) {
+	conflictIDs, timestampIDs := objectIDs[vote.ConflictType], objectIDs[vote.TimestampType]
+	if len(queried) != len(conflictIDs)+len(timestampIDs) {
+		return nil, fmt.Errorf("%w: got %d, want %d", ErrInvalidOpinionCount, len(queried), len(conflictIDs)+len(timestampIDs))
+	}
+
+	opinions := make(opinion.Opinions, 0, objectIDs.Len())
+	for _, objectType := range objectIDs.Types() {
+		switch objectType {
 		case vote.ConflictType:
-			conflictIDs = append(conflictIDs, id)
+			opinions = append(opinions, queried[:len(conflictIDs)]...)
 		case vote.TimestampType:
-			timestampIDs = append(timestampIDs, id)
+			opinions = append(opinions, queried[len(conflictIDs):]...)
+		default:
+			for range objectIDs[objectType] {
+				opinions = append(opinions, opinion.Unknown)
+			}
+		}
+	}
+	return opinions, nil
+}
+
+// splits the given IDs into shards of at most maxIDsPerQuery IDs. The IDs are assigned in the order of their object
+// types, so that the concatenation of the shards preserves the order of the IDs.
+// If maxIDsPerQuery is zero, all IDs are put into a single shard.
+func shardQueryIDs(objectIDs vote.ObjectIDs, maxIDsPerQuery int) []vote.ObjectIDs {
+	if maxIDsPerQuery <= 0 || objectIDs.Len() <= maxIDsPerQuery {
+		return []vote.ObjectIDs{objectIDs}
+	}
+
+	shards := make([]vote.ObjectIDs, 0, (objectIDs.Len()+maxIDsPerQuery-1)/maxIDsPerQuery)
+	shard, shardSize := vote.ObjectIDs{}, 0
+	for _, objectType := range objectIDs.Types() {
+		ids := objectIDs[objectType]
+		for len(ids) > 0 {
+			count := len(ids)
+			if count > maxIDsPerQuery-shardSize {
+				count = maxIDsPerQuery - shardSize
+			}
+			shard[objectType], ids = ids[:count], ids[count:]
+			if shardSize += count; shardSize == maxIDsPerQuery {
+				shards = append(shards, shard)
+				shard, shardSize = vote.ObjectIDs{}, 0
+			}
+		}
+	}
+	if shardSize > 0 {
+		shards = append(shards, shard)
+	}
+	return shards
+}
+
+// limits the queried opinions to MaxQueriesPerRound. The object types are prioritized in ascending order, i.e.
+// conflicts over timestamps, and, within each type, the vote contexts which were deferred in the last round are
+// prioritized. The vote contexts exceeding the budget are deferred to the next round. If the budget does not even
+// suffice for the sampled opinion givers, their amount is reduced as well.
+func (f *FPC) applyQueryBudget(opinionGiversToQuery map[opinion.OpinionGiver]int, objectIDs vote.ObjectIDs) vote.ObjectIDs {
+	lastDeferred := f.deferredVoteCtxs
+	f.deferredVoteCtxs = make(map[string]struct{})
+
+	if f.paras.MaxQueriesPerRound <= 0 || len(opinionGiversToQuery) == 0 {
+		return objectIDs
+	}
+
+	for opinionGiver := range opinionGiversToQuery {
+		if len(opinionGiversToQuery) <= f.paras.MaxQueriesPerRound {
+			break
+		}
+		delete(opinionGiversToQuery, opinionGiver)
+	}
+
+	maxVoteCtxs := f.paras.MaxQueriesPerRound / len(opinionGiversToQuery)
+	if objectIDs.Len() <= maxVoteCtxs {
+		return objectIDs
+	}
+
+	budgeted := vote.ObjectIDs{}
+	remaining := maxVoteCtxs
+	for _, objectType := range objectIDs.Types() {
+		ids := objectIDs[objectType]
+		sort.SliceStable(ids, func(i, j int) bool {
+			_, deferredI := lastDeferred[ids[i]]
+			_, deferredJ := lastDeferred[ids[j]]
+			return deferredI && !deferredJ
+		})
+
+		count := len(ids)
+		if count > remaining {
+			count = remaining
+		}
+		if count > 0 {
+			budgeted[objectType] = ids[:count]
+		}
+		for _, id := range ids[count:] {
+			f.deferredVoteCtxs[id] = struct{}{}
 		}
+		remaining -= count
 	}
-	return conflictIDs, timestampIDs
+	return budgeted
+}
+
+// returns the given opinion givers without the ones which failed to be probed.
+// Opinion givers which don't implement opinion.Prober are assumed reachable.
+func (f *FPC) probeOpinionGivers(opinionGivers []opinion.OpinionGiver) []opinion.OpinionGiver {
+	reachable := make([]bool, len(opinionGivers))
+
+	var wg sync.WaitGroup
+	for i, opinionGiver := range opinionGivers {
+		prober, ok := opinionGiver.(opinion.Prober)
+		if !ok {
+			reachable[i] = true
+			continue
+		}
+		wg.Add(1)
+		go func(i int, prober opinion.Prober) {
+			defer wg.Done()
+
+			probeCtx, cancel := context.WithTimeout(context.Background(), f.paras.QueryTimeout)
+			defer cancel()
+			reachable[i] = prober.Probe(probeCtx) == nil
+		}(i, prober)
+	}
+	wg.Wait()
+
+	result := make([]opinion.OpinionGiver, 0, len(opinionGivers))
+	for i, opinionGiver := range opinionGivers {
+		if reachable[i] {
+			result = append(result, opinionGiver)
+		}
+	}
+	return result
+}
+
+// selects the opinion givers to query in the current round according to the sampling parameters.
+func (f *FPC) sampleOpinionGivers(opinionGivers []opinion.OpinionGiver) (map[opinion.OpinionGiver]int, float64) {
+	if f.paras.SampleWithoutReplacement && len(opinionGivers) >= f.paras.QuerySampleSize {
+		return ManaBasedSamplingWithoutReplacement(opinionGivers, f.paras.QuerySampleSize, f.paras.TotalManaTolerance, f.opinionGiverRng)
+	}
+	var weightFactor func(opinion.OpinionGiver) float64
+	if f.giverHealth != nil || f.paras.ManaRecencyHalfLife > 0 {
+		now := f.clock.Now()
+		weightFactor = func(opinionGiver opinion.OpinionGiver) float64 {
+			factor := 1.0
+			if f.giverHealth != nil {
+				factor *= 1 - f.giverHealth.timeoutRate(opinionGiver.ID())
+			}
+			return factor * ManaRecencyFactor(opinionGiver, f.paras.ManaRecencyHalfLife, now)
+		}
+	}
+	return manaBasedSampling(opinionGivers, f.paras.MaxQuerySampleSize, f.paras.QuerySampleSize, f.paras.TotalManaTolerance, f.opinionGiverRng, weightFactor, f.paras.SamplingAuditLog)
+}
+
+// returns the IDs of the active vote contexts grouped by their object type.
+func (f *FPC) voteContextIDs() vote.ObjectIDs {
+	f.ctxsMu.RLock()
+	defer f.ctxsMu.RUnlock()
+	objectIDs := vote.ObjectIDs{}
+	for id, ctx := range f.ctxs {
+		objectIDs[ctx.Type] = append(objectIDs[ctx.Type], id)
+	}
+	return objectIDs
 }
 
 // get round boundaries based on the voting stage
 func (f *FPC) setThreshold(voteCtx *vote.Context) (float64, float64) {
-	lowerThreshold := f.paras.SubsequentRoundsLowerBoundThreshold
-	upperThreshold := f.paras.SubsequentRoundsUpperBoundThreshold
+	paras := f.parasFor(voteCtx.ID)
+	lowerThreshold := paras.SubsequentRoundsLowerBoundThreshold
+	upperThreshold := paras.SubsequentRoundsUpperBoundThreshold
 
 	if voteCtx.HadFirstRound() {
-		lowerThreshold = f.paras.FirstRoundLowerBoundThreshold
-		upperThreshold = f.paras.FirstRoundUpperBoundThreshold
+		lowerThreshold = paras.FirstRoundLowerBoundThreshold
+		upperThreshold = paras.FirstRoundUpperBoundThreshold
 	}
 
-	if voteCtx.HadFixedRound(f.paras.TotalRoundsCoolingOffPeriod, f.paras.TotalRoundsFinalization, f.paras.TotalRoundsFixedThreshold) {
-		lowerThreshold = f.paras.EndingRoundsFixedThreshold
-		upperThreshold = f.paras.EndingRoundsFixedThreshold
+	if voteCtx.HadFixedRound(paras.TotalRoundsCoolingOffPeriod, paras.TotalRoundsFinalization, paras.TotalRoundsFixedThreshold) {
+		lowerThreshold = paras.EndingRoundsFixedThreshold
+		upperThreshold = paras.EndingRoundsFixedThreshold
 	}
 
 	return lowerThreshold, upperThreshold
@@ -369,31 +1209,94 @@ func (f *FPC) SetOpinionGiverRng(rng *rand.Rand) {
 }
 
 // ManaBasedSampling returns list of OpinionGivers to query, weighted by consensus mana and corresponding total mana value.
-// If mana not available, fallback to uniform sampling
+// If mana not available, i.e. the total mana is not above totalManaTolerance, fallback to uniform sampling.
+// If no OpinionGivers are given, the list is empty.
 // weighted random sampling based on https://eli.thegreenplace.net/2010/01/22/weighted-random-generation-in-python/
-func ManaBasedSampling(opinionGivers []opinion.OpinionGiver, maxQuerySampleSize, querySampleSize int, rng *rand.Rand) (map[opinion.OpinionGiver]int, float64) {
+func ManaBasedSampling(opinionGivers []opinion.OpinionGiver, maxQuerySampleSize, querySampleSize int, totalManaTolerance float64, rng *rand.Rand) (map[opinion.OpinionGiver]int, float64) {
+	return manaBasedSampling(opinionGivers, maxQuerySampleSize, querySampleSize, totalManaTolerance, rng, nil, nil)
+}
+
+// ManaAndRecencyBasedSampling works like ManaBasedSampling, but additionally decays the weight of every opinion giver
+// implementing opinion.ManaUpdateTimer by the age of its mana at the given time, see ManaRecencyFactor.
+// The returned total mana is not affected by the decay.
+func ManaAndRecencyBasedSampling(opinionGivers []opinion.OpinionGiver, maxQuerySampleSize, querySampleSize int, totalManaTolerance float64, recencyHalfLife time.Duration, now time.Time, rng *rand.Rand) (map[opinion.OpinionGiver]int, float64) {
+	recencyFactor := func(opinionGiver opinion.OpinionGiver) float64 {
+		return ManaRecencyFactor(opinionGiver, recencyHalfLife, now)
+	}
+	return manaBasedSampling(opinionGivers, maxQuerySampleSize, querySampleSize, totalManaTolerance, rng, recencyFactor, nil)
+}
+
+// ManaRecencyFactor returns the factor in (0,1] by which the sampling weight of the given opinion giver is scaled
+// due to the age of its mana, which halves with every halfLife elapsed since the last mana update.
+// It returns 1, if halfLife is not positive, the opinion giver does not implement opinion.ManaUpdateTimer or the time
+// of its last mana update is unknown.
+func ManaRecencyFactor(opinionGiver opinion.OpinionGiver, halfLife time.Duration, now time.Time) float64 {
+	if halfLife <= 0 {
+		return 1
+	}
+	updateTimer, ok := opinionGiver.(opinion.ManaUpdateTimer)
+	if !ok || updateTimer.ManaUpdateTime().IsZero() {
+		return 1
+	}
+	age := now.Sub(updateTimer.ManaUpdateTime())
+	if age <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, float64(age)/float64(halfLife))
+}
+
+// manaBasedSampling implements ManaBasedSampling. If weightFactor is not nil, the mana of every opinion giver is
+// multiplied with its weight factor to get its sampling weight, while the returned total mana is not affected.
+// If auditLog is not nil, every sampling decision is logged with it.
+func manaBasedSampling(opinionGivers []opinion.OpinionGiver, maxQuerySampleSize, querySampleSize int, totalManaTolerance float64, rng *rand.Rand, weightFactor func(opinion.OpinionGiver) float64, auditLog func(format string, args ...interface{})) (map[opinion.OpinionGiver]int, float64) {
+	if len(opinionGivers) == 0 {
+		return map[opinion.OpinionGiver]int{}, 0
+	}
+
 	totalConsensusMana := 0.0
+	totalWeight := 0.0
 	totals := make([]float64, 0, len(opinionGivers))
 
 	for i := 0; i < len(opinionGivers); i++ {
-		totalConsensusMana += opinionGivers[i].Mana()
-		totals = append(totals, totalConsensusMana)
+		mana := opinionGivers[i].Mana()
+		totalConsensusMana += mana
+		weight := mana
+		if weightFactor != nil {
+			weight *= weightFactor(opinionGivers[i])
+		}
+		totalWeight += weight
+		totals = append(totals, totalWeight)
 	}
 
 	// check if total mana is almost zero
 
-	if math.Abs(totalConsensusMana) <= toleranceTotalMana {
+	if math.Abs(totalWeight) <= totalManaTolerance {
 		// fallback to uniform sampling
-		return UniformSampling(opinionGivers, maxQuerySampleSize, querySampleSize, rng), 0
+		if auditLog != nil {
+			auditLog("sampling: total mana %f within tolerance %f, sampling uniformly", totalWeight, totalManaTolerance)
+		}
+		if math.Abs(totalConsensusMana) <= totalManaTolerance {
+			totalConsensusMana = 0
+		}
+		return UniformSampling(opinionGivers, maxQuerySampleSize, querySampleSize, rng), totalConsensusMana
+	}
+
+	if auditLog != nil {
+		for idx, v := range totals {
+			auditLog("sampling: opinion giver %s owns cumulative mana boundary %f", opinionGivers[idx].ID(), v)
+		}
 	}
 
 	opinionGiversToQuery := map[opinion.OpinionGiver]int{}
 	for i := 0; i < maxQuerySampleSize && len(opinionGiversToQuery) < querySampleSize; i++ {
-		rnd := rng.Float64() * totalConsensusMana
+		rnd := rng.Float64() * totalWeight
 		for idx, v := range totals {
 			if rnd < v {
 				selected := opinionGivers[idx]
 				opinionGiversToQuery[selected]++
+				if auditLog != nil {
+					auditLog("sampling: draw %d: random mana %f selected opinion giver %s", i, rnd, selected.ID())
+				}
 				break
 			}
 		}
@@ -401,9 +1304,13 @@ func ManaBasedSampling(opinionGivers []opinion.OpinionGiver, maxQuerySampleSize,
 	return opinionGiversToQuery, totalConsensusMana
 }
 
-// UniformSampling returns list of OpinionGivers to query, sampled uniformly
+// UniformSampling returns list of OpinionGivers to query, sampled uniformly.
+// If no OpinionGivers are given, the list is empty.
 func UniformSampling(opinionGivers []opinion.OpinionGiver, maxQuerySampleSize, querySampleSize int, rng *rand.Rand) map[opinion.OpinionGiver]int {
 	opinionGiversToQuery := map[opinion.OpinionGiver]int{}
+	if len(opinionGivers) == 0 {
+		return opinionGiversToQuery
+	}
 	for i := 0; i < querySampleSize; i++ {
 		selected := opinionGivers[rng.Intn(len(opinionGivers))]
 		opinionGiversToQuery[selected]++
@@ -411,14 +1318,73 @@ func UniformSampling(opinionGivers []opinion.OpinionGiver, maxQuerySampleSize, q
 	return opinionGiversToQuery
 }
 
-// create a voteMap for the stored conflicts and timestamps
-func createVoteMapForConflicts(conflictIDs, timestampIDs []string) map[string]opinion.Opinions {
-	voteMap := map[string]opinion.Opinions{}
+// ManaBasedSamplingWithoutReplacement returns list of OpinionGivers to query, weighted by consensus mana and corresponding total mana value.
+// Every OpinionGiver is selected at most once: once selected, it is removed from the pool of candidates.
+// If mana not available, i.e. the total mana is not above totalManaTolerance, fallback to uniform sampling without replacement.
+func ManaBasedSamplingWithoutReplacement(opinionGivers []opinion.OpinionGiver, querySampleSize int, totalManaTolerance float64, rng *rand.Rand) (map[opinion.OpinionGiver]int, float64) {
+	// snapshot the mana of the opinion givers, as it is needed again after each selection
+	candidates := make([]opinion.OpinionGiver, len(opinionGivers))
+	candidatesMana := make([]float64, len(opinionGivers))
+	totalConsensusMana := 0.0
+	for i, opinionGiver := range opinionGivers {
+		candidates[i] = opinionGiver
+		candidatesMana[i] = opinionGiver.Mana()
+		totalConsensusMana += candidatesMana[i]
+	}
 
-	for _, id := range conflictIDs {
-		voteMap[id] = opinion.Opinions{}
+	// check if total mana is almost zero
+	if math.Abs(totalConsensusMana) <= totalManaTolerance {
+		// fallback to uniform sampling
+		return UniformSamplingWithoutReplacement(opinionGivers, querySampleSize, rng), 0
 	}
-	for _, id := range timestampIDs {
+
+	remainingMana := totalConsensusMana
+
+	opinionGiversToQuery := map[opinion.OpinionGiver]int{}
+	for len(opinionGiversToQuery) < querySampleSize && len(candidates) > 0 {
+		var selectedIdx int
+		if remainingMana <= totalManaTolerance {
+			// the remaining candidates don't hold any mana, pick one of them uniformly
+			selectedIdx = rng.Intn(len(candidates))
+		} else {
+			// default to the last candidate in case of floating point inaccuracies
+			selectedIdx = len(candidates) - 1
+			rnd := rng.Float64() * remainingMana
+			cumulativeMana := 0.0
+			for idx, candidateMana := range candidatesMana {
+				cumulativeMana += candidateMana
+				if rnd < cumulativeMana {
+					selectedIdx = idx
+					break
+				}
+			}
+		}
+
+		opinionGiversToQuery[candidates[selectedIdx]] = 1
+		remainingMana -= candidatesMana[selectedIdx]
+		candidates = append(candidates[:selectedIdx], candidates[selectedIdx+1:]...)
+		candidatesMana = append(candidatesMana[:selectedIdx], candidatesMana[selectedIdx+1:]...)
+	}
+	return opinionGiversToQuery, totalConsensusMana
+}
+
+// UniformSamplingWithoutReplacement returns list of OpinionGivers to query, sampled uniformly without replacement.
+func UniformSamplingWithoutReplacement(opinionGivers []opinion.OpinionGiver, querySampleSize int, rng *rand.Rand) map[opinion.OpinionGiver]int {
+	opinionGiversToQuery := map[opinion.OpinionGiver]int{}
+	for _, idx := range rng.Perm(len(opinionGivers)) {
+		if len(opinionGiversToQuery) >= querySampleSize {
+			break
+		}
+		opinionGiversToQuery[opinionGivers[idx]] = 1
+	}
+	return opinionGiversToQuery
+}
+
+// create a voteMap for the given IDs
+func createVoteMap(ids []string) map[string]opinion.Opinions {
+	voteMap := map[string]opinion.Opinions{}
+
+	for _, id := range ids {
 		voteMap[id] = opinion.Opinions{}
 	}
 
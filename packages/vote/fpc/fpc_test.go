@@ -2,9 +2,16 @@ package fpc_test
 
 import (
 	"context"
+	"crypto/ed25519"
 	"errors"
+	"fmt"
+	"io"
 	"math/rand"
+	"net"
+	"os"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
@@ -30,6 +37,9 @@ func TestVoteContext_IsFinalized(t *testing.T) {
 		{vote.Context{
 			Opinions: []opinion.Opinion{opinion.Like, opinion.Like, opinion.Like, opinion.Like, opinion.Dislike},
 		}, 2, 2, false},
+		{vote.Context{
+			Opinions: []opinion.Opinion{opinion.Like, opinion.Like, opinion.Like, opinion.Abstain, opinion.Abstain},
+		}, 2, 2, false},
 	}
 
 	for _, test := range tests {
@@ -257,7 +267,7 @@ func TestManaBasedSamplingFallback(t *testing.T) {
 	}
 
 	// provide custom rng with fixed seed, so that execution is deterministic
-	opinionGiversToQuery, _ := fpc.ManaBasedSampling(opinionGivers, fpc.DefaultParameters().MaxQuerySampleSize, fpc.DefaultParameters().QuerySampleSize, rand.New(rand.NewSource(42)))
+	opinionGiversToQuery, _ := fpc.ManaBasedSampling(opinionGivers, fpc.DefaultParameters().MaxQuerySampleSize, fpc.DefaultParameters().QuerySampleSize, fpc.DefaultParameters().TotalManaTolerance, rand.New(rand.NewSource(42)))
 	sumVotes := 0
 	for _, v := range opinionGiversToQuery {
 		sumVotes += v
@@ -266,6 +276,40 @@ func TestManaBasedSamplingFallback(t *testing.T) {
 	assert.Equal(t, expectedOpinionGivers, opinionGiversToQuery)
 }
 
+func TestSamplingWithoutOpinionGivers(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	paras := fpc.DefaultParameters()
+
+	assert.NotPanics(t, func() {
+		assert.Empty(t, fpc.UniformSampling(nil, paras.MaxQuerySampleSize, paras.QuerySampleSize, rng))
+	})
+	assert.NotPanics(t, func() {
+		opinionGiversToQuery, totalMana := fpc.ManaBasedSampling([]opinion.OpinionGiver{}, paras.MaxQuerySampleSize, paras.QuerySampleSize, paras.TotalManaTolerance, rng)
+		assert.Empty(t, opinionGiversToQuery)
+		assert.Zero(t, totalMana)
+	})
+}
+
+func TestManaBasedSamplingTotalManaTolerance(t *testing.T) {
+	// opinion givers with a tiny total mana of 0.0001
+	manaGiver := &opiniongivermock{mana: 0.0001, id: identity.GenerateIdentity().ID()}
+	opinionGivers := []opinion.OpinionGiver{manaGiver}
+	for i := 0; i < 9; i++ {
+		opinionGivers = append(opinionGivers, &opiniongivermock{id: identity.GenerateIdentity().ID()})
+	}
+	paras := fpc.DefaultParameters()
+
+	// the total mana does not exceed the tolerance, thus the opinion givers are sampled uniformly
+	opinionGiversToQuery, totalMana := fpc.ManaBasedSampling(opinionGivers, paras.MaxQuerySampleSize, paras.QuerySampleSize, 0.0001, rand.New(rand.NewSource(42)))
+	assert.Zero(t, totalMana)
+	assert.Greater(t, len(opinionGiversToQuery), 1)
+
+	// the total mana exceeds the tolerance, thus only the opinion giver with mana is sampled
+	opinionGiversToQuery, totalMana = fpc.ManaBasedSampling(opinionGivers, paras.MaxQuerySampleSize, paras.QuerySampleSize, 0.00005, rand.New(rand.NewSource(42)))
+	assert.Equal(t, 0.0001, totalMana)
+	assert.Equal(t, map[opinion.OpinionGiver]int{manaGiver: paras.MaxQuerySampleSize}, opinionGiversToQuery)
+}
+
 func TestManaBasedSampling(t *testing.T) {
 	// opinion givers with exponentially distributed mana
 	opinionGivers := make([]opinion.OpinionGiver, fpc.DefaultParameters().QuerySampleSize)
@@ -292,7 +336,7 @@ func TestManaBasedSampling(t *testing.T) {
 	}
 
 	// provide custom rng with fixed seed, so that execution is deterministic
-	opinionGiversToQuery, _ := fpc.ManaBasedSampling(opinionGivers, fpc.DefaultParameters().MaxQuerySampleSize, fpc.DefaultParameters().QuerySampleSize, rand.New(rand.NewSource(42)))
+	opinionGiversToQuery, _ := fpc.ManaBasedSampling(opinionGivers, fpc.DefaultParameters().MaxQuerySampleSize, fpc.DefaultParameters().QuerySampleSize, fpc.DefaultParameters().TotalManaTolerance, rand.New(rand.NewSource(42)))
 	sumVotes := 0
 	for _, v := range opinionGiversToQuery {
 		sumVotes += v
@@ -357,3 +401,2513 @@ func TestFPCVotingMultipleOpinionGiversWithMana(t *testing.T) {
 		assert.Equal(t, test.expectedOpinion, *finalOpinion)
 	}
 }
+
+func TestManaBasedSamplingWithoutReplacement(t *testing.T) {
+	// opinion givers with exponentially distributed mana
+	opinionGivers := make([]opinion.OpinionGiver, fpc.DefaultParameters().QuerySampleSize*2)
+	for i := 0; i < len(opinionGivers); i++ {
+		opinionGivers[i] = &opiniongivermock{mana: float64(i * i), id: identity.GenerateIdentity().ID()}
+	}
+
+	querySampleSize := fpc.DefaultParameters().QuerySampleSize
+	opinionGiversToQuery, totalMana := fpc.ManaBasedSamplingWithoutReplacement(opinionGivers, querySampleSize, fpc.DefaultParameters().TotalManaTolerance, rand.New(rand.NewSource(42)))
+	assert.Len(t, opinionGiversToQuery, querySampleSize)
+	for _, selectedCount := range opinionGiversToQuery {
+		assert.Equal(t, 1, selectedCount, "no opinion giver should be selected twice")
+	}
+	var expectedTotalMana float64
+	for _, opinionGiver := range opinionGivers {
+		expectedTotalMana += opinionGiver.Mana()
+	}
+	assert.Equal(t, expectedTotalMana, totalMana)
+
+	// opinion givers without mana fall back to uniform sampling without replacement
+	for i := 0; i < len(opinionGivers); i++ {
+		opinionGivers[i] = &opiniongivermock{id: identity.GenerateIdentity().ID()}
+	}
+	opinionGiversToQuery, totalMana = fpc.ManaBasedSamplingWithoutReplacement(opinionGivers, querySampleSize, fpc.DefaultParameters().TotalManaTolerance, rand.New(rand.NewSource(42)))
+	assert.Len(t, opinionGiversToQuery, querySampleSize)
+	for _, selectedCount := range opinionGiversToQuery {
+		assert.Equal(t, 1, selectedCount, "no opinion giver should be selected twice")
+	}
+	assert.Zero(t, totalMana)
+}
+
+// manaCountingOpinionGiverMock counts the calls of Mana.
+type manaCountingOpinionGiverMock struct {
+	*opiniongivermock
+	manaCalls int
+}
+
+func (ogm *manaCountingOpinionGiverMock) Mana() float64 {
+	ogm.manaCalls++
+	return ogm.opiniongivermock.Mana()
+}
+
+func TestManaBasedSamplingManaCalls(t *testing.T) {
+	paras := fpc.DefaultParameters()
+	mocks := make([]*manaCountingOpinionGiverMock, paras.QuerySampleSize*2)
+	opinionGivers := make([]opinion.OpinionGiver, len(mocks))
+	for i := range mocks {
+		mocks[i] = &manaCountingOpinionGiverMock{opiniongivermock: &opiniongivermock{mana: float64(i + 1), id: identity.GenerateIdentity().ID()}}
+		opinionGivers[i] = mocks[i]
+	}
+	assertManaCalledOnce := func() {
+		for _, giver := range mocks {
+			assert.LessOrEqual(t, giver.manaCalls, 1)
+			giver.manaCalls = 0
+		}
+	}
+
+	fpc.ManaBasedSampling(opinionGivers, paras.MaxQuerySampleSize, paras.QuerySampleSize, paras.TotalManaTolerance, rand.New(rand.NewSource(42)))
+	assertManaCalledOnce()
+	fpc.ManaBasedSamplingWithoutReplacement(opinionGivers, paras.QuerySampleSize, paras.TotalManaTolerance, rand.New(rand.NewSource(42)))
+	assertManaCalledOnce()
+}
+
+// recencyOpinionGiverMock reports the time of its last mana update.
+type recencyOpinionGiverMock struct {
+	*opiniongivermock
+	manaUpdateTime time.Time
+}
+
+func (ogm *recencyOpinionGiverMock) ManaUpdateTime() time.Time {
+	return ogm.manaUpdateTime
+}
+
+func TestManaAndRecencyBasedSampling(t *testing.T) {
+	const halfLife = time.Minute
+	now := time.Now()
+
+	// opinion givers with equal mana, but the mana of the stale one was last updated ten half-lives ago
+	fresh := &recencyOpinionGiverMock{opiniongivermock: &opiniongivermock{mana: 100}, manaUpdateTime: now}
+	stale := &recencyOpinionGiverMock{opiniongivermock: &opiniongivermock{mana: 100}, manaUpdateTime: now.Add(-10 * halfLife)}
+	opinionGivers := []opinion.OpinionGiver{fresh, stale}
+
+	assert.Equal(t, 1.0, fpc.ManaRecencyFactor(fresh, halfLife, now))
+	assert.InDelta(t, 1.0/1024, fpc.ManaRecencyFactor(stale, halfLife, now), 1e-9)
+	assert.Equal(t, 1.0, fpc.ManaRecencyFactor(fresh.opiniongivermock, halfLife, now))
+	assert.Equal(t, 1.0, fpc.ManaRecencyFactor(&recencyOpinionGiverMock{opiniongivermock: &opiniongivermock{mana: 100}}, halfLife, now))
+
+	// the sample size can't be reached with two opinion givers, thus all draws are performed
+	const sampleSize = 1000
+
+	// without the recency weighting both are sampled about equally often
+	opinionGiversToQuery, totalMana := fpc.ManaBasedSampling(opinionGivers, sampleSize, 3, 0, rand.New(rand.NewSource(42)))
+	assert.Equal(t, 200.0, totalMana)
+	assert.Greater(t, opinionGiversToQuery[stale], sampleSize/4)
+
+	// with the recency weighting the stale opinion giver is hardly sampled, the total mana is unaffected
+	opinionGiversToQuery, totalMana = fpc.ManaAndRecencyBasedSampling(opinionGivers, sampleSize, 3, 0, halfLife, now, rand.New(rand.NewSource(42)))
+	assert.Equal(t, 200.0, totalMana)
+	assert.Less(t, opinionGiversToQuery[stale], sampleSize/100)
+	assert.Greater(t, opinionGiversToQuery[fresh], sampleSize*99/100)
+
+	// a zero half-life disables the weighting
+	opinionGiversToQuery, _ = fpc.ManaAndRecencyBasedSampling(opinionGivers, sampleSize, 3, 0, 0, now, rand.New(rand.NewSource(42)))
+	assert.Greater(t, opinionGiversToQuery[stale], sampleSize/4)
+}
+
+func TestFPCSampleWithoutReplacement(t *testing.T) {
+	opinionGivers := make([]*opiniongivermock, fpc.DefaultParameters().QuerySampleSize)
+	for i := 0; i < len(opinionGivers); i++ {
+		opinionGivers[i] = &opiniongivermock{mana: float64(i * i), id: identity.GenerateIdentity().ID(), roundsReplies: []opinion.Opinions{{opinion.Like}}}
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		givers = make([]opinion.OpinionGiver, len(opinionGivers))
+		for i := range opinionGivers {
+			givers[i] = opinionGivers[i]
+		}
+		return givers, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.SampleWithoutReplacement = true
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+	voter.SetOpinionGiverRng(rand.New(rand.NewSource(42)))
+
+	var queriedOpinions []opinion.QueriedOpinions
+	voter.Events().RoundExecuted.Attach(events.NewClosure(func(roundStats *vote.RoundStats) {
+		queriedOpinions = roundStats.QueriedOpinions
+	}))
+
+	assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+	assert.NoError(t, voter.Round(0.5))
+
+	// as many distinct opinion givers as the sample size exist, so every one of them is queried exactly once
+	require.Len(t, queriedOpinions, paras.QuerySampleSize)
+	for _, queriedOpinion := range queriedOpinions {
+		assert.Equal(t, 1, queriedOpinion.TimesCounted)
+	}
+}
+
+func TestFPCValidateResponse(t *testing.T) {
+	// an opinion which is neither Like, Dislike nor Unknown
+	malformedOpinion := opinion.Opinion(1 << 3)
+	validOpinionGiver := &opiniongivermock{id: identity.GenerateIdentity().ID(), roundsReplies: []opinion.Opinions{{opinion.Like}}}
+	malformedOpinionGiver := &opiniongivermock{id: identity.GenerateIdentity().ID(), roundsReplies: []opinion.Opinions{{malformedOpinion}}}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{validOpinionGiver, malformedOpinionGiver}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.ValidateResponse = func(_ opinion.OpinionGiver, _ vote.ObjectIDs, opinions []opinion.Opinion) error {
+		for _, o := range opinions {
+			if o != opinion.Like && o != opinion.Dislike && o != opinion.Unknown {
+				return errors.New("malformed opinion")
+			}
+		}
+		return nil
+	}
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+	var queriedOpinions []opinion.QueriedOpinions
+	voter.Events().RoundExecuted.Attach(events.NewClosure(func(roundStats *vote.RoundStats) {
+		queriedOpinions = roundStats.QueriedOpinions
+	}))
+	var queryErrs []error
+	voter.Events().Error.Attach(events.NewClosure(func(err error) {
+		queryErrs = append(queryErrs, err)
+	}))
+
+	assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+	assert.NoError(t, voter.Round(0.5))
+
+	// only the opinions of the valid opinion giver are counted
+	require.Len(t, queriedOpinions, 1)
+	assert.Equal(t, validOpinionGiver.ID().String(), queriedOpinions[0].OpinionGiverID)
+	assert.Equal(t, opinion.Like, queriedOpinions[0].Opinions["a"])
+
+	// the invalid response is counted and reported like a failed query
+	assert.EqualValues(t, 1, voter.QueryFailures(fpc.QueryFailureInvalid))
+	require.Len(t, queryErrs, 1)
+	var opinionGiverErr *fpc.OpinionGiverError
+	require.True(t, errors.As(queryErrs[0], &opinionGiverErr))
+	assert.Equal(t, malformedOpinionGiver.ID(), opinionGiverErr.OpinionGiverID)
+	assert.Equal(t, fpc.QueryFailureInvalid, opinionGiverErr.Failure)
+	assert.ErrorIs(t, queryErrs[0], fpc.ErrInvalidResponse)
+}
+
+func TestFPCVerifyResponse(t *testing.T) {
+	goodPublicKey, goodPrivateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	_, badPrivateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	goodOpinionGiver := &signingOpinionGiverMock{id: identity.GenerateIdentity().ID(), opinion: opinion.Like, privateKey: goodPrivateKey}
+	// signs with a key which does not belong to the opinion giver
+	badOpinionGiver := &signingOpinionGiverMock{id: identity.GenerateIdentity().ID(), opinion: opinion.Dislike, privateKey: badPrivateKey}
+	publicKeys := map[string]ed25519.PublicKey{
+		goodOpinionGiver.ID().String(): goodPublicKey,
+		badOpinionGiver.ID().String():  goodPublicKey,
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{goodOpinionGiver, badOpinionGiver}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.QuerySampleSize = 2
+	paras.SampleWithoutReplacement = true
+	paras.VerifyResponse = func(giverID string, response []byte, sig []byte) error {
+		if !ed25519.Verify(publicKeys[giverID], response, sig) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+	var queriedOpinions []opinion.QueriedOpinions
+	voter.Events().RoundExecuted.Attach(events.NewClosure(func(roundStats *vote.RoundStats) {
+		queriedOpinions = roundStats.QueriedOpinions
+	}))
+	var errs []error
+	voter.Events().Error.Attach(events.NewClosure(func(err error) {
+		errs = append(errs, err)
+	}))
+
+	assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+	assert.NoError(t, voter.Round(0.5))
+
+	// only the opinions of the opinion giver with a valid signature are counted
+	require.Len(t, queriedOpinions, 1)
+	assert.Equal(t, goodOpinionGiver.ID().String(), queriedOpinions[0].OpinionGiverID)
+	assert.Equal(t, opinion.Like, queriedOpinions[0].Opinions["a"])
+
+	require.Len(t, errs, 1)
+	assert.True(t, errors.Is(errs[0], fpc.ErrUnverifiedResponse))
+	var opinionGiverErr *fpc.OpinionGiverError
+	require.True(t, errors.As(errs[0], &opinionGiverErr))
+	assert.Equal(t, badOpinionGiver.ID(), opinionGiverErr.OpinionGiverID)
+	assert.EqualValues(t, 1, voter.QueryFailures(fpc.QueryFailureUnverified))
+}
+
+// signingOpinionGiverMock replies with the same opinion for each of the queried IDs and signs its response.
+type signingOpinionGiverMock struct {
+	id         identity.ID
+	opinion    opinion.Opinion
+	privateKey ed25519.PrivateKey
+}
+
+func (ogm *signingOpinionGiverMock) ID() identity.ID {
+	return ogm.id
+}
+
+func (ogm *signingOpinionGiverMock) Query(ctx context.Context, conflictIDs []string, timestampIDs []string) (opinion.Opinions, error) {
+	opinions, _, _, err := ogm.QuerySigned(ctx, conflictIDs, timestampIDs)
+	return opinions, err
+}
+
+func (ogm *signingOpinionGiverMock) QuerySigned(_ context.Context, conflictIDs []string, timestampIDs []string) (opinion.Opinions, []byte, []byte, error) {
+	opinions := make(opinion.Opinions, len(conflictIDs)+len(timestampIDs))
+	response := make([]byte, len(opinions))
+	for i := range opinions {
+		opinions[i] = ogm.opinion
+		response[i] = byte(ogm.opinion)
+	}
+	return opinions, response, ed25519.Sign(ogm.privateKey, response), nil
+}
+
+func (ogm *signingOpinionGiverMock) Mana() float64 {
+	return 0
+}
+
+// opinionsByIDGiverMock replies with the opinion given by opinionFunc for each of the queried IDs.
+type opinionsByIDGiverMock struct {
+	id          identity.ID
+	mana        float64
+	queries     int
+	opinionFunc func(id string, query int) opinion.Opinion
+	// optional, fails the given query if it returns an error.
+	queryErrFunc func(query int) error
+}
+
+func (ogm *opinionsByIDGiverMock) ID() identity.ID {
+	return ogm.id
+}
+
+func (ogm *opinionsByIDGiverMock) Query(_ context.Context, conflictIDs []string, timestampIDs []string) (opinion.Opinions, error) {
+	if ogm.queryErrFunc != nil {
+		if err := ogm.queryErrFunc(ogm.queries); err != nil {
+			ogm.queries++
+			return nil, err
+		}
+	}
+	opinions := make(opinion.Opinions, 0, len(conflictIDs)+len(timestampIDs))
+	for _, id := range append(append([]string{}, conflictIDs...), timestampIDs...) {
+		opinions = append(opinions, ogm.opinionFunc(id, ogm.queries))
+	}
+	ogm.queries++
+	return opinions, nil
+}
+
+func (ogm *opinionsByIDGiverMock) Mana() float64 {
+	return ogm.mana
+}
+
+func TestFPCCounters(t *testing.T) {
+	opinionGiverMock := &opinionsByIDGiverMock{
+		id: identity.GenerateIdentity().ID(),
+		opinionFunc: func(id string, query int) opinion.Opinion {
+			switch id {
+			case "like":
+				return opinion.Like
+			case "dislike":
+				return opinion.Dislike
+			}
+			// flip the opinion on every query so that it never gets finalized
+			if query%2 == 0 {
+				return opinion.Like
+			}
+			return opinion.Dislike
+		},
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{opinionGiverMock}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.TotalRoundsFinalization = 2
+	paras.TotalRoundsCoolingOffPeriod = 2
+	paras.QuerySampleSize = 1
+	paras.MaxRoundsPerVoteContext = 10
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+	assert.NoError(t, voter.Vote("like", vote.ConflictType, opinion.Like))
+	assert.NoError(t, voter.Vote("dislike", vote.ConflictType, opinion.Dislike))
+	assert.NoError(t, voter.Vote("flipping", vote.ConflictType, opinion.Like))
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, voter.Round(0.5))
+	}
+	likeFinal, dislikeFinal, failed := voter.Counters()
+	assert.EqualValues(t, 1, likeFinal)
+	assert.EqualValues(t, 1, dislikeFinal)
+	assert.EqualValues(t, 0, failed)
+
+	for i := 0; i < 6; i++ {
+		assert.NoError(t, voter.Round(0.5))
+	}
+	likeFinal, dislikeFinal, failed = voter.Counters()
+	assert.EqualValues(t, 1, likeFinal)
+	assert.EqualValues(t, 1, dislikeFinal)
+	assert.EqualValues(t, 1, failed)
+}
+
+func TestFPCAvgRoundsToFinalize(t *testing.T) {
+	// the opinion of each vote context flips on every query until the given query,
+	// from which on the final opinion is returned.
+	flipUntil := map[string]int{"like": 0, "late-like": 4, "dislike": 0, "late-dislike": 3}
+	finalOpinion := map[string]opinion.Opinion{"like": opinion.Like, "late-like": opinion.Like, "dislike": opinion.Dislike, "late-dislike": opinion.Dislike}
+	opinionGiverMock := &opinionsByIDGiverMock{
+		id: identity.GenerateIdentity().ID(),
+		opinionFunc: func(id string, query int) opinion.Opinion {
+			if query >= flipUntil[id] {
+				return finalOpinion[id]
+			}
+			if query%2 == 0 {
+				return opinion.Like
+			}
+			return opinion.Dislike
+		},
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{opinionGiverMock}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.TotalRoundsFinalization = 2
+	paras.TotalRoundsCoolingOffPeriod = 2
+	paras.QuerySampleSize = 1
+	paras.MaxRoundsPerVoteContext = 10
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+	like, dislike := voter.AvgRoundsToFinalize()
+	assert.Zero(t, like)
+	assert.Zero(t, dislike)
+
+	for id, opn := range finalOpinion {
+		assert.NoError(t, voter.Vote(id, vote.ConflictType, opn))
+	}
+	for i := 0; i < 7; i++ {
+		assert.NoError(t, voter.Round(0.5))
+	}
+
+	// "like" and "dislike" finalize after 4 rounds, "late-dislike" after 5 and "late-like" after 6.
+	likeFinal, dislikeFinal, _ := voter.Counters()
+	require.EqualValues(t, 2, likeFinal)
+	require.EqualValues(t, 2, dislikeFinal)
+	like, dislike = voter.AvgRoundsToFinalize()
+	assert.InDelta(t, 5.0, like, 1e-9)
+	assert.InDelta(t, 4.5, dislike, 1e-9)
+}
+
+func TestFPCReVote(t *testing.T) {
+	opinionGiverMock := &opinionsByIDGiverMock{
+		id: identity.GenerateIdentity().ID(),
+		opinionFunc: func(id string, query int) opinion.Opinion {
+			if id == "like" {
+				return opinion.Like
+			}
+			// flip the opinion on every query so that it never gets finalized
+			if query%2 == 0 {
+				return opinion.Like
+			}
+			return opinion.Dislike
+		},
+		// the opinion giver is unreachable during the first vote
+		queryErrFunc: func(query int) error {
+			if query < 3 {
+				return errors.New("unreachable")
+			}
+			return nil
+		},
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{opinionGiverMock}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.TotalRoundsFinalization = 2
+	paras.TotalRoundsCoolingOffPeriod = 0
+	paras.QuerySampleSize = 1
+	paras.MaxRoundsPerVoteContext = 3
+	paras.MaxReVotes = 1
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+	finalized := make(map[string]*vote.OpinionEvent)
+	voter.Events().Finalized.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+		finalized[ev.ID] = ev
+	}))
+	failed := make(map[string]*vote.OpinionEvent)
+	voter.Events().Failed.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+		failed[ev.ID] = ev
+	}))
+
+	assert.NoError(t, voter.Vote("like", vote.ConflictType, opinion.Like))
+	assert.NoError(t, voter.Vote("flipping", vote.ConflictType, opinion.Like))
+
+	// the contexts don't receive any opinions before reaching the max rounds and are voted on again
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, voter.Round(0.5))
+	}
+	assert.Empty(t, finalized)
+	assert.Empty(t, failed)
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, voter.Round(0.5))
+	}
+
+	require.Contains(t, finalized, "like")
+	assert.Equal(t, opinion.Like, finalized["like"].Opinion)
+	assert.Equal(t, 1, finalized["like"].Ctx.ReVotes)
+
+	// the flipping context received opinions, thus its failure is genuine and it is not voted on again
+	require.Contains(t, failed, "flipping")
+	assert.Equal(t, 1, failed["flipping"].Ctx.ReVotes)
+	_, err := voter.IntermediateOpinion("flipping")
+	assert.True(t, errors.Is(err, vote.ErrVotingNotFound))
+}
+
+func TestFPCMinManaShareForFinalization(t *testing.T) {
+	likeOpinion := func(string, int) opinion.Opinion {
+		return opinion.Like
+	}
+	// the opinion giver holding most of the mana is unreachable during the first rounds
+	highManaGiver := &opinionsByIDGiverMock{
+		id:          identity.GenerateIdentity().ID(),
+		mana:        90,
+		opinionFunc: likeOpinion,
+		queryErrFunc: func(query int) error {
+			if query < 6 {
+				return errors.New("unreachable")
+			}
+			return nil
+		},
+	}
+	lowManaGiver := &opinionsByIDGiverMock{
+		id:          identity.GenerateIdentity().ID(),
+		mana:        10,
+		opinionFunc: likeOpinion,
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{highManaGiver, lowManaGiver}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.TotalRoundsFinalization = 2
+	paras.TotalRoundsCoolingOffPeriod = 0
+	paras.QuerySampleSize = 2
+	paras.SampleWithoutReplacement = true
+	paras.MinManaShareForFinalization = 0.5
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+	var finalizedEvent *vote.OpinionEvent
+	voter.Events().Finalized.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+		finalizedEvent = ev
+	}))
+
+	assert.NoError(t, voter.Vote("like", vote.ConflictType, opinion.Like))
+
+	// only 10% of the mana responds, thus the context keeps voting
+	for i := 0; i < 6; i++ {
+		assert.NoError(t, voter.Round(0.5))
+	}
+	assert.Nil(t, finalizedEvent)
+
+	// the high mana opinion giver responds and the context finalizes
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, voter.Round(0.5))
+	}
+	require.NotNil(t, finalizedEvent)
+	assert.Equal(t, opinion.Like, finalizedEvent.Opinion)
+	assert.Equal(t, 100.0, finalizedEvent.Ctx.Weights.RespondedWeights)
+}
+
+func TestFPCWarmup(t *testing.T) {
+	opinionGivers := make([]opinion.OpinionGiver, 21)
+	for i := range opinionGivers {
+		opinionGivers[i] = &opinionsByIDGiverMock{
+			id: identity.GenerateIdentity().ID(),
+			opinionFunc: func(string, int) opinion.Opinion {
+				return opinion.Like
+			},
+		}
+	}
+	numOpinionGivers := 2
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return opinionGivers[:numOpinionGivers], nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.WarmupMinGivers = paras.QuerySampleSize
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+	var voteCtx *vote.Context
+	voter.Events().RoundExecuted.Attach(events.NewClosure(func(roundStats *vote.RoundStats) {
+		voteCtx = roundStats.ActiveVoteContexts["test"]
+	}))
+
+	assert.NoError(t, voter.Vote("test", vote.ConflictType, opinion.Like))
+
+	// the amount of seen opinion givers grows below the threshold, no opinions are formed
+	for _, n := range []int{2, 2, 10, 10, 20, 21} {
+		numOpinionGivers = n
+		assert.NoError(t, voter.Round(0.5))
+		require.NotNil(t, voteCtx)
+		assert.Len(t, voteCtx.Opinions, 1)
+		assert.Zero(t, voteCtx.Rounds)
+	}
+
+	// the threshold was reached in the last round, thus opinions are formed
+	assert.NoError(t, voter.Round(0.5))
+	assert.Len(t, voteCtx.Opinions, 2)
+	assert.Equal(t, 1, voteCtx.Rounds)
+}
+
+func TestFPCQueryFailures(t *testing.T) {
+	failingGiver := func(err error) *opinionsByIDGiverMock {
+		return &opinionsByIDGiverMock{
+			id: identity.GenerateIdentity().ID(),
+			queryErrFunc: func(int) error {
+				return err
+			},
+		}
+	}
+	timeoutGiver := failingGiver(fmt.Errorf("query: %w", context.DeadlineExceeded))
+	refusedGiver := failingGiver(&net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)})
+	decodeGiver := failingGiver(fmt.Errorf("read reply: %w", io.ErrUnexpectedEOF))
+	unknownGiver := failingGiver(errors.New("unknown"))
+	// replies without any opinions
+	wrongCountGiver := &opiniongivermock{roundsReplies: []opinion.Opinions{{}}}
+
+	expectedFailures := map[identity.ID]fpc.QueryFailure{
+		timeoutGiver.ID():    fpc.QueryFailureTimeout,
+		refusedGiver.ID():    fpc.QueryFailureConnectionRefused,
+		decodeGiver.ID():     fpc.QueryFailureDecode,
+		wrongCountGiver.ID(): fpc.QueryFailureDecode,
+		unknownGiver.ID():    fpc.QueryFailureUnknown,
+	}
+
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{timeoutGiver, refusedGiver, decodeGiver, wrongCountGiver, unknownGiver}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.QuerySampleSize = 5
+	paras.SampleWithoutReplacement = true
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+	failures := make(map[identity.ID]fpc.QueryFailure)
+	voter.Events().Error.Attach(events.NewClosure(func(err error) {
+		assert.True(t, errors.Is(err, fpc.ErrOpinionGiverUnreachable))
+		var opinionGiverErr *fpc.OpinionGiverError
+		require.True(t, errors.As(err, &opinionGiverErr))
+		failures[opinionGiverErr.OpinionGiverID] = opinionGiverErr.Failure
+	}))
+
+	assert.NoError(t, voter.Vote("test", vote.ConflictType, opinion.Like))
+	assert.NoError(t, voter.Round(0.5))
+
+	assert.Equal(t, expectedFailures, failures)
+	assert.EqualValues(t, 1, voter.QueryFailures(fpc.QueryFailureTimeout))
+	assert.EqualValues(t, 1, voter.QueryFailures(fpc.QueryFailureConnectionRefused))
+	assert.EqualValues(t, 2, voter.QueryFailures(fpc.QueryFailureDecode))
+	assert.EqualValues(t, 1, voter.QueryFailures(fpc.QueryFailureUnknown))
+}
+
+func TestFPCMaxQueriesPerRound(t *testing.T) {
+	opinionGivers := make([]opinion.OpinionGiver, 2)
+	for i := range opinionGivers {
+		opinionGivers[i] = &opinionsByIDGiverMock{
+			id: identity.GenerateIdentity().ID(),
+			opinionFunc: func(string, int) opinion.Opinion {
+				return opinion.Like
+			},
+		}
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return opinionGivers, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.QuerySampleSize = len(opinionGivers)
+	paras.SampleWithoutReplacement = true
+	// allows to query three vote contexts per opinion giver
+	paras.MaxQueriesPerRound = 6
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+	var queriedIDs map[string]int
+	voter.Events().RoundExecuted.Attach(events.NewClosure(func(roundStats *vote.RoundStats) {
+		queriedIDs = make(map[string]int)
+		for _, queriedOpinions := range roundStats.QueriedOpinions {
+			for id := range queriedOpinions.Opinions {
+				queriedIDs[id]++
+			}
+		}
+	}))
+
+	conflictIDs := []string{"conflict1", "conflict2"}
+	timestampIDs := []string{"timestamp1", "timestamp2", "timestamp3", "timestamp4"}
+	for _, id := range conflictIDs {
+		assert.NoError(t, voter.Vote(id, vote.ConflictType, opinion.Like))
+	}
+	for _, id := range timestampIDs {
+		assert.NoError(t, voter.Vote(id, vote.TimestampType, opinion.Like))
+	}
+
+	// returns the queried timestamps while asserting that the conflicts are always queried and the budget is respected
+	queriedTimestamps := func() []string {
+		var totalQueries int
+		for _, count := range queriedIDs {
+			totalQueries += count
+		}
+		assert.Equal(t, paras.MaxQueriesPerRound, totalQueries)
+		for _, id := range conflictIDs {
+			assert.Equal(t, len(opinionGivers), queriedIDs[id])
+		}
+		var timestamps []string
+		for _, id := range timestampIDs {
+			if queriedIDs[id] > 0 {
+				timestamps = append(timestamps, id)
+			}
+		}
+		return timestamps
+	}
+
+	assert.NoError(t, voter.Round(0.5))
+	firstTimestamps := queriedTimestamps()
+	require.Len(t, firstTimestamps, 1)
+
+	// the timestamps deferred in the last round are queried first
+	assert.NoError(t, voter.Round(0.5))
+	secondTimestamps := queriedTimestamps()
+	require.Len(t, secondTimestamps, 1)
+	assert.NotEqual(t, firstTimestamps[0], secondTimestamps[0])
+}
+
+func TestFPCFinalizationReason(t *testing.T) {
+	type testInput struct {
+		name                      string
+		totalRoundsFixedThreshold int
+		flipOpinions              bool
+		expectedReason            vote.FinalizationReason
+	}
+	tests := []testInput{
+		{"stable proportion", 0, false, vote.StableProportion},
+		{"fixed threshold", 1, false, vote.FixedThreshold},
+		{"max rounds exceeded", 1, true, vote.MaxRoundsExceeded},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opinionGiverMock := &opinionsByIDGiverMock{
+				id: identity.GenerateIdentity().ID(),
+				opinionFunc: func(_ string, query int) opinion.Opinion {
+					// flip the opinion on every query so that it never gets finalized
+					if test.flipOpinions && query%2 == 1 {
+						return opinion.Dislike
+					}
+					return opinion.Like
+				},
+			}
+			opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+				return []opinion.OpinionGiver{opinionGiverMock}, nil
+			}
+			ownWeightRetrieverFunc := func() (float64, error) {
+				return 0, nil
+			}
+
+			paras := fpc.DefaultParameters()
+			paras.QuerySampleSize = 1
+			paras.TotalRoundsFinalization = 2
+			paras.TotalRoundsFixedThreshold = test.totalRoundsFixedThreshold
+			paras.MaxRoundsPerVoteContext = 5
+			voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+			var event *vote.OpinionEvent
+			eventClosure := events.NewClosure(func(ev *vote.OpinionEvent) {
+				event = ev
+			})
+			voter.Events().Finalized.Attach(eventClosure)
+			voter.Events().Failed.Attach(eventClosure)
+
+			assert.NoError(t, voter.Vote("test", vote.ConflictType, opinion.Like))
+			for i := 0; i < paras.MaxRoundsPerVoteContext+1 && event == nil; i++ {
+				assert.NoError(t, voter.Round(0.5))
+			}
+
+			require.NotNil(t, event, "the vote context should have been finalized or failed")
+			assert.Equal(t, test.expectedReason, event.Reason)
+			assert.Equal(t, test.expectedReason, event.Ctx.FinalizationReason)
+		})
+	}
+}
+
+// probingOpinionGiverMock is an opinionsByIDGiverMock which fails the probe with probeErr.
+type probingOpinionGiverMock struct {
+	*opinionsByIDGiverMock
+	probeErr error
+}
+
+func (ogm *probingOpinionGiverMock) Probe(context.Context) error {
+	return ogm.probeErr
+}
+
+func TestFPCProbeBeforeRound(t *testing.T) {
+	likeFunc := func(string, int) opinion.Opinion {
+		return opinion.Like
+	}
+	reachableGiver := &probingOpinionGiverMock{
+		opinionsByIDGiverMock: &opinionsByIDGiverMock{id: identity.GenerateIdentity().ID(), opinionFunc: likeFunc},
+	}
+	unreachableGiver := &probingOpinionGiverMock{
+		opinionsByIDGiverMock: &opinionsByIDGiverMock{id: identity.GenerateIdentity().ID(), opinionFunc: likeFunc},
+		probeErr:              errors.New("unreachable"),
+	}
+	// opinion givers without a probe are assumed reachable
+	nonProbingGiver := &opinionsByIDGiverMock{id: identity.GenerateIdentity().ID(), opinionFunc: likeFunc}
+
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{reachableGiver, unreachableGiver, nonProbingGiver}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.QuerySampleSize = 2
+	paras.SampleWithoutReplacement = true
+	paras.ProbeBeforeRound = true
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+	assert.NoError(t, voter.Vote("test", vote.ConflictType, opinion.Like))
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, voter.Round(0.5))
+	}
+
+	assert.Equal(t, 3, reachableGiver.queries)
+	assert.Zero(t, unreachableGiver.queries)
+	assert.Equal(t, 3, nonProbingGiver.queries)
+}
+
+// fakeClock advances by step on every read.
+type fakeClock struct {
+	now  time.Time
+	step time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	now := c.now
+	c.now = c.now.Add(c.step)
+	return now
+}
+
+func TestFPCSetClock(t *testing.T) {
+	likeFunc := func(string, int) opinion.Opinion {
+		return opinion.Like
+	}
+	newVoter := func(givers []*opinionsByIDGiverMock) *fpc.FPC {
+		opinionGiverFunc := func() ([]opinion.OpinionGiver, error) {
+			opinionGivers := make([]opinion.OpinionGiver, len(givers))
+			for i := range givers {
+				opinionGivers[i] = givers[i]
+			}
+			return opinionGivers, nil
+		}
+		ownWeightRetrieverFunc := func() (float64, error) {
+			return 0, nil
+		}
+		paras := fpc.DefaultParameters()
+		paras.QuerySampleSize = 1
+		paras.SampleWithoutReplacement = true
+		voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+		voter.SetClock(&fakeClock{now: time.Unix(1000, 0), step: time.Second})
+		return voter
+	}
+
+	ids := make([]identity.ID, 5)
+	for i := range ids {
+		ids[i] = identity.GenerateIdentity().ID()
+	}
+	newGivers := func() []*opinionsByIDGiverMock {
+		givers := make([]*opinionsByIDGiverMock, len(ids))
+		for i := range ids {
+			givers[i] = &opinionsByIDGiverMock{id: ids[i], opinionFunc: likeFunc}
+		}
+		return givers
+	}
+
+	giversA, giversB := newGivers(), newGivers()
+	voterA, voterB := newVoter(giversA), newVoter(giversB)
+
+	var durations []time.Duration
+	voterA.Events().RoundExecuted.Attach(events.NewClosure(func(roundStats *vote.RoundStats) {
+		durations = append(durations, roundStats.Duration)
+	}))
+
+	assert.NoError(t, voterA.Vote("test", vote.ConflictType, opinion.Like))
+	assert.NoError(t, voterB.Vote("test", vote.ConflictType, opinion.Like))
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, voterA.Round(0.5))
+		assert.NoError(t, voterB.Round(0.5))
+	}
+
+	// every round reads the clock once at its start and once at its end
+	require.NotEmpty(t, durations)
+	for _, duration := range durations {
+		assert.Equal(t, time.Second, duration)
+	}
+	// the opinion givers are selected deterministically from the seed of the clock
+	for i := range ids {
+		assert.Equal(t, giversA[i].queries, giversB[i].queries)
+	}
+}
+
+func TestFPCRecentRounds(t *testing.T) {
+	opinionGiverMock := &opinionsByIDGiverMock{
+		id:          identity.GenerateIdentity().ID(),
+		opinionFunc: func(string, int) opinion.Opinion { return opinion.Like },
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{opinionGiverMock}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	// no rounds are retained by default
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc)
+	assert.NoError(t, voter.Round(0.5))
+	assert.Empty(t, voter.RecentRounds(0))
+
+	paras := fpc.DefaultParameters()
+	paras.QuerySampleSize = 1
+	paras.RoundStatsBufferSize = 3
+	voter = fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+	assert.NoError(t, voter.Vote("test", vote.ConflictType, opinion.Like))
+	for i := 1; i <= 5; i++ {
+		assert.NoError(t, voter.Round(float64(i)/10))
+	}
+
+	// the buffer is capped at its size and returns the newest rounds first
+	recentRounds := voter.RecentRounds(0)
+	require.Len(t, recentRounds, 3)
+	for i, roundStats := range recentRounds {
+		assert.Equal(t, float64(5-i)/10, roundStats.RandUsed)
+		// the retained stats are snapshots which are not modified by later rounds
+		require.Contains(t, roundStats.ActiveVoteContexts, "test")
+		assert.Equal(t, 5-i, roundStats.ActiveVoteContexts["test"].Rounds)
+	}
+	assert.Len(t, voter.RecentRounds(2), 2)
+	assert.Len(t, voter.RecentRounds(10), 3)
+}
+
+func TestFPCMaxIDsPerQuery(t *testing.T) {
+	var conflictIDs, timestampIDs []string
+	for i := 0; i < 5; i++ {
+		conflictIDs = append(conflictIDs, fmt.Sprintf("conflict%d", i))
+	}
+	for i := 0; i < 4; i++ {
+		timestampIDs = append(timestampIDs, fmt.Sprintf("timestamp%d", i))
+	}
+	// every ID gets its own distinct opinion, so that misattributed opinions are detected
+	expectedOpinions := make(map[string]opinion.Opinion)
+	for i, id := range append(append([]string{}, conflictIDs...), timestampIDs...) {
+		expectedOpinions[id] = opinion.Like
+		if i%2 == 1 {
+			expectedOpinions[id] = opinion.Dislike
+		}
+	}
+
+	queryCountingMock := &queryCountingOpinionGiverMock{
+		opinionsByIDGiverMock: &opinionsByIDGiverMock{
+			id: identity.GenerateIdentity().ID(),
+			opinionFunc: func(id string, _ int) opinion.Opinion {
+				return expectedOpinions[id]
+			},
+		},
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{queryCountingMock}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.QuerySampleSize = 1
+	paras.MaxIDsPerQuery = 3
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+	var queriedOpinions []opinion.QueriedOpinions
+	voter.Events().RoundExecuted.Attach(events.NewClosure(func(roundStats *vote.RoundStats) {
+		queriedOpinions = roundStats.QueriedOpinions
+	}))
+
+	for _, id := range conflictIDs {
+		assert.NoError(t, voter.Vote(id, vote.ConflictType, opinion.Like))
+	}
+	for _, id := range timestampIDs {
+		assert.NoError(t, voter.Vote(id, vote.TimestampType, opinion.Like))
+	}
+	assert.NoError(t, voter.Round(0.5))
+
+	// the 9 IDs are queried in 3 shards of 3 IDs
+	assert.Equal(t, []int{3, 3, 3}, queryCountingMock.queriedIDCounts)
+	require.Len(t, queriedOpinions, 1)
+	assert.Equal(t, expectedOpinions, queriedOpinions[0].Opinions)
+}
+
+func TestFPCRoundBudget(t *testing.T) {
+	const (
+		budget    = 100 * time.Millisecond
+		slowDelay = 2 * time.Second
+	)
+	fastOpinionGiver := &opinionsByIDGiverMock{
+		id: identity.GenerateIdentity().ID(),
+		opinionFunc: func(string, int) opinion.Opinion {
+			return opinion.Like
+		},
+	}
+	slowOpinionGiver := &slowOpinionGiverMock{
+		opinionsByIDGiverMock: &opinionsByIDGiverMock{
+			id: identity.GenerateIdentity().ID(),
+			opinionFunc: func(string, int) opinion.Opinion {
+				return opinion.Dislike
+			},
+		},
+		delay: slowDelay,
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{fastOpinionGiver, slowOpinionGiver}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.QuerySampleSize = 2
+	paras.SampleWithoutReplacement = true
+	paras.QueryTimeout = 2 * slowDelay
+	paras.RoundBudget = budget
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+	var roundStats *vote.RoundStats
+	voter.Events().RoundExecuted.Attach(events.NewClosure(func(stats *vote.RoundStats) {
+		roundStats = stats
+	}))
+	var errs []error
+	voter.Events().Error.Attach(events.NewClosure(func(err error) {
+		errs = append(errs, err)
+	}))
+
+	assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Dislike))
+	assert.NoError(t, voter.Vote("b", vote.ConflictType, opinion.Dislike))
+
+	start := time.Now()
+	assert.NoError(t, voter.Round(0.5))
+	assert.Less(t, int64(time.Since(start)), int64(budget+slowDelay/2))
+
+	// only the fast opinion giver responded within the budget, the cut off query is no failure
+	require.NotNil(t, roundStats)
+	require.Len(t, roundStats.QueriedOpinions, 1)
+	assert.Equal(t, fastOpinionGiver.ID().String(), roundStats.QueriedOpinions[0].OpinionGiverID)
+	assert.Empty(t, errs)
+	for _, id := range []string{"a", "b"} {
+		assert.Equal(t, 1, roundStats.ActiveVoteContexts[id].OpinionsReceived)
+		assert.Equal(t, 1.0, roundStats.ActiveVoteContexts[id].ProportionLiked)
+	}
+}
+
+func TestFPCQuorumCertificate(t *testing.T) {
+	likeOpinionGiver := &opinionsByIDGiverMock{
+		id:   identity.GenerateIdentity().ID(),
+		mana: 10,
+		opinionFunc: func(string, int) opinion.Opinion {
+			return opinion.Like
+		},
+	}
+	// the mana of this opinion giver changes in every query, so that only the mana of the final round matches
+	changingManaOpinionGiver := &opinionsByIDGiverMock{
+		id:   identity.GenerateIdentity().ID(),
+		mana: 5,
+		opinionFunc: func(string, int) opinion.Opinion {
+			return opinion.Like
+		},
+	}
+	// unknown opinions don't contribute to the decision
+	unknownOpinionGiver := &opinionsByIDGiverMock{
+		id:   identity.GenerateIdentity().ID(),
+		mana: 20,
+		opinionFunc: func(string, int) opinion.Opinion {
+			return opinion.Unknown
+		},
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		changingManaOpinionGiver.mana++
+		return []opinion.OpinionGiver{likeOpinionGiver, changingManaOpinionGiver, unknownOpinionGiver}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.TotalRoundsFinalization = 2
+	paras.QuerySampleSize = 3
+	paras.SampleWithoutReplacement = true
+	paras.QuorumCertificates = true
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+	var lastRoundStats, finalRoundStats *vote.RoundStats
+	var lastRoundMana, finalRoundMana float64
+	var finalizedEvent *vote.OpinionEvent
+	voter.Events().RoundExecuted.Attach(events.NewClosure(func(roundStats *vote.RoundStats) {
+		lastRoundStats = roundStats
+		lastRoundMana = changingManaOpinionGiver.mana
+	}))
+	voter.Events().Finalized.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+		finalizedEvent = ev
+		// the opinions queried in the previous round are the final ones
+		finalRoundStats, finalRoundMana = lastRoundStats, lastRoundMana
+	}))
+	assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+
+	for i := 0; i < 10 && finalizedEvent == nil; i++ {
+		assert.NoError(t, voter.Round(0.5))
+	}
+	require.NotNil(t, finalizedEvent, "finalized event should have been fired")
+	require.NotNil(t, finalRoundStats)
+
+	certificate := finalizedEvent.QuorumCertificate
+	require.NotNil(t, certificate)
+	require.Len(t, certificate.Entries, 2)
+
+	// the certificate matches the opinions of the final round
+	expectedMana := map[string]float64{
+		likeOpinionGiver.ID().String():         likeOpinionGiver.mana,
+		changingManaOpinionGiver.ID().String(): finalRoundMana,
+	}
+	var manaSum float64
+	for _, entry := range certificate.Entries {
+		assert.Equal(t, expectedMana[entry.OpinionGiverID], entry.Mana)
+		manaSum += entry.Mana
+		found := false
+		for _, queried := range finalRoundStats.QueriedOpinions {
+			if queried.OpinionGiverID == entry.OpinionGiverID {
+				assert.Equal(t, queried.Opinions["a"], entry.Opinion)
+				found = true
+			}
+		}
+		assert.True(t, found, "opinion giver %s was not queried in the final round", entry.OpinionGiverID)
+	}
+	assert.Equal(t, manaSum, certificate.TotalMana)
+	assert.Equal(t, likeOpinionGiver.mana+finalRoundMana, certificate.TotalMana)
+
+	// no certificate is attached if the flag is not set
+	paras = fpc.DefaultParameters()
+	paras.TotalRoundsFinalization = 2
+	voter = fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+	finalizedEvent = nil
+	voter.Events().Finalized.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+		finalizedEvent = ev
+	}))
+	assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+	for i := 0; i < 10 && finalizedEvent == nil; i++ {
+		assert.NoError(t, voter.Round(0.5))
+	}
+	require.NotNil(t, finalizedEvent, "finalized event should have been fired")
+	assert.Nil(t, finalizedEvent.QuorumCertificate)
+}
+
+// slowOpinionGiverMock replies after the given delay, unless the query is cancelled before.
+type slowOpinionGiverMock struct {
+	*opinionsByIDGiverMock
+	delay time.Duration
+}
+
+func (ogm *slowOpinionGiverMock) Query(ctx context.Context, conflictIDs []string, timestampIDs []string) (opinion.Opinions, error) {
+	select {
+	case <-time.After(ogm.delay):
+		return ogm.opinionsByIDGiverMock.Query(ctx, conflictIDs, timestampIDs)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// queryCountingOpinionGiverMock records the amount of IDs of each query.
+type queryCountingOpinionGiverMock struct {
+	*opinionsByIDGiverMock
+	queriedIDCounts []int
+}
+
+func (ogm *queryCountingOpinionGiverMock) Query(ctx context.Context, conflictIDs []string, timestampIDs []string) (opinion.Opinions, error) {
+	ogm.queriedIDCounts = append(ogm.queriedIDCounts, len(conflictIDs)+len(timestampIDs))
+	return ogm.opinionsByIDGiverMock.Query(ctx, conflictIDs, timestampIDs)
+}
+
+func TestFPCProportionEMAFactor(t *testing.T) {
+	// the queried opinion mostly likes the conflict, but every third query dislikes it
+	runVoter := func(emaFactor float64) (likeFinal, dislikeFinal, failed uint64) {
+		opinionGiverMock := &opinionsByIDGiverMock{
+			id: identity.GenerateIdentity().ID(),
+			opinionFunc: func(_ string, query int) opinion.Opinion {
+				if query%3 == 2 {
+					return opinion.Dislike
+				}
+				return opinion.Like
+			},
+		}
+		opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+			return []opinion.OpinionGiver{opinionGiverMock}, nil
+		}
+		ownWeightRetrieverFunc := func() (float64, error) {
+			return 0, nil
+		}
+
+		paras := fpc.DefaultParameters()
+		paras.FirstRoundLowerBoundThreshold = 0.5
+		paras.FirstRoundUpperBoundThreshold = 0.5
+		paras.SubsequentRoundsLowerBoundThreshold = 0.5
+		paras.SubsequentRoundsUpperBoundThreshold = 0.5
+		paras.TotalRoundsFinalization = 5
+		paras.QuerySampleSize = 1
+		paras.MaxRoundsPerVoteContext = 15
+		paras.ProportionEMAFactor = emaFactor
+		voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+		assert.NoError(t, voter.Vote("noisy", vote.ConflictType, opinion.Like))
+		for i := 0; i < 20; i++ {
+			assert.NoError(t, voter.Round(0.5))
+		}
+		return voter.Counters()
+	}
+
+	// without the averaging, the opinion flips with every disliking query and never gets finalized
+	likeFinal, dislikeFinal, failed := runVoter(0)
+	assert.EqualValues(t, 0, likeFinal)
+	assert.EqualValues(t, 0, dislikeFinal)
+	assert.EqualValues(t, 1, failed)
+
+	// with the averaging, the single disliking queries are dampened and the opinion stays stable
+	likeFinal, dislikeFinal, failed = runVoter(0.7)
+	assert.EqualValues(t, 1, likeFinal)
+	assert.EqualValues(t, 0, dislikeFinal)
+	assert.EqualValues(t, 0, failed)
+}
+
+func TestFPCOwnManaCacheTTL(t *testing.T) {
+	likeFunc := func(string, int) opinion.Opinion {
+		return opinion.Like
+	}
+	newVoter := func(ttl time.Duration) (*fpc.FPC, *int, *fakeClock) {
+		opinionGiverMock := &opinionsByIDGiverMock{id: identity.GenerateIdentity().ID(), opinionFunc: likeFunc}
+		opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+			return []opinion.OpinionGiver{opinionGiverMock}, nil
+		}
+		var retrievals int
+		ownWeightRetrieverFunc := func() (float64, error) {
+			retrievals++
+			return 10, nil
+		}
+
+		paras := fpc.DefaultParameters()
+		paras.QuerySampleSize = 1
+		paras.OwnManaCacheTTL = ttl
+		voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+		clock := &fakeClock{now: time.Unix(1000, 0)}
+		voter.SetClock(clock)
+		return voter, &retrievals, clock
+	}
+
+	// without a TTL, the own mana is retrieved in every round
+	voter, retrievals, _ := newVoter(0)
+	assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, voter.Round(0.5))
+	}
+	assert.Equal(t, 3, *retrievals)
+
+	// within the TTL, the cached own mana is used
+	voter, retrievals, clock := newVoter(10 * time.Second)
+	assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, voter.Round(0.5))
+		clock.now = clock.now.Add(3 * time.Second)
+	}
+	assert.Equal(t, 1, *retrievals)
+
+	// once the TTL expired, the own mana is retrieved again
+	clock.now = clock.now.Add(time.Second)
+	assert.NoError(t, voter.Round(0.5))
+	assert.Equal(t, 2, *retrievals)
+}
+
+func TestFPCTolerateOwnManaError(t *testing.T) {
+	likeFunc := func(string, int) opinion.Opinion {
+		return opinion.Like
+	}
+	// the own mana can be retrieved in the given rounds only
+	newVoter := func(tolerate bool, successfulRetrievals int) (*fpc.FPC, *[]error, *int) {
+		opinionGiverMock := &opinionsByIDGiverMock{id: identity.GenerateIdentity().ID(), opinionFunc: likeFunc}
+		opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+			return []opinion.OpinionGiver{opinionGiverMock}, nil
+		}
+		var retrievals int
+		ownWeightRetrieverFunc := func() (float64, error) {
+			retrievals++
+			if retrievals > successfulRetrievals {
+				return 0, assert.AnError
+			}
+			return 10, nil
+		}
+
+		paras := fpc.DefaultParameters()
+		paras.QuerySampleSize = 1
+		paras.TolerateOwnManaError = tolerate
+		voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+		var errs []error
+		voter.Events().Error.Attach(events.NewClosure(func(err error) {
+			errs = append(errs, err)
+		}))
+		var executedRounds int
+		voter.Events().RoundExecuted.Attach(events.NewClosure(func(*vote.RoundStats) {
+			executedRounds++
+		}))
+		assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+		return voter, &errs, &executedRounds
+	}
+
+	// the round proceeds with the last known own mana
+	voter, errs, executedRounds := newVoter(true, 1)
+	assert.NoError(t, voter.Round(0.5))
+	assert.Empty(t, *errs)
+	assert.NoError(t, voter.Round(0.5))
+	assert.Equal(t, 2, *executedRounds)
+	require.Len(t, *errs, 1)
+	assert.True(t, errors.Is((*errs)[0], fpc.ErrOwnManaUnavailable))
+	assert.True(t, voter.LastRoundSuccessful())
+
+	// without a last known own mana, the round fails
+	voter, _, executedRounds = newVoter(true, 0)
+	assert.True(t, errors.Is(voter.Round(0.5), assert.AnError))
+	assert.Zero(t, *executedRounds)
+
+	// without tolerance, the round fails
+	voter, _, executedRounds = newVoter(false, 1)
+	assert.NoError(t, voter.Round(0.5))
+	assert.True(t, errors.Is(voter.Round(0.5), assert.AnError))
+	assert.Equal(t, 1, *executedRounds)
+}
+
+func TestFPCStartupGracePeriod(t *testing.T) {
+	opinionGiverMock := &opinionsByIDGiverMock{
+		id: identity.GenerateIdentity().ID(),
+		opinionFunc: func(_ string, query int) opinion.Opinion {
+			// flip the opinion on every query so that it never gets finalized
+			if query%2 == 0 {
+				return opinion.Like
+			}
+			return opinion.Dislike
+		},
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{opinionGiverMock}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.TotalRoundsFinalization = 2
+	paras.QuerySampleSize = 1
+	paras.MaxRoundsPerVoteContext = 3
+	paras.StartupGracePeriod = time.Minute
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	voter.SetClock(clock)
+
+	assert.NoError(t, voter.Vote("flipping", vote.ConflictType, opinion.Like))
+
+	// the vote context exceeds MaxRoundsPerVoteContext within the grace period, but is spared
+	for i := 0; i < 6; i++ {
+		assert.NoError(t, voter.Round(0.5))
+		clock.now = clock.now.Add(10 * time.Second)
+	}
+	_, _, failed := voter.Counters()
+	assert.EqualValues(t, 0, failed)
+	assert.Contains(t, voter.ActiveVoteContexts(), "flipping")
+
+	// once the grace period is over, it fails normally
+	assert.NoError(t, voter.Round(0.5))
+	_, _, failed = voter.Counters()
+	assert.EqualValues(t, 1, failed)
+	assert.Empty(t, voter.ActiveVoteContexts())
+}
+
+func TestFPCAgreementRate(t *testing.T) {
+	// the opinions of the four opinion givers per vote context
+	opinions := map[string][]opinion.Opinion{
+		"split":     {opinion.Like, opinion.Like, opinion.Like, opinion.Dislike},
+		"even":      {opinion.Like, opinion.Dislike, opinion.Like, opinion.Dislike},
+		"unanimous": {opinion.Dislike, opinion.Dislike, opinion.Dislike, opinion.Dislike},
+		"partial":   {opinion.Like, opinion.Unknown, opinion.Dislike, opinion.Dislike},
+		"unknown":   {opinion.Unknown, opinion.Unknown, opinion.Unknown, opinion.Unknown},
+	}
+	opinionGivers := make([]opinion.OpinionGiver, 4)
+	for i := range opinionGivers {
+		giverIdx := i
+		opinionGivers[i] = &opinionsByIDGiverMock{
+			id:   identity.GenerateIdentity().ID(),
+			mana: 1,
+			opinionFunc: func(id string, _ int) opinion.Opinion {
+				return opinions[id][giverIdx]
+			},
+		}
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return opinionGivers, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.QuerySampleSize = len(opinionGivers)
+	paras.SampleWithoutReplacement = true
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+	var agreementRate map[string]float64
+	voter.Events().RoundExecuted.Attach(events.NewClosure(func(roundStats *vote.RoundStats) {
+		agreementRate = roundStats.AgreementRate
+	}))
+
+	for id := range opinions {
+		assert.NoError(t, voter.Vote(id, vote.ConflictType, opinion.Like))
+	}
+	assert.NoError(t, voter.Round(0.5))
+
+	assert.Equal(t, map[string]float64{
+		"split":     0.75,
+		"even":      0.5,
+		"unanimous": 1,
+		"partial":   2. / 3,
+	}, agreementRate)
+}
+
+func TestFPCFinalizationDuration(t *testing.T) {
+	opinionGiverMock := &opiniongivermock{
+		roundsReplies: []opinion.Opinions{{opinion.Like}},
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{opinionGiverMock}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.TotalRoundsFinalization = 2
+	paras.TotalRoundsCoolingOffPeriod = 2
+	paras.QuerySampleSize = 1
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+	start := time.Unix(1000, 0)
+	voter.SetClock(&fakeClock{now: start, step: time.Second})
+
+	var finalizedCtx *vote.Context
+	voter.Events().Finalized.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+		finalizedCtx = &ev.Ctx
+	}))
+	assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+
+	rounds := 0
+	for ; finalizedCtx == nil && rounds < 10; rounds++ {
+		activeCtx, ok := voter.ActiveVoteContexts()["a"]
+		if ok {
+			_, finalized := activeCtx.FinalizationDuration()
+			assert.False(t, finalized)
+		}
+		assert.NoError(t, voter.Round(0.5))
+	}
+	require.NotNil(t, finalizedCtx, "finalized event should have been fired")
+
+	duration, finalized := finalizedCtx.FinalizationDuration()
+	assert.True(t, finalized)
+	// SetClock reads the clock once to reseed the opinion giver selection before the vote is enqueued
+	assert.Equal(t, start.Add(time.Second), finalizedCtx.EnqueueTime)
+	// the fake clock advances by one second on every read, so the duration is bounded by the reads of all rounds
+	assert.Greater(t, duration, time.Duration(0))
+	assert.LessOrEqual(t, duration, time.Duration(rounds)*3*time.Second)
+}
+
+func TestFPCOpinionGiverHistory(t *testing.T) {
+	// giver A alternates its opinion on "a" and always likes "b", giver B always dislikes
+	giverA := &opinionsByIDGiverMock{
+		id: identity.GenerateIdentity().ID(),
+		opinionFunc: func(id string, query int) opinion.Opinion {
+			if id == "a" && query%2 == 1 {
+				return opinion.Dislike
+			}
+			return opinion.Like
+		},
+	}
+	giverB := &opinionsByIDGiverMock{
+		id:          identity.GenerateIdentity().ID(),
+		opinionFunc: func(string, int) opinion.Opinion { return opinion.Dislike },
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{giverA, giverB}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	// no history is retained by default
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc)
+	assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+	assert.NoError(t, voter.Round(0.5))
+	assert.Nil(t, voter.OpinionGiverHistory(giverA.ID().String()))
+
+	giverA.queries, giverB.queries = 0, 0
+	paras := fpc.DefaultParameters()
+	paras.QuerySampleSize = 2
+	paras.SampleWithoutReplacement = true
+	paras.OpinionGiverHistorySize = 3
+	voter = fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+	assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+	assert.NoError(t, voter.Vote("b", vote.TimestampType, opinion.Like))
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, voter.Round(0.5))
+	}
+
+	// only the opinions of the last three queries are retained, oldest first
+	assert.Equal(t, map[string][]opinion.Opinion{
+		"a": {opinion.Like, opinion.Dislike, opinion.Like},
+		"b": {opinion.Like, opinion.Like, opinion.Like},
+	}, voter.OpinionGiverHistory(giverA.ID().String()))
+	assert.Equal(t, map[string][]opinion.Opinion{
+		"a": {opinion.Dislike, opinion.Dislike, opinion.Dislike},
+		"b": {opinion.Dislike, opinion.Dislike, opinion.Dislike},
+	}, voter.OpinionGiverHistory(giverB.ID().String()))
+	assert.Nil(t, voter.OpinionGiverHistory(identity.GenerateIdentity().ID().String()))
+}
+
+func TestFPCRun(t *testing.T) {
+	opinionGiverMock := &opinionsByIDGiverMock{
+		id:          identity.GenerateIdentity().ID(),
+		opinionFunc: func(string, int) opinion.Opinion { return opinion.Like },
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{opinionGiverMock}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.QuerySampleSize = 1
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+	assert.NoError(t, voter.Vote("test", vote.ConflictType, opinion.Like))
+
+	const expectedRounds = 3
+	roundsExecuted := make(chan float64, expectedRounds)
+	voter.Events().RoundExecuted.Attach(events.NewClosure(func(roundStats *vote.RoundStats) {
+		select {
+		case roundsExecuted <- roundStats.RandUsed:
+		default:
+		}
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runDone := make(chan struct{})
+	go func() {
+		defer close(runDone)
+		voter.Run(ctx, 5*time.Millisecond, func() float64 { return 0.42 })
+	}()
+
+	for i := 0; i < expectedRounds; i++ {
+		select {
+		case randUsed := <-roundsExecuted:
+			assert.Equal(t, 0.42, randUsed)
+		case <-time.After(time.Second):
+			require.FailNow(t, "round not executed", "executed %d rounds", i)
+		}
+	}
+
+	// the loop stops once the context is cancelled
+	cancel()
+	select {
+	case <-runDone:
+	case <-time.After(time.Second):
+		require.FailNow(t, "Run did not return after the context was cancelled")
+	}
+}
+
+func TestFPCAbstain(t *testing.T) {
+	// the two opinion givers disagree, so the liked proportion sits right at the threshold
+	likeGiver := &opinionsByIDGiverMock{
+		id:          identity.GenerateIdentity().ID(),
+		opinionFunc: func(string, int) opinion.Opinion { return opinion.Like },
+	}
+	dislikeGiver := &opinionsByIDGiverMock{
+		id:          identity.GenerateIdentity().ID(),
+		opinionFunc: func(string, int) opinion.Opinion { return opinion.Dislike },
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{likeGiver, dislikeGiver}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	runVoter := func(abstainBand float64) *fpc.FPC {
+		paras := fpc.DefaultParameters()
+		paras.FirstRoundLowerBoundThreshold = 0.5
+		paras.FirstRoundUpperBoundThreshold = 0.5
+		paras.SubsequentRoundsLowerBoundThreshold = 0.5
+		paras.SubsequentRoundsUpperBoundThreshold = 0.5
+		paras.EndingRoundsFixedThreshold = 0.5
+		paras.TotalRoundsFinalization = 3
+		paras.TotalRoundsFixedThreshold = 1
+		paras.QuerySampleSize = 2
+		paras.SampleWithoutReplacement = true
+		paras.AbstainBand = abstainBand
+		voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+		assert.NoError(t, voter.Vote("tied", vote.ConflictType, opinion.Like))
+		for i := 0; i < 6; i++ {
+			assert.NoError(t, voter.Round(0.5))
+		}
+		return voter
+	}
+
+	// without the dead-band, the tie is broken towards Like and the vote context gets finalized
+	voter := runVoter(0)
+	likeFinal, dislikeFinal, _ := voter.Counters()
+	assert.EqualValues(t, 1, likeFinal)
+	assert.EqualValues(t, 0, dislikeFinal)
+
+	// within the dead-band, the node abstains and the vote context is not finalized
+	voter = runVoter(0.1)
+	likeFinal, dislikeFinal, _ = voter.Counters()
+	assert.EqualValues(t, 0, likeFinal)
+	assert.EqualValues(t, 0, dislikeFinal)
+	voteCtx, ok := voter.ActiveVoteContexts()["tied"]
+	require.True(t, ok)
+	assert.Contains(t, voteCtx.Opinions, opinion.Abstain)
+	assert.Equal(t, opinion.Abstain, voteCtx.LastOpinion())
+}
+
+func TestFPCFinalizationBlocker(t *testing.T) {
+	likeOpinion := func(string, int) opinion.Opinion {
+		return opinion.Like
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	t.Run("rounds", func(t *testing.T) {
+		unreachable := true
+		opinionGiverMock := &opinionsByIDGiverMock{
+			id:          identity.GenerateIdentity().ID(),
+			mana:        10,
+			opinionFunc: likeOpinion,
+			queryErrFunc: func(int) error {
+				if unreachable {
+					return errors.New("unreachable")
+				}
+				return nil
+			},
+		}
+		opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+			return []opinion.OpinionGiver{opinionGiverMock}, nil
+		}
+
+		paras := fpc.DefaultParameters()
+		paras.TotalRoundsFinalization = 2
+		paras.TotalRoundsCoolingOffPeriod = 2
+		paras.QuerySampleSize = 1
+		voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+		_, err := voter.FinalizationBlocker("a")
+		assert.True(t, errors.Is(err, vote.ErrVotingNotFound))
+
+		assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+		blocker, err := voter.FinalizationBlocker("a")
+		require.NoError(t, err)
+		assert.Equal(t, "enqueued, not voted on yet", blocker)
+
+		expected := []string{
+			"insufficient opinions in the last round: received 0, need 1",
+			"in cooling off period: 0 of 2 rounds",
+			"in cooling off period: 1 of 2 rounds",
+			"proportion unstable: opinion Like held for 0 of 2 rounds",
+			"proportion unstable: opinion Like held for 1 of 2 rounds",
+		}
+		for _, want := range expected {
+			assert.NoError(t, voter.Round(0.5))
+			unreachable = false
+
+			blocker, err := voter.FinalizationBlocker("a")
+			require.NoError(t, err)
+			assert.Equal(t, want, blocker)
+		}
+
+		// the vote context is finalized and removed
+		assert.NoError(t, voter.Round(0.5))
+		_, err = voter.FinalizationBlocker("a")
+		assert.True(t, errors.Is(err, vote.ErrVotingNotFound))
+	})
+
+	t.Run("mana share", func(t *testing.T) {
+		highManaGiver := &opinionsByIDGiverMock{
+			id:           identity.GenerateIdentity().ID(),
+			mana:         90,
+			opinionFunc:  likeOpinion,
+			queryErrFunc: func(int) error { return errors.New("unreachable") },
+		}
+		lowManaGiver := &opinionsByIDGiverMock{
+			id:          identity.GenerateIdentity().ID(),
+			mana:        10,
+			opinionFunc: likeOpinion,
+		}
+		opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+			return []opinion.OpinionGiver{highManaGiver, lowManaGiver}, nil
+		}
+
+		paras := fpc.DefaultParameters()
+		paras.TotalRoundsFinalization = 2
+		paras.QuerySampleSize = 2
+		paras.SampleWithoutReplacement = true
+		paras.MinManaShareForFinalization = 0.5
+		voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+		assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+		for i := 0; i < 4; i++ {
+			assert.NoError(t, voter.Round(0.5))
+		}
+		blocker, err := voter.FinalizationBlocker("a")
+		require.NoError(t, err)
+		assert.Equal(t, "below mana share quorum: 0.10 responded, need 0.50", blocker)
+	})
+
+	t.Run("abstain", func(t *testing.T) {
+		likeGiver := &opinionsByIDGiverMock{
+			id:          identity.GenerateIdentity().ID(),
+			opinionFunc: likeOpinion,
+		}
+		dislikeGiver := &opinionsByIDGiverMock{
+			id:          identity.GenerateIdentity().ID(),
+			opinionFunc: func(string, int) opinion.Opinion { return opinion.Dislike },
+		}
+		opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+			return []opinion.OpinionGiver{likeGiver, dislikeGiver}, nil
+		}
+
+		paras := fpc.DefaultParameters()
+		paras.SubsequentRoundsLowerBoundThreshold = 0.5
+		paras.SubsequentRoundsUpperBoundThreshold = 0.5
+		paras.FirstRoundLowerBoundThreshold = 0.5
+		paras.FirstRoundUpperBoundThreshold = 0.5
+		paras.QuerySampleSize = 2
+		paras.SampleWithoutReplacement = true
+		paras.AbstainBand = 0.1
+		voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+		assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+		for i := 0; i < 2; i++ {
+			assert.NoError(t, voter.Round(0.5))
+		}
+		blocker, err := voter.FinalizationBlocker("a")
+		require.NoError(t, err)
+		assert.Equal(t, "abstained in the last round: liked proportion 0.50 is too close to the threshold", blocker)
+	})
+}
+
+func TestFPCQueryRetries(t *testing.T) {
+	runVoter := func(retries int) *vote.Context {
+		// the first query of the opinion giver fails, all further ones succeed
+		opinionGiverMock := &opinionsByIDGiverMock{
+			id:          identity.GenerateIdentity().ID(),
+			opinionFunc: func(string, int) opinion.Opinion { return opinion.Like },
+			queryErrFunc: func(query int) error {
+				if query == 0 {
+					return errors.New("transient failure")
+				}
+				return nil
+			},
+		}
+		opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+			return []opinion.OpinionGiver{opinionGiverMock}, nil
+		}
+		ownWeightRetrieverFunc := func() (float64, error) {
+			return 0, nil
+		}
+
+		paras := fpc.DefaultParameters()
+		paras.QuerySampleSize = 1
+		paras.QueryRetries = retries
+		paras.QueryRetryBackoff = time.Millisecond
+		voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+		assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+		assert.NoError(t, voter.Round(0.5))
+		return voter.ActiveVoteContexts()["a"]
+	}
+
+	// without retries, the opinion giver does not contribute to the round
+	voteCtx := runVoter(0)
+	assert.Zero(t, voteCtx.OpinionsReceived)
+	assert.True(t, voteCtx.IsNew())
+
+	// with a retry, the opinion of the opinion giver is counted
+	voteCtx = runVoter(1)
+	assert.Equal(t, 1, voteCtx.OpinionsReceived)
+	assert.Equal(t, 1., voteCtx.ProportionLiked)
+}
+
+func TestFPCHysteresisMargin(t *testing.T) {
+	// the liked proportion oscillates between 0.6 and 0.5 around the threshold of 0.55
+	runVoter := func(margin float64) (likeFinal, dislikeFinal, failed uint64) {
+		opinionGivers := make([]opinion.OpinionGiver, 0, 10)
+		for i := 0; i < 10; i++ {
+			giverOpinion := opinion.Dislike
+			if i < 5 {
+				giverOpinion = opinion.Like
+			}
+			alternating := i == 9
+			opinionGivers = append(opinionGivers, &opinionsByIDGiverMock{
+				id: identity.GenerateIdentity().ID(),
+				opinionFunc: func(_ string, query int) opinion.Opinion {
+					if alternating && query%2 == 0 {
+						return opinion.Like
+					}
+					return giverOpinion
+				},
+			})
+		}
+		opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+			return opinionGivers, nil
+		}
+		ownWeightRetrieverFunc := func() (float64, error) {
+			return 0, nil
+		}
+
+		paras := fpc.DefaultParameters()
+		paras.FirstRoundLowerBoundThreshold = 0.55
+		paras.FirstRoundUpperBoundThreshold = 0.55
+		paras.SubsequentRoundsLowerBoundThreshold = 0.55
+		paras.SubsequentRoundsUpperBoundThreshold = 0.55
+		paras.EndingRoundsFixedThreshold = 0.55
+		paras.TotalRoundsFinalization = 3
+		paras.TotalRoundsFixedThreshold = 1
+		paras.QuerySampleSize = 10
+		paras.SampleWithoutReplacement = true
+		paras.MaxRoundsPerVoteContext = 10
+		paras.HysteresisMargin = margin
+		voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+		assert.NoError(t, voter.Vote("borderline", vote.ConflictType, opinion.Like))
+		for i := 0; i < 15; i++ {
+			assert.NoError(t, voter.Round(0.5))
+		}
+		return voter.Counters()
+	}
+
+	// without the hysteresis, the opinion flips with every round and never gets finalized
+	likeFinal, dislikeFinal, failed := runVoter(0)
+	assert.EqualValues(t, 0, likeFinal)
+	assert.EqualValues(t, 0, dislikeFinal)
+	assert.EqualValues(t, 1, failed)
+
+	// with the hysteresis, the liked proportion stays within the margin and the opinion does not flip
+	likeFinal, dislikeFinal, failed = runVoter(0.1)
+	assert.EqualValues(t, 1, likeFinal)
+	assert.EqualValues(t, 0, dislikeFinal)
+	assert.EqualValues(t, 0, failed)
+}
+
+func TestFPCSamplingAuditLog(t *testing.T) {
+	opinionGivers := make([]opinion.OpinionGiver, 0, 4)
+	for i := 1; i <= 4; i++ {
+		opinionGivers = append(opinionGivers, &opinionsByIDGiverMock{
+			id:          identity.GenerateIdentity().ID(),
+			mana:        float64(i),
+			opinionFunc: func(string, int) opinion.Opinion { return opinion.Like },
+		})
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return opinionGivers, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	var lines []string
+	paras := fpc.DefaultParameters()
+	paras.QuerySampleSize = 3
+	paras.SamplingAuditLog = func(format string, args ...interface{}) {
+		lines = append(lines, fmt.Sprintf(format, args...))
+	}
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+	voter.SetOpinionGiverRng(rand.New(rand.NewSource(42)))
+
+	assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+	assert.NoError(t, voter.Round(0.5))
+
+	// replay the sampling with the same seed
+	var expected []string
+	totals := []float64{1, 3, 6, 10}
+	for i, total := range totals {
+		expected = append(expected, fmt.Sprintf("sampling: opinion giver %s owns cumulative mana boundary %f", opinionGivers[i].ID(), total))
+	}
+	rng := rand.New(rand.NewSource(42))
+	selected := make(map[opinion.OpinionGiver]struct{})
+	for i := 0; i < paras.MaxQuerySampleSize && len(selected) < paras.QuerySampleSize; i++ {
+		rnd := rng.Float64() * totals[len(totals)-1]
+		for idx, total := range totals {
+			if rnd < total {
+				selected[opinionGivers[idx]] = struct{}{}
+				expected = append(expected, fmt.Sprintf("sampling: draw %d: random mana %f selected opinion giver %s", i, rnd, opinionGivers[idx].ID()))
+				break
+			}
+		}
+	}
+	assert.Equal(t, expected, lines)
+
+	// exactly the logged opinion givers were queried
+	for _, opinionGiver := range opinionGivers {
+		_, ok := selected[opinionGiver]
+		assert.Equal(t, ok, opinionGiver.(*opinionsByIDGiverMock).queries > 0)
+	}
+}
+
+func TestFPCRoundStatsOnlyWithHandlers(t *testing.T) {
+	opinionGiverMock := &opinionsByIDGiverMock{
+		id:          identity.GenerateIdentity().ID(),
+		opinionFunc: func(string, int) opinion.Opinion { return opinion.Like },
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{opinionGiverMock}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.RoundStatsBufferSize = 0
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+	clock := &fakeClock{now: time.Unix(1000, 0), step: time.Second}
+	voter.SetClock(clock)
+	assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+
+	// the duration of the round is only measured when the round stats are assembled,
+	// so the number of clock reads tells whether they were
+	clockReads := func() time.Duration {
+		before := clock.now
+		require.NoError(t, voter.Round(0.5))
+		return clock.now.Sub(before) / clock.step
+	}
+	withoutHandler := clockReads()
+
+	var triggered int
+	closure := events.NewClosure(func(roundStats *vote.RoundStats) {
+		triggered++
+		assert.Contains(t, roundStats.ActiveVoteContexts, "a")
+	})
+	voter.Events().RoundExecuted.Attach(closure)
+	assert.True(t, voter.Events().RoundExecuted.HasHandlers())
+	assert.Equal(t, withoutHandler+1, clockReads())
+	assert.Equal(t, 1, triggered)
+
+	voter.Events().RoundExecuted.Detach(closure)
+	assert.False(t, voter.Events().RoundExecuted.HasHandlers())
+	assert.Equal(t, withoutHandler, clockReads())
+	assert.Equal(t, 1, triggered)
+}
+
+func TestFPCSeedOpinion(t *testing.T) {
+	opinionGiverMock := &opinionsByIDGiverMock{
+		id:          identity.GenerateIdentity().ID(),
+		opinionFunc: func(string, int) opinion.Opinion { return opinion.Like },
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{opinionGiverMock}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+	history := make([]opinion.Opinion, paras.TotalRoundsFinalization)
+	for i := range history {
+		history[i] = opinion.Like
+	}
+	assert.True(t, errors.Is(voter.SeedOpinion("a", vote.ConflictType, nil, 0), fpc.ErrInvalidSeed))
+	require.NoError(t, voter.SeedOpinion("a", vote.ConflictType, history, paras.TotalRoundsFinalization-1))
+	assert.True(t, errors.Is(voter.SeedOpinion("a", vote.ConflictType, history, 0), fpc.ErrVoteAlreadyOngoing))
+	assert.True(t, errors.Is(voter.Vote("a", vote.ConflictType, opinion.Like), fpc.ErrVoteAlreadyOngoing))
+
+	// the seeded vote context is active right away and continues from the given round
+	require.Contains(t, voter.ActiveVoteContexts(), "a")
+	require.NoError(t, voter.Round(0.5))
+	voteCtx := voter.ActiveVoteContexts()["a"]
+	assert.Equal(t, paras.TotalRoundsFinalization, voteCtx.Rounds)
+	assert.Equal(t, history, voteCtx.Opinions)
+
+	// a single formed opinion completes the seeded history
+	require.NoError(t, voter.Round(0.5))
+	likeFinal, _, _ := voter.Counters()
+	assert.EqualValues(t, 1, likeFinal)
+	assert.NotContains(t, voter.ActiveVoteContexts(), "a")
+}
+
+func TestFPCOpinionGiverRefreshInterval(t *testing.T) {
+	likeFunc := func(string, int) opinion.Opinion {
+		return opinion.Like
+	}
+	newVoter := func(interval time.Duration) (*fpc.FPC, *int, *fakeClock) {
+		opinionGiverMock := &opinionsByIDGiverMock{id: identity.GenerateIdentity().ID(), opinionFunc: likeFunc}
+		var retrievals int
+		opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+			retrievals++
+			return []opinion.OpinionGiver{opinionGiverMock}, nil
+		}
+		ownWeightRetrieverFunc := func() (float64, error) {
+			return 0, nil
+		}
+
+		paras := fpc.DefaultParameters()
+		paras.QuerySampleSize = 1
+		paras.OpinionGiverRefreshInterval = interval
+		voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+		clock := &fakeClock{now: time.Unix(1000, 0)}
+		voter.SetClock(clock)
+		return voter, &retrievals, clock
+	}
+
+	// without a refresh interval, the opinion givers are retrieved in every round
+	voter, retrievals, _ := newVoter(0)
+	assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, voter.Round(0.5))
+	}
+	assert.Equal(t, 3, *retrievals)
+
+	// within the refresh interval, the cached opinion givers are used
+	voter, retrievals, clock := newVoter(10 * time.Second)
+	assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, voter.Round(0.5))
+		clock.now = clock.now.Add(3 * time.Second)
+	}
+	assert.Equal(t, 1, *retrievals)
+
+	// once the list is stale, the opinion givers are retrieved again
+	clock.now = clock.now.Add(time.Second)
+	assert.NoError(t, voter.Round(0.5))
+	assert.Equal(t, 2, *retrievals)
+}
+
+// objectQuerierMock gives the opinion configured for the object type on all objects of that type.
+type objectQuerierMock struct {
+	id       identity.ID
+	opinions map[vote.ObjectType]opinion.Opinion
+	queried  []vote.ObjectIDs
+}
+
+func (m *objectQuerierMock) ID() identity.ID {
+	return m.id
+}
+
+func (m *objectQuerierMock) Mana() float64 {
+	return 1
+}
+
+func (m *objectQuerierMock) Query(ctx context.Context, conflictIDs []string, timestampIDs []string) (opinion.Opinions, error) {
+	return m.QueryObjects(ctx, vote.ObjectIDs{vote.ConflictType: conflictIDs, vote.TimestampType: timestampIDs})
+}
+
+func (m *objectQuerierMock) QueryObjects(_ context.Context, ids vote.ObjectIDs) (opinion.Opinions, error) {
+	m.queried = append(m.queried, ids)
+	var opinions opinion.Opinions
+	for _, objectType := range ids.Types() {
+		for range ids[objectType] {
+			opinions = append(opinions, m.opinions[objectType])
+		}
+	}
+	return opinions, nil
+}
+
+func TestFPCCustomObjectType(t *testing.T) {
+	const customType vote.ObjectType = vote.TimestampType + 1
+
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+	paras := fpc.DefaultParameters()
+	paras.TotalRoundsFinalization = 2
+	paras.QuerySampleSize = 1
+
+	t.Run("object querier", func(t *testing.T) {
+		opinionGiverMock := &objectQuerierMock{
+			id:       identity.GenerateIdentity().ID(),
+			opinions: map[vote.ObjectType]opinion.Opinion{vote.ConflictType: opinion.Like, customType: opinion.Dislike},
+		}
+		opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+			return []opinion.OpinionGiver{opinionGiverMock}, nil
+		}
+		voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+		finalized := make(map[string]opinion.Opinion)
+		voter.Events().Finalized.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+			finalized[ev.ID] = ev.Opinion
+		}))
+		require.NoError(t, voter.Vote("conflict", vote.ConflictType, opinion.Like))
+		require.NoError(t, voter.Vote("custom", customType, opinion.Like))
+
+		for i := 0; i < 5 && len(finalized) < 2; i++ {
+			require.NoError(t, voter.Round(0.5))
+		}
+		assert.Equal(t, map[string]opinion.Opinion{"conflict": opinion.Like, "custom": opinion.Dislike}, finalized)
+
+		// the object types are carried by the query
+		require.NotEmpty(t, opinionGiverMock.queried)
+		assert.Equal(t, vote.ObjectIDs{vote.ConflictType: {"conflict"}, customType: {"custom"}}, opinionGiverMock.queried[0])
+	})
+
+	t.Run("legacy opinion giver", func(t *testing.T) {
+		opinionGiverMock := &opinionsByIDGiverMock{
+			id:          identity.GenerateIdentity().ID(),
+			opinionFunc: func(string, int) opinion.Opinion { return opinion.Like },
+		}
+		opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+			return []opinion.OpinionGiver{opinionGiverMock}, nil
+		}
+		voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+		var queriedOpinions []opinion.QueriedOpinions
+		voter.Events().RoundExecuted.Attach(events.NewClosure(func(roundStats *vote.RoundStats) {
+			queriedOpinions = roundStats.QueriedOpinions
+		}))
+		require.NoError(t, voter.Vote("conflict", vote.ConflictType, opinion.Like))
+		require.NoError(t, voter.Vote("custom", customType, opinion.Like))
+		require.NoError(t, voter.Round(0.5))
+
+		// opinion givers which only know conflicts and timestamps have no opinion on other objects
+		require.Len(t, queriedOpinions, 1)
+		assert.Equal(t, map[string]opinion.Opinion{"conflict": opinion.Like, "custom": opinion.Unknown}, queriedOpinions[0].Opinions)
+	})
+}
+
+func TestFPCHealthWindow(t *testing.T) {
+	const window = 4
+	likeFunc := func(string, int) opinion.Opinion {
+		return opinion.Like
+	}
+	healthy := &opinionsByIDGiverMock{id: identity.GenerateIdentity().ID(), mana: 10, opinionFunc: likeFunc}
+	flaky := &opinionsByIDGiverMock{id: identity.GenerateIdentity().ID(), mana: 10, opinionFunc: likeFunc,
+		queryErrFunc: func(query int) error {
+			// every other query times out
+			if query%2 == 0 {
+				return context.DeadlineExceeded
+			}
+			return nil
+		},
+	}
+	dead := &opinionsByIDGiverMock{id: identity.GenerateIdentity().ID(), mana: 10, opinionFunc: likeFunc,
+		queryErrFunc: func(int) error { return context.DeadlineExceeded },
+	}
+	opinionGivers := []*opinionsByIDGiverMock{healthy, flaky, dead}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{healthy, flaky, dead}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	var lines []string
+	paras := fpc.DefaultParameters()
+	paras.QuerySampleSize = len(opinionGivers)
+	paras.TotalRoundsFinalization = 100
+	paras.MaxRoundsPerVoteContext = 200
+	paras.HealthWindow = window
+	paras.SamplingAuditLog = func(format string, args ...interface{}) {
+		lines = append(lines, fmt.Sprintf(format, args...))
+	}
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+	voter.SetOpinionGiverRng(rand.New(rand.NewSource(42)))
+	require.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, voter.Round(0.5))
+	}
+
+	// the timeout rates reflect the last queries of each opinion giver
+	expectedRate := func(opinionGiver *opinionsByIDGiverMock) float64 {
+		var timeouts int
+		for query := opinionGiver.queries - 1; query >= 0 && query >= opinionGiver.queries-window; query-- {
+			if opinionGiver.queryErrFunc != nil && opinionGiver.queryErrFunc(query) != nil {
+				timeouts++
+			}
+		}
+		return float64(timeouts) / window
+	}
+	rates := make([]float64, len(opinionGivers))
+	for i, opinionGiver := range opinionGivers {
+		rates[i] = voter.OpinionGiverTimeoutRate(opinionGiver.ID())
+		assert.Equal(t, expectedRate(opinionGiver), rates[i])
+	}
+	assert.Zero(t, rates[0])
+	assert.Equal(t, 0.5, rates[1])
+	// once all queries of the window timed out, the opinion giver is not sampled anymore
+	assert.Equal(t, 1., rates[2])
+	assert.Equal(t, window, dead.queries)
+
+	// the sampling weights are the mana scaled down by the timeout rates
+	lines = nil
+	require.NoError(t, voter.Round(0.5))
+	var expected []string
+	var total float64
+	for i, opinionGiver := range opinionGivers {
+		total += opinionGiver.mana * (1 - rates[i])
+		expected = append(expected, fmt.Sprintf("sampling: opinion giver %s owns cumulative mana boundary %f", opinionGiver.ID(), total))
+	}
+	require.GreaterOrEqual(t, len(lines), len(expected))
+	assert.Equal(t, expected, lines[:len(expected)])
+	assert.Equal(t, window, dead.queries)
+}
+
+func TestFPCImmediateFinalizationProportion(t *testing.T) {
+	const numOpinionGivers = 10
+	opinionGivers := make([]opinion.OpinionGiver, numOpinionGivers)
+	for i := range opinionGivers {
+		i := i
+		opinionGivers[i] = &opinionsByIDGiverMock{
+			id:   identity.GenerateIdentity().ID(),
+			mana: 1,
+			opinionFunc: func(id string, _ int) opinion.Opinion {
+				// 70% of the opinion givers like the borderline vote context
+				if id == "borderline" && i >= 7 {
+					return opinion.Dislike
+				}
+				return opinion.Like
+			},
+		}
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return opinionGivers, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.QuerySampleSize = numOpinionGivers
+	paras.SampleWithoutReplacement = true
+	paras.TotalRoundsFinalization = 5
+	paras.TotalRoundsFixedThreshold = 0
+	paras.ImmediateFinalizationProportion = 0.9
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+	var round int
+	finalizedInRound := make(map[string]int)
+	reasons := make(map[string]vote.FinalizationReason)
+	voter.Events().Finalized.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+		finalizedInRound[ev.ID] = round
+		reasons[ev.ID] = ev.Reason
+	}))
+
+	require.NoError(t, voter.Vote("unanimous", vote.TimestampType, opinion.Like))
+	require.NoError(t, voter.Vote("borderline", vote.TimestampType, opinion.Like))
+	for round = 1; round <= paras.MaxRoundsPerVoteContext && len(finalizedInRound) < 2; round++ {
+		require.NoError(t, voter.Round(0.5))
+	}
+
+	// the near-unanimous vote context is finalized with the first opinion formed after the cooling off period
+	assert.Equal(t, 2, finalizedInRound["unanimous"])
+	assert.Equal(t, vote.OverwhelmingProportion, reasons["unanimous"])
+
+	// the borderline vote context has to hold its opinion for the full schedule
+	assert.Equal(t, paras.TotalRoundsFinalization+1, finalizedInRound["borderline"])
+	assert.Equal(t, vote.StableProportion, reasons["borderline"])
+}
+
+func TestFPCMinResponseFraction(t *testing.T) {
+	const numOpinionGivers = 10
+	opinionGivers := make([]opinion.OpinionGiver, numOpinionGivers)
+	for i := range opinionGivers {
+		opinionGiverMock := &opinionsByIDGiverMock{
+			id:   identity.GenerateIdentity().ID(),
+			mana: 1,
+			opinionFunc: func(_ string, query int) opinion.Opinion {
+				// the opinion givers still responding after the first query dislike the vote context
+				if query > 0 {
+					return opinion.Dislike
+				}
+				return opinion.Like
+			},
+		}
+		// 80% of the opinion givers stop responding after the first query
+		if i >= 2 {
+			opinionGiverMock.queryErrFunc = func(query int) error {
+				if query > 0 {
+					return context.DeadlineExceeded
+				}
+				return nil
+			}
+		}
+		opinionGivers[i] = opinionGiverMock
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return opinionGivers, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.QuerySampleSize = numOpinionGivers
+	paras.SampleWithoutReplacement = true
+	paras.MinResponseFraction = 0.5
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+	var insufficientResponses int
+	voter.Events().Error.Attach(events.NewClosure(func(err error) {
+		if errors.Is(err, fpc.ErrInsufficientResponses) {
+			insufficientResponses++
+		}
+	}))
+
+	require.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+
+	// all opinion givers respond to the first query
+	require.NoError(t, voter.Round(0.5))
+	assert.True(t, voter.LastRoundSuccessful())
+	assert.Zero(t, insufficientResponses)
+
+	// the first opinion is formed, but only 20% of the opinion givers respond to the query
+	require.NoError(t, voter.Round(0.5))
+	assert.False(t, voter.LastRoundSuccessful())
+	assert.Equal(t, 1, insufficientResponses)
+	voteCtx := voter.ActiveVoteContexts()["a"]
+	require.NotNil(t, voteCtx)
+	assert.Equal(t, []opinion.Opinion{opinion.Like, opinion.Like}, voteCtx.Opinions)
+	assert.Equal(t, 1., voteCtx.ProportionLiked)
+
+	// the Dislike responses of the failed rounds are not used to form opinions
+	for i := 0; i < 3; i++ {
+		require.NoError(t, voter.Round(0.5))
+	}
+	assert.Equal(t, 4, insufficientResponses)
+	voteCtx = voter.ActiveVoteContexts()["a"]
+	require.NotNil(t, voteCtx)
+	assert.Equal(t, []opinion.Opinion{opinion.Like, opinion.Like}, voteCtx.Opinions)
+	assert.Equal(t, 1., voteCtx.ProportionLiked)
+}
+
+func TestFPCVoteWithParams(t *testing.T) {
+	opinionGiverMock := &opinionsByIDGiverMock{
+		id:   identity.GenerateIdentity().ID(),
+		mana: 1,
+		opinionFunc: func(string, int) opinion.Opinion {
+			return opinion.Like
+		},
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{opinionGiverMock}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.QuerySampleSize = 1
+	paras.TotalRoundsFinalization = 5
+	paras.TotalRoundsFixedThreshold = 0
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+	// the urgent conflict is finalized on a tighter schedule
+	urgentParas := *paras
+	urgentParas.TotalRoundsFinalization = 2
+
+	var round int
+	finalizedInRound := make(map[string]int)
+	voter.Events().Finalized.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+		finalizedInRound[ev.ID] = round
+	}))
+
+	require.NoError(t, voter.Vote("default", vote.ConflictType, opinion.Like))
+	require.NoError(t, voter.VoteWithParams("urgent", vote.ConflictType, opinion.Like, &urgentParas))
+	assert.True(t, errors.Is(voter.VoteWithParams("urgent", vote.ConflictType, opinion.Like, &urgentParas), fpc.ErrVoteAlreadyOngoing))
+	for round = 1; round <= paras.MaxRoundsPerVoteContext && len(finalizedInRound) < 2; round++ {
+		require.NoError(t, voter.Round(0.5))
+	}
+
+	assert.Equal(t, urgentParas.TotalRoundsFinalization+1, finalizedInRound["urgent"])
+	assert.Equal(t, paras.TotalRoundsFinalization+1, finalizedInRound["default"])
+}
+
+func TestFPCVoteWithParamsQuorum(t *testing.T) {
+	// half of the mana is held by an opinion giver which never responds
+	likeGiver := &opinionsByIDGiverMock{
+		id:   identity.GenerateIdentity().ID(),
+		mana: 1,
+		opinionFunc: func(string, int) opinion.Opinion {
+			return opinion.Like
+		},
+	}
+	unreachableGiver := &opinionsByIDGiverMock{
+		id:   identity.GenerateIdentity().ID(),
+		mana: 1,
+		queryErrFunc: func(int) error {
+			return errors.New("unreachable")
+		},
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{likeGiver, unreachableGiver}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.QuerySampleSize = 2
+	paras.SampleWithoutReplacement = true
+	paras.TotalRoundsFinalization = 2
+	paras.TotalRoundsFixedThreshold = 0
+	paras.MaxRoundsPerVoteContext = 4
+
+	tests := []struct {
+		name     string
+		override func(paras *fpc.Parameters)
+		// the round in which the vote context is finalized, zero if it fails
+		finalizedInRound int
+		failedInRound    int
+	}{
+		{name: "defaults", override: func(*fpc.Parameters) {}, finalizedInRound: 3},
+		{name: "min opinions received", override: func(paras *fpc.Parameters) { paras.MinOpinionsReceived = 2 }, failedInRound: 5},
+		{name: "min mana share", override: func(paras *fpc.Parameters) { paras.MinManaShareForFinalization = 0.6 }, failedInRound: 5},
+		{name: "max re-votes", override: func(paras *fpc.Parameters) {
+			paras.MinOpinionsReceived = 2
+			paras.MaxReVotes = 1
+		}, failedInRound: 9},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+			var round, finalizedInRound, failedInRound int
+			voter.Events().Finalized.Attach(events.NewClosure(func(*vote.OpinionEvent) {
+				finalizedInRound = round
+			}))
+			voter.Events().Failed.Attach(events.NewClosure(func(*vote.OpinionEvent) {
+				failedInRound = round
+			}))
+
+			// the parameters of FPC are left untouched, only the vote context uses the override
+			voteParas := *paras
+			test.override(&voteParas)
+			require.NoError(t, voter.VoteWithParams("a", vote.ConflictType, opinion.Like, &voteParas))
+			for round = 1; round <= 3*paras.MaxRoundsPerVoteContext && finalizedInRound == 0 && failedInRound == 0; round++ {
+				require.NoError(t, voter.Round(0.5))
+			}
+			assert.Equal(t, test.finalizedInRound, finalizedInRound)
+			assert.Equal(t, test.failedInRound, failedInRound)
+		})
+	}
+}
+
+func TestFPCPause(t *testing.T) {
+	opinionGiverMock := &opinionsByIDGiverMock{
+		id:   identity.GenerateIdentity().ID(),
+		mana: 1,
+		opinionFunc: func(string, int) opinion.Opinion {
+			return opinion.Like
+		},
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{opinionGiverMock}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.QuerySampleSize = 1
+	paras.TotalRoundsFinalization = 2
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+
+	finalized := make(map[string]opinion.Opinion)
+	voter.Events().Finalized.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+		finalized[ev.ID] = ev.Opinion
+	}))
+
+	require.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Dislike))
+	require.NoError(t, voter.Round(0.5))
+	require.Equal(t, 1, opinionGiverMock.queries)
+
+	voter.Pause()
+	assert.True(t, voter.IsPaused())
+	require.NoError(t, voter.Vote("b", vote.ConflictType, opinion.Dislike))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, voter.Round(0.5))
+	}
+
+	// new votes are enqueued and the rounds are counted, but nothing is queried, formed or finalized
+	assert.Equal(t, 1, opinionGiverMock.queries)
+	assert.Empty(t, finalized)
+	voteCtxs := voter.ActiveVoteContexts()
+	require.Contains(t, voteCtxs, "a")
+	require.Contains(t, voteCtxs, "b")
+	assert.Equal(t, []opinion.Opinion{opinion.Dislike}, voteCtxs["a"].Opinions)
+	assert.Equal(t, 4, voteCtxs["a"].Rounds)
+	assert.Equal(t, []opinion.Opinion{opinion.Dislike}, voteCtxs["b"].Opinions)
+	assert.Equal(t, 3, voteCtxs["b"].Rounds)
+
+	voter.Resume()
+	assert.False(t, voter.IsPaused())
+
+	// the first round after resuming only queries the opinions
+	require.NoError(t, voter.Round(0.5))
+	assert.Equal(t, 2, opinionGiverMock.queries)
+	voteCtxs = voter.ActiveVoteContexts()
+	assert.Equal(t, []opinion.Opinion{opinion.Dislike}, voteCtxs["a"].Opinions)
+
+	// afterwards, the opinions are formed and finalized as usual
+	for i := 0; i < paras.TotalRoundsFinalization; i++ {
+		require.NoError(t, voter.Round(0.5))
+	}
+	assert.Equal(t, map[string]opinion.Opinion{"a": opinion.Like, "b": opinion.Like}, finalized)
+}
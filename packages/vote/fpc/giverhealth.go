@@ -0,0 +1,52 @@
+package fpc
+
+import (
+	"sync"
+
+	"github.com/iotaledger/hive.go/identity"
+)
+
+// giverHealth tracks whether the most recent queries of each opinion giver timed out.
+type giverHealth struct {
+	window int
+
+	mu sync.Mutex
+	// the outcomes of the recent queries per opinion giver, oldest first. true marks a timeout.
+	timeouts map[identity.ID][]bool
+}
+
+func newGiverHealth(window int) *giverHealth {
+	return &giverHealth{
+		window:   window,
+		timeouts: make(map[identity.ID][]bool),
+	}
+}
+
+// record adds the outcome of a query of the given opinion giver, dropping the oldest one if the window is full.
+func (h *giverHealth) record(id identity.ID, timedOut bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	outcomes := h.timeouts[id]
+	if len(outcomes) < h.window {
+		h.timeouts[id] = append(outcomes, timedOut)
+		return
+	}
+	copy(outcomes, outcomes[1:])
+	outcomes[len(outcomes)-1] = timedOut
+}
+
+// timeoutRate returns the share of timeouts within the window of the given opinion giver.
+// Queries which were not made yet count as successful, so that a single timeout does not exclude a new opinion giver.
+func (h *giverHealth) timeoutRate(id identity.ID) float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var timeouts int
+	for _, timedOut := range h.timeouts[id] {
+		if timedOut {
+			timeouts++
+		}
+	}
+	return float64(timeouts) / float64(h.window)
+}
@@ -0,0 +1,76 @@
+package fpc
+
+import (
+	"sync"
+
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+// opinionHistory retains the most recent opinions each opinion giver provided on each active vote context.
+type opinionHistory struct {
+	size int
+
+	mu sync.RWMutex
+	// the opinions per opinion giver ID and vote context ID, oldest first.
+	opinions map[string]map[string][]opinion.Opinion
+}
+
+func newOpinionHistory(size int) *opinionHistory {
+	return &opinionHistory{
+		size:     size,
+		opinions: make(map[string]map[string][]opinion.Opinion),
+	}
+}
+
+// add adds the given queried opinions to the history, dropping the oldest opinion of a vote context if it already
+// holds size opinions of the opinion giver.
+func (h *opinionHistory) add(queriedOpinions []opinion.QueriedOpinions) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, queried := range queriedOpinions {
+		giverHistory, ok := h.opinions[queried.OpinionGiverID]
+		if !ok {
+			giverHistory = make(map[string][]opinion.Opinion)
+			h.opinions[queried.OpinionGiverID] = giverHistory
+		}
+		for id, o := range queried.Opinions {
+			history := giverHistory[id]
+			if len(history) < h.size {
+				giverHistory[id] = append(history, o)
+				continue
+			}
+			copy(history, history[1:])
+			history[len(history)-1] = o
+		}
+	}
+}
+
+// remove removes the opinions on the given vote context of all opinion givers.
+func (h *opinionHistory) remove(id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for opinionGiverID, giverHistory := range h.opinions {
+		delete(giverHistory, id)
+		if len(giverHistory) == 0 {
+			delete(h.opinions, opinionGiverID)
+		}
+	}
+}
+
+// get returns a copy of the opinions of the given opinion giver per vote context ID, oldest first.
+func (h *opinionHistory) get(opinionGiverID string) map[string][]opinion.Opinion {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	giverHistory, ok := h.opinions[opinionGiverID]
+	if !ok {
+		return nil
+	}
+	result := make(map[string][]opinion.Opinion, len(giverHistory))
+	for id, history := range giverHistory {
+		result[id] = append([]opinion.Opinion(nil), history...)
+	}
+	return result
+}
@@ -1,6 +1,11 @@
 package fpc
 
-import "time"
+import (
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
 
 // Parameters define the parameters of an FPC instance.
 type Parameters struct {
@@ -28,8 +33,104 @@ type Parameters struct {
 	MaxRoundsPerVoteContext int
 	// The max amount of time a query is allowed to take.
 	QueryTimeout time.Duration
+	// QueryRetries defines how many times a failed query of an opinion giver is retried within the same round.
+	// The retries have to be started within QueryTimeout after the queries of the round were sent. Zero disables retries.
+	QueryRetries int
+	// QueryRetryBackoff defines the time to wait before a failed query is retried.
+	QueryRetryBackoff time.Duration
 	// MinOpinionsReceived defines the minimum amount of opinions to receive in order to consider an FPC round valid.
 	MinOpinionsReceived int
+	// RoundBudget defines the time within which the opinion givers of a round are queried. Once it elapsed, no further
+	// queries are started, the pending ones are abandoned and the round proceeds with the responses received so far.
+	// Vote contexts with less than MinOpinionsReceived opinions keep their liked proportion. Zero disables the budget.
+	RoundBudget time.Duration
+	// MinResponseFraction defines the minimum fraction of the sampled opinion givers which must respond in a round.
+	// Otherwise, the round is considered failed and no opinions are formed on its results. Zero disables the check.
+	MinResponseFraction float64
+	// MaxReVotes defines how many times a vote context which failed due to never receiving enough opinions
+	// is voted on again before it is considered failed.
+	MaxReVotes int
+	// MinManaShareForFinalization defines the minimum share of the total mana (own mana included) which must have
+	// responded to the last query in order for a vote context to be finalized. Zero disables the check.
+	MinManaShareForFinalization float64
+	// WarmupMinGivers defines the amount of distinct opinion givers which must have been seen across rounds before
+	// opinions are formed. Until then, the vote contexts are kept in their cooling off period. Zero disables the warm-up.
+	WarmupMinGivers int
+	// MaxQueriesPerRound defines the maximum amount of queried opinions per round, i.e. the amount of queried opinion
+	// givers times the amount of vote contexts. Conflicts are queried before timestamps and the vote contexts exceeding
+	// the budget are deferred to the next round. Zero disables the limit.
+	MaxQueriesPerRound int
+	// MaxIDsPerQuery defines the maximum amount of IDs queried from an opinion giver at once. If more vote contexts are
+	// active, the IDs are split into multiple queries per opinion giver. Zero disables the splitting.
+	MaxIDsPerQuery int
+	// ProportionEMAFactor defines the weight of a vote context's previous liked proportion when it is blended with the
+	// proportion of the latest round, i.e. the liked proportion becomes an exponential moving average across rounds.
+	// Zero disables the averaging and only the latest round's proportion is used.
+	ProportionEMAFactor float64
+	// AbstainBand defines the half-width of the dead-band around the threshold within which the liked proportion is
+	// considered too close to call. Instead of a Like or Dislike, an Abstain opinion is formed, which does not count
+	// towards the finalization of the vote context. Zero disables abstentions.
+	AbstainBand float64
+	// HysteresisMargin defines by how much the liked proportion has to cross the threshold to flip the last opinion of
+	// a vote context, i.e. a Like is only flipped below threshold-HysteresisMargin and a Dislike only at or above
+	// threshold+HysteresisMargin. This stabilizes opinions whose liked proportion hovers around the threshold.
+	// Zero disables the hysteresis.
+	HysteresisMargin float64
+	// ImmediateFinalizationProportion defines the liked proportion at or above which a Like (or at or below 1 minus
+	// which a Dislike) finalizes a vote context immediately after the cooling off period, without holding the opinion
+	// for TotalRoundsFinalization rounds. Zero disables the immediate finalization.
+	ImmediateFinalizationProportion float64
+	// StartupGracePeriod defines the duration after the first round during which vote contexts exceeding
+	// MaxRoundsPerVoteContext are not failed, as the opinion givers and mana are still warming up. They can still be
+	// finalized. Zero disables the grace period.
+	StartupGracePeriod time.Duration
+	// OwnManaCacheTTL defines how long the own mana is cached before it is retrieved again, so that rapid rounds do not
+	// query the mana of the node in every round. Zero disables the caching.
+	OwnManaCacheTTL time.Duration
+	// TolerateOwnManaError defines whether a round proceeds with the last successfully retrieved own mana if retrieving
+	// it fails. The failure is then triggered on the Error event wrapped in ErrOwnManaUnavailable. A round still fails,
+	// if the own mana has never been retrieved.
+	TolerateOwnManaError bool
+	// OpinionGiverRefreshInterval defines how long the list of opinion givers is reused before it is retrieved again,
+	// so that an expensive discovery is not performed in every round. Zero retrieves the list in every round.
+	OpinionGiverRefreshInterval time.Duration
+	// TotalManaTolerance defines the total mana of the opinion givers up to which it is considered zero,
+	// in which case the opinion givers are sampled uniformly instead of based on their mana.
+	TotalManaTolerance float64
+	// ProbeBeforeRound defines whether the reachability of the opinion givers implementing opinion.Prober is probed
+	// before each round. Unreachable opinion givers are excluded from sampling, the others are assumed reachable.
+	ProbeBeforeRound bool
+	// QuorumCertificates defines whether a vote.QuorumCertificate, listing the opinion givers and their mana which
+	// contributed to the opinions of the final round, is attached to every Finalized event.
+	QuorumCertificates bool
+	// RoundStatsBufferSize defines the amount of recent rounds whose stats are retained and returned by RecentRounds.
+	// Zero disables the retention.
+	RoundStatsBufferSize int
+	// OpinionGiverHistorySize defines the amount of most recent opinions retained per opinion giver and active vote
+	// context, which are returned by OpinionGiverHistory. Zero disables the retention.
+	OpinionGiverHistorySize int
+	// HealthWindow defines the amount of most recent queries per opinion giver whose timeout rate scales down the
+	// weight of the opinion giver in the mana based sampling, i.e. its mana is multiplied with (1 - timeout rate).
+	// Zero disables the down-weighting.
+	HealthWindow int
+	// ManaRecencyHalfLife defines the age of the mana of an opinion giver implementing opinion.ManaUpdateTimer after
+	// which its weight in the mana based sampling is halved, i.e. the weight decays exponentially with the time since
+	// the last mana update. Opinion givers not implementing the interface are not affected. Zero disables the decay.
+	ManaRecencyHalfLife time.Duration
+	// SampleWithoutReplacement defines whether an opinion giver can be selected at most once per round.
+	// Falls back to sampling with replacement if there are less opinion givers than QuerySampleSize.
+	SampleWithoutReplacement bool
+	// ValidateResponse is an optional hook which validates the opinions returned by an opinion giver.
+	// Responses failing the validation are ignored and counted as failed queries wrapping ErrInvalidResponse.
+	// The opinions are given in the order of the queried IDs.
+	ValidateResponse func(opinionGiver opinion.OpinionGiver, objectIDs vote.ObjectIDs, opinions []opinion.Opinion) error
+	// VerifyResponse is an optional hook which verifies the signature of the response of an opinion giver. If set, the
+	// opinion givers are queried through opinion.SignedQuerier and responses which are unsigned or fail the
+	// verification are ignored and counted as failed queries wrapping ErrUnverifiedResponse.
+	VerifyResponse func(giverID string, response []byte, sig []byte) error
+	// SamplingAuditLog is an optional hook which logs every decision of the mana based sampling of the opinion givers:
+	// the cumulative mana boundaries of the opinion givers, the random draws and the selected opinion givers.
+	SamplingAuditLog func(format string, args ...interface{})
 }
 
 // DefaultParameters returns the default parameters used in FPC.
@@ -48,6 +149,7 @@ func DefaultParameters() *Parameters {
 		TotalRoundsCoolingOffPeriod:         0,
 		MaxRoundsPerVoteContext:             100,
 		QueryTimeout:                        1500 * time.Millisecond,
+		TotalManaTolerance:                  0.001,
 	}
 
 	return p
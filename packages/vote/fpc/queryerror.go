@@ -0,0 +1,113 @@
+package fpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+
+	"github.com/iotaledger/hive.go/identity"
+)
+
+var (
+	// ErrOpinionGiverUnreachable is wrapped by every OpinionGiverError returned when an opinion giver could not be queried.
+	ErrOpinionGiverUnreachable = errors.New("opinion giver unreachable")
+	// ErrInvalidOpinionCount is returned if an opinion giver replies with a wrong amount of opinions.
+	ErrInvalidOpinionCount = errors.New("invalid amount of opinions")
+)
+
+// QueryFailure classifies why an opinion giver could not be queried.
+type QueryFailure uint8
+
+const (
+	// QueryFailureUnknown is any failure which could not be classified.
+	QueryFailureUnknown QueryFailure = iota
+	// QueryFailureTimeout is a query which did not complete in time, i.e. a slow or overloaded opinion giver.
+	QueryFailureTimeout
+	// QueryFailureConnectionRefused is a query to an opinion giver which refused the connection.
+	QueryFailureConnectionRefused
+	// QueryFailureDecode is a reply which could not be decoded, i.e. a protocol error.
+	QueryFailureDecode
+	// QueryFailureUnverified is a reply whose signature could not be verified.
+	QueryFailureUnverified
+	// QueryFailureInvalid is a reply which failed the response validation.
+	QueryFailureInvalid
+
+	numQueryFailures
+)
+
+// String returns the name of the query failure.
+func (q QueryFailure) String() string {
+	switch q {
+	case QueryFailureTimeout:
+		return "timeout"
+	case QueryFailureConnectionRefused:
+		return "connection refused"
+	case QueryFailureDecode:
+		return "decode"
+	case QueryFailureUnverified:
+		return "unverified"
+	case QueryFailureInvalid:
+		return "invalid"
+	default:
+		return "unknown"
+	}
+}
+
+// OpinionGiverError is the error of a failed query to an opinion giver.
+type OpinionGiverError struct {
+	// OpinionGiverID is the ID of the queried opinion giver.
+	OpinionGiverID identity.ID
+	// Failure is the class of the failure.
+	Failure QueryFailure
+	// Err is the underlying error.
+	Err error
+}
+
+// NewOpinionGiverError creates a new OpinionGiverError by classifying the given query error.
+func NewOpinionGiverError(opinionGiverID identity.ID, err error) *OpinionGiverError {
+	return &OpinionGiverError{
+		OpinionGiverID: opinionGiverID,
+		Failure:        classifyQueryError(err),
+		Err:            err,
+	}
+}
+
+// Error returns the error message.
+func (e *OpinionGiverError) Error() string {
+	return fmt.Sprintf("%s: %s (%s): %s", ErrOpinionGiverUnreachable, e.OpinionGiverID, e.Failure, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *OpinionGiverError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the error matches ErrOpinionGiverUnreachable.
+func (e *OpinionGiverError) Is(target error) bool {
+	return target == ErrOpinionGiverUnreachable
+}
+
+func classifyQueryError(err error) QueryFailure {
+	var netErr net.Error
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, ErrUnverifiedResponse):
+		return QueryFailureUnverified
+	case errors.Is(err, ErrInvalidResponse):
+		return QueryFailureInvalid
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return QueryFailureTimeout
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return QueryFailureConnectionRefused
+	case errors.Is(err, ErrInvalidOpinionCount), errors.Is(err, io.ErrUnexpectedEOF),
+		errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+		return QueryFailureDecode
+	default:
+		return QueryFailureUnknown
+	}
+}
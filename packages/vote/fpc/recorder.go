@@ -0,0 +1,104 @@
+package fpc
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/iotaledger/hive.go/events"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+)
+
+var (
+	// ErrAlreadyRecording is returned if a recording is started while another one is still ongoing.
+	ErrAlreadyRecording = errors.New("a recording is already ongoing")
+)
+
+// recorder writes the RoundStats of every executed round as a length-prefixed record to a writer.
+type recorder struct {
+	mu      sync.Mutex
+	closure *events.Closure
+}
+
+// StartRecording starts writing the RoundStats of every executed round to the given writer.
+// Every record consists of the length of the JSON encoded RoundStats as a big endian uint32 followed by the encoding itself.
+// Errors occurring while writing are fired on the Error event.
+func (f *FPC) StartRecording(w io.Writer) error {
+	f.recorder.mu.Lock()
+	if f.recorder.closure != nil {
+		f.recorder.mu.Unlock()
+		return ErrAlreadyRecording
+	}
+	var closure *events.Closure
+	closure = events.NewClosure(func(roundStats *vote.RoundStats) {
+		f.recorder.mu.Lock()
+		defer f.recorder.mu.Unlock()
+
+		// the recording might have been stopped in the meantime
+		if f.recorder.closure != closure {
+			return
+		}
+		if err := writeRecord(w, roundStats); err != nil {
+			f.events.Error.Trigger(fmt.Errorf("failed to record round: %w", err))
+		}
+	})
+	f.recorder.closure = closure
+	f.recorder.mu.Unlock()
+
+	// attach outside of the lock as the closure might currently be executed
+	f.events.RoundExecuted.Attach(closure)
+	return nil
+}
+
+// StopRecording stops an ongoing recording.
+func (f *FPC) StopRecording() {
+	f.recorder.mu.Lock()
+	closure := f.recorder.closure
+	f.recorder.closure = nil
+	f.recorder.mu.Unlock()
+
+	if closure != nil {
+		f.events.RoundExecuted.Detach(closure)
+	}
+}
+
+// ReplayRecording decodes all the RoundStats recorded with StartRecording from the given reader.
+func ReplayRecording(r io.Reader) ([]*vote.RoundStats, error) {
+	var result []*vote.RoundStats
+	for {
+		var length uint32
+		if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+			if errors.Is(err, io.EOF) {
+				return result, nil
+			}
+			return nil, fmt.Errorf("failed to read record length: %w", err)
+		}
+
+		record := make([]byte, length)
+		if _, err := io.ReadFull(r, record); err != nil {
+			return nil, fmt.Errorf("failed to read record: %w", err)
+		}
+
+		roundStats := &vote.RoundStats{}
+		if err := json.Unmarshal(record, roundStats); err != nil {
+			return nil, fmt.Errorf("failed to decode record: %w", err)
+		}
+		result = append(result, roundStats)
+	}
+}
+
+func writeRecord(w io.Writer, roundStats *vote.RoundStats) error {
+	record, err := json.Marshal(roundStats)
+	if err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, uint32(len(record))); err != nil {
+		return err
+	}
+	_, err = w.Write(record)
+	return err
+}
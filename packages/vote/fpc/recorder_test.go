@@ -0,0 +1,67 @@
+package fpc_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/fpc"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+func TestFPCRecording(t *testing.T) {
+	opinionGiverMock := &opiniongivermock{
+		id:            identity.GenerateIdentity().ID(),
+		roundsReplies: []opinion.Opinions{{opinion.Like}},
+	}
+	opinionGiverFunc := func() (givers []opinion.OpinionGiver, err error) {
+		return []opinion.OpinionGiver{opinionGiverMock}, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.QuerySampleSize = 1
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc, paras)
+	assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+
+	var buf bytes.Buffer
+	require.NoError(t, voter.StartRecording(&buf))
+	assert.True(t, errors.Is(voter.StartRecording(&buf), fpc.ErrAlreadyRecording))
+
+	rands := []float64{0.1, 0.5, 0.9}
+	for _, r := range rands {
+		assert.NoError(t, voter.Round(r))
+	}
+	voter.StopRecording()
+
+	// rounds executed after stopping are not recorded
+	assert.NoError(t, voter.Round(0.3))
+
+	roundStats, err := fpc.ReplayRecording(&buf)
+	require.NoError(t, err)
+	require.Len(t, roundStats, len(rands))
+	for i, stats := range roundStats {
+		assert.Equal(t, rands[i], stats.RandUsed)
+		require.Contains(t, stats.ActiveVoteContexts, "a")
+		assert.Equal(t, i+1, stats.ActiveVoteContexts["a"].Rounds)
+		require.Len(t, stats.QueriedOpinions, 1)
+		assert.Equal(t, opinionGiverMock.ID().String(), stats.QueriedOpinions[0].OpinionGiverID)
+		assert.Equal(t, opinion.Like, stats.QueriedOpinions[0].Opinions["a"])
+	}
+
+	// a new recording can be started after stopping the previous one
+	require.NoError(t, voter.StartRecording(&buf))
+	voter.StopRecording()
+}
+
+func TestReplayRecordingTruncated(t *testing.T) {
+	_, err := fpc.ReplayRecording(bytes.NewReader([]byte{0, 0, 0, 10, '{'}))
+	assert.Error(t, err)
+}
@@ -0,0 +1,54 @@
+package fpc
+
+import (
+	"sync"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+)
+
+// roundStatsBuffer is a ring buffer of the stats of the most recent rounds.
+type roundStatsBuffer struct {
+	mu     sync.RWMutex
+	rounds []*vote.RoundStats
+	next   int
+	full   bool
+}
+
+func newRoundStatsBuffer(size int) *roundStatsBuffer {
+	return &roundStatsBuffer{rounds: make([]*vote.RoundStats, size)}
+}
+
+// add adds a snapshot of the given round stats to the buffer, overwriting the oldest round if the buffer is full.
+// The snapshot does not reference any vote context, so that they can be garbage collected after their finalization.
+func (b *roundStatsBuffer) add(roundStats *vote.RoundStats) {
+	snapshot := roundStats.Snapshot()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.rounds[b.next] = snapshot
+	b.next = (b.next + 1) % len(b.rounds)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// recent returns up to limit of the most recent rounds, newest first. A limit of zero returns all buffered rounds.
+func (b *roundStatsBuffer) recent(limit int) []*vote.RoundStats {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	size := b.next
+	if b.full {
+		size = len(b.rounds)
+	}
+	if limit <= 0 || limit > size {
+		limit = size
+	}
+
+	result := make([]*vote.RoundStats, limit)
+	for i := range result {
+		result[i] = b.rounds[(b.next-1-i+len(b.rounds))%len(b.rounds)]
+	}
+	return result
+}
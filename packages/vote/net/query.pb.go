@@ -31,8 +31,10 @@ type QueryRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ConflictIDs  []string `protobuf:"bytes,1,rep,name=conflictIDs,proto3" json:"conflictIDs,omitempty"`
-	TimestampIDs []string `protobuf:"bytes,2,rep,name=timestampIDs,proto3" json:"timestampIDs,omitempty"`
+	ConflictIDs  []string    `protobuf:"bytes,1,rep,name=conflictIDs,proto3" json:"conflictIDs,omitempty"`
+	TimestampIDs []string    `protobuf:"bytes,2,rep,name=timestampIDs,proto3" json:"timestampIDs,omitempty"`
+	Nonce        []byte      `protobuf:"bytes,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	TypedIDs     []*TypedIDs `protobuf:"bytes,4,rep,name=typedIDs,proto3" json:"typedIDs,omitempty"`
 }
 
 func (x *QueryRequest) Reset() {
@@ -81,12 +83,27 @@ func (x *QueryRequest) GetTimestampIDs() []string {
 	return nil
 }
 
+func (x *QueryRequest) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
+func (x *QueryRequest) GetTypedIDs() []*TypedIDs {
+	if x != nil {
+		return x.TypedIDs
+	}
+	return nil
+}
+
 type QueryReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Opinion []int32 `protobuf:"varint,1,rep,packed,name=opinion,proto3" json:"opinion,omitempty"`
+	Opinion   []int32 `protobuf:"varint,1,rep,packed,name=opinion,proto3" json:"opinion,omitempty"`
+	Signature []byte  `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
 func (x *QueryReply) Reset() {
@@ -128,25 +145,96 @@ func (x *QueryReply) GetOpinion() []int32 {
 	return nil
 }
 
+func (x *QueryReply) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type TypedIDs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type uint32   `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	Ids  []string `protobuf:"bytes,2,rep,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *TypedIDs) Reset() {
+	*x = TypedIDs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_packages_vote_net_query_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TypedIDs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypedIDs) ProtoMessage() {}
+
+func (x *TypedIDs) ProtoReflect() protoreflect.Message {
+	mi := &file_packages_vote_net_query_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypedIDs.ProtoReflect.Descriptor instead.
+func (*TypedIDs) Descriptor() ([]byte, []int) {
+	return file_packages_vote_net_query_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TypedIDs) GetType() uint32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *TypedIDs) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
 var File_packages_vote_net_query_proto protoreflect.FileDescriptor
 
 var file_packages_vote_net_query_proto_rawDesc = []byte{
 	0x0a, 0x1d, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x2f, 0x76, 0x6f, 0x74, 0x65, 0x2f,
 	0x6e, 0x65, 0x74, 0x2f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x03, 0x6e, 0x65, 0x74, 0x22, 0x54, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74,
-	0x49, 0x44, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x6c,
-	0x69, 0x63, 0x74, 0x49, 0x44, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x49, 0x44, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x49, 0x44, 0x73, 0x22, 0x26, 0x0a, 0x0a, 0x51, 0x75,
-	0x65, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x70, 0x69, 0x6e,
-	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x07, 0x6f, 0x70, 0x69, 0x6e, 0x69,
-	0x6f, 0x6e, 0x32, 0x3d, 0x0a, 0x0a, 0x56, 0x6f, 0x74, 0x65, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79,
-	0x12, 0x2f, 0x0a, 0x07, 0x4f, 0x70, 0x69, 0x6e, 0x69, 0x6f, 0x6e, 0x12, 0x11, 0x2e, 0x6e, 0x65,
-	0x74, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f,
-	0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
-	0x00, 0x42, 0x07, 0x5a, 0x05, 0x2e, 0x3b, 0x6e, 0x65, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x03, 0x6e, 0x65, 0x74, 0x22, 0x95, 0x01, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63,
+	0x74, 0x49, 0x44, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x66,
+	0x6c, 0x69, 0x63, 0x74, 0x49, 0x44, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x49, 0x44, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x49, 0x44, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6e,
+	0x6f, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63,
+	0x65, 0x12, 0x29, 0x0a, 0x08, 0x74, 0x79, 0x70, 0x65, 0x64, 0x49, 0x44, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x64, 0x49,
+	0x44, 0x73, 0x52, 0x08, 0x74, 0x79, 0x70, 0x65, 0x64, 0x49, 0x44, 0x73, 0x22, 0x44, 0x0a, 0x0a,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x70,
+	0x69, 0x6e, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x07, 0x6f, 0x70, 0x69,
+	0x6e, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x22, 0x30, 0x0a, 0x08, 0x54, 0x79, 0x70, 0x65, 0x64, 0x49, 0x44, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x03, 0x69, 0x64, 0x73, 0x32, 0x3d, 0x0a, 0x0a, 0x56, 0x6f, 0x74, 0x65, 0x72, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x12, 0x2f, 0x0a, 0x07, 0x4f, 0x70, 0x69, 0x6e, 0x69, 0x6f, 0x6e, 0x12, 0x11, 0x2e,
+	0x6e, 0x65, 0x74, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x0f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x42, 0x07, 0x5a, 0x05, 0x2e, 0x3b, 0x6e, 0x65, 0x74, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -161,19 +249,21 @@ func file_packages_vote_net_query_proto_rawDescGZIP() []byte {
 	return file_packages_vote_net_query_proto_rawDescData
 }
 
-var file_packages_vote_net_query_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_packages_vote_net_query_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_packages_vote_net_query_proto_goTypes = []interface{}{
 	(*QueryRequest)(nil), // 0: net.QueryRequest
 	(*QueryReply)(nil),   // 1: net.QueryReply
+	(*TypedIDs)(nil),     // 2: net.TypedIDs
 }
 var file_packages_vote_net_query_proto_depIdxs = []int32{
-	0, // 0: net.VoterQuery.Opinion:input_type -> net.QueryRequest
-	1, // 1: net.VoterQuery.Opinion:output_type -> net.QueryReply
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	2, // 0: net.QueryRequest.typedIDs:type_name -> net.TypedIDs
+	0, // 1: net.VoterQuery.Opinion:input_type -> net.QueryRequest
+	1, // 2: net.VoterQuery.Opinion:output_type -> net.QueryReply
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_packages_vote_net_query_proto_init() }
@@ -206,6 +296,18 @@ func file_packages_vote_net_query_proto_init() {
 				return nil
 			}
 		}
+		file_packages_vote_net_query_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TypedIDs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -213,7 +315,7 @@ func file_packages_vote_net_query_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_packages_vote_net_query_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1,7 +1,10 @@
 package net
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/binary"
 	"net"
 
 	"github.com/iotaledger/hive.go/events"
@@ -17,6 +20,88 @@ import (
 // If there's no opinion, the function should return Unknown.
 type OpinionRetriever func(id string, objectType vote.ObjectType) opinion.Opinion
 
+// ReplySigner signs the given data and returns the signature.
+type ReplySigner func(data []byte) []byte
+
+// NewQueryRequest creates a request querying the opinions on the given IDs. The conflicts and timestamps are put into
+// their own fields, so that nodes which don't know the typed IDs still answer them, while the IDs of all other object
+// types are added as typed IDs in ascending order of their types. The opinions of the reply are therefore given in the
+// order of vote.ObjectIDs.IDs.
+func NewQueryRequest(objectIDs vote.ObjectIDs, nonce []byte) *QueryRequest {
+	req := &QueryRequest{
+		ConflictIDs:  objectIDs[vote.ConflictType],
+		TimestampIDs: objectIDs[vote.TimestampType],
+		Nonce:        nonce,
+	}
+	for _, objectType := range objectIDs.Types() {
+		if objectType == vote.ConflictType || objectType == vote.TimestampType {
+			continue
+		}
+		req.TypedIDs = append(req.TypedIDs, &TypedIDs{Type: uint32(objectType), Ids: objectIDs[objectType]})
+	}
+	return req
+}
+
+// returns the number of IDs queried by the given request.
+func queriedIDsCount(req *QueryRequest) int {
+	count := len(req.ConflictIDs) + len(req.TimestampIDs)
+	for _, typedIDs := range req.TypedIDs {
+		count += len(typedIDs.Ids)
+	}
+	return count
+}
+
+// QueryNonceSize is the size of the nonce which binds a signed reply to its query.
+const QueryNonceSize = 32
+
+// NewQueryNonce returns a random nonce for a query request.
+func NewQueryNonce() ([]byte, error) {
+	nonce := make([]byte, QueryNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return nonce, nil
+}
+
+// ReplySigningBytes returns the bytes which are signed for a reply with the given opinions to the given request.
+// The data covers the request including its nonce, so that a signed reply can't be replayed for another query.
+func ReplySigningBytes(req *QueryRequest, opinions []int32) []byte {
+	var buffer bytes.Buffer
+	writeSigningBytes(&buffer, req.Nonce)
+	writeSigningUint32(&buffer, uint32(len(req.ConflictIDs)))
+	for _, id := range req.ConflictIDs {
+		writeSigningBytes(&buffer, []byte(id))
+	}
+	writeSigningUint32(&buffer, uint32(len(req.TimestampIDs)))
+	for _, id := range req.TimestampIDs {
+		writeSigningBytes(&buffer, []byte(id))
+	}
+	writeSigningUint32(&buffer, uint32(len(req.TypedIDs)))
+	for _, typedIDs := range req.TypedIDs {
+		writeSigningUint32(&buffer, typedIDs.Type)
+		writeSigningUint32(&buffer, uint32(len(typedIDs.Ids)))
+		for _, id := range typedIDs.Ids {
+			writeSigningBytes(&buffer, []byte(id))
+		}
+	}
+	writeSigningUint32(&buffer, uint32(len(opinions)))
+	for _, o := range opinions {
+		writeSigningUint32(&buffer, uint32(o))
+	}
+	return buffer.Bytes()
+}
+
+func writeSigningUint32(buffer *bytes.Buffer, value uint32) {
+	var encoded [4]byte
+	binary.BigEndian.PutUint32(encoded[:], value)
+	buffer.Write(encoded[:])
+}
+
+func writeSigningBytes(buffer *bytes.Buffer, data []byte) {
+	writeSigningUint32(buffer, uint32(len(data)))
+	buffer.Write(data)
+}
+
 // New creates a new VoterServer.
 func New(voter vote.Voter, opnRetriever OpinionRetriever, bindAddr string, netRxEvent, netTxEvent, queryReceivedEvent *events.Event) *VoterServer {
 	return &VoterServer{
@@ -39,31 +124,41 @@ type VoterServer struct {
 	netRxEvent         *events.Event
 	netTxEvent         *events.Event
 	queryReceivedEvent *events.Event
+	signer             ReplySigner
 	UnimplementedVoterQueryServer
 }
 
+// SetSigner sets the signer used to sign the replies. It must be called before Run.
+func (vs *VoterServer) SetSigner(signer ReplySigner) {
+	vs.signer = signer
+}
+
 // Opinion replies the query request with an opinion and triggers the events.
+// The opinions are given in the order of the conflict IDs, the timestamp IDs and the typed IDs of the request.
 func (vs *VoterServer) Opinion(ctx context.Context, req *QueryRequest) (*QueryReply, error) {
 	reply := &QueryReply{
-		Opinion: make([]int32, len(req.ConflictIDs)+len(req.TimestampIDs)),
+		Opinion: make([]int32, 0, queriedIDsCount(req)),
 	}
-	for i, id := range req.ConflictIDs {
-		// check whether there's an ongoing vote
-		opinion, err := vs.voter.IntermediateOpinion(id)
-		if err == nil {
-			reply.Opinion[i] = int32(opinion)
-			continue
-		}
-		reply.Opinion[i] = int32(vs.opnRetriever(id, vote.ConflictType))
+	for _, id := range req.ConflictIDs {
+		reply.Opinion = append(reply.Opinion, vs.opinion(id, vote.ConflictType))
 	}
-	for i, id := range req.TimestampIDs {
-		// check whether there's an ongoing vote
-		opinion, err := vs.voter.IntermediateOpinion(id)
-		if err == nil {
-			reply.Opinion[i+len(req.ConflictIDs)] = int32(opinion)
-			continue
+	for _, id := range req.TimestampIDs {
+		reply.Opinion = append(reply.Opinion, vs.opinion(id, vote.TimestampType))
+	}
+	for _, typedIDs := range req.TypedIDs {
+		objectType := vote.ObjectType(typedIDs.Type)
+		for _, id := range typedIDs.Ids {
+			// there are no objects of types exceeding vote.ObjectType
+			if uint32(objectType) != typedIDs.Type {
+				reply.Opinion = append(reply.Opinion, int32(opinion.Unknown))
+				continue
+			}
+			reply.Opinion = append(reply.Opinion, vs.opinion(id, objectType))
 		}
-		reply.Opinion[i+len(req.ConflictIDs)] = int32(vs.opnRetriever(id, vote.TimestampType))
+	}
+
+	if vs.signer != nil {
+		reply.Signature = vs.signer(ReplySigningBytes(req, reply.Opinion))
 	}
 
 	if vs.netRxEvent != nil {
@@ -73,12 +168,21 @@ func (vs *VoterServer) Opinion(ctx context.Context, req *QueryRequest) (*QueryRe
 		vs.netTxEvent.Trigger(uint64(proto.Size(reply)))
 	}
 	if vs.queryReceivedEvent != nil {
-		vs.queryReceivedEvent.Trigger(&metrics.QueryReceivedEvent{OpinionCount: len(req.ConflictIDs) + len(req.TimestampIDs)})
+		vs.queryReceivedEvent.Trigger(&metrics.QueryReceivedEvent{OpinionCount: len(reply.Opinion)})
 	}
 
 	return reply, nil
 }
 
+// returns the opinion on the given ID.
+func (vs *VoterServer) opinion(id string, objectType vote.ObjectType) int32 {
+	// check whether there's an ongoing vote
+	if opinion, err := vs.voter.IntermediateOpinion(id); err == nil {
+		return int32(opinion)
+	}
+	return int32(vs.opnRetriever(id, objectType))
+}
+
 // Run starts the voting server.
 func (vs *VoterServer) Run() error {
 	listener, err := net.Listen("tcp", vs.bindAddr)
@@ -0,0 +1,60 @@
+package net
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+type voterMock struct {
+	opinions map[string]opinion.Opinion
+}
+
+func (v *voterMock) Vote(string, vote.ObjectType, opinion.Opinion) error {
+	return nil
+}
+
+func (v *voterMock) IntermediateOpinion(id string) (opinion.Opinion, error) {
+	if o, ok := v.opinions[id]; ok {
+		return o, nil
+	}
+	return opinion.Unknown, errors.New("no ongoing vote")
+}
+
+func (v *voterMock) Events() vote.Events {
+	return vote.Events{}
+}
+
+func TestVoterServer_SignedReplyReplay(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	vs := New(&voterMock{opinions: map[string]opinion.Opinion{"a": opinion.Like}},
+		func(string, vote.ObjectType) opinion.Opinion { return opinion.Dislike }, "", nil, nil, nil)
+	vs.SetSigner(func(data []byte) []byte { return ed25519.Sign(privateKey, data) })
+
+	nonce, err := NewQueryNonce()
+	require.NoError(t, err)
+	query := &QueryRequest{ConflictIDs: []string{"a"}, TimestampIDs: []string{"b"}, Nonce: nonce}
+	reply, err := vs.Opinion(context.Background(), query)
+	require.NoError(t, err)
+	assert.Equal(t, []int32{int32(opinion.Like), int32(opinion.Dislike)}, reply.Opinion)
+	assert.True(t, ed25519.Verify(publicKey, ReplySigningBytes(query, reply.Opinion), reply.Signature))
+
+	// the same reply replayed for a later query with the same IDs must not verify
+	otherNonce, err := NewQueryNonce()
+	require.NoError(t, err)
+	replayedQuery := &QueryRequest{ConflictIDs: query.ConflictIDs, TimestampIDs: query.TimestampIDs, Nonce: otherNonce}
+	assert.False(t, ed25519.Verify(publicKey, ReplySigningBytes(replayedQuery, reply.Opinion), reply.Signature))
+
+	// moving an ID between the conflict and the timestamp IDs must change the signed data
+	movedQuery := &QueryRequest{ConflictIDs: []string{"a", "b"}, Nonce: nonce}
+	assert.NotEqual(t, ReplySigningBytes(query, reply.Opinion), ReplySigningBytes(movedQuery, reply.Opinion))
+}
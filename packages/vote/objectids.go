@@ -0,0 +1,50 @@
+package vote
+
+import (
+	"context"
+	"sort"
+
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+// ObjectIDs groups the IDs of the objects to vote on by their object type.
+type ObjectIDs map[ObjectType][]string
+
+// Types returns the object types which have IDs in ascending order.
+func (o ObjectIDs) Types() []ObjectType {
+	types := make([]ObjectType, 0, len(o))
+	for objectType, ids := range o {
+		if len(ids) > 0 {
+			types = append(types, objectType)
+		}
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// IDs returns all IDs in the order of their ascending object types. This is the order in which the opinions on the
+// IDs are given, e.g. the IDs of the conflicts followed by the ones of the timestamps.
+func (o ObjectIDs) IDs() []string {
+	ids := make([]string, 0, o.Len())
+	for _, objectType := range o.Types() {
+		ids = append(ids, o[objectType]...)
+	}
+	return ids
+}
+
+// Len returns the number of IDs of all object types.
+func (o ObjectIDs) Len() int {
+	var n int
+	for _, ids := range o {
+		n += len(ids)
+	}
+	return n
+}
+
+// ObjectQuerier is optionally implemented by an opinion.OpinionGiver which gives opinions about objects of any type.
+// Opinion givers which only implement opinion.OpinionGiver are queried for the conflicts and timestamps only.
+type ObjectQuerier interface {
+	// QueryObjects queries for the opinions on the given IDs. The opinions are returned in the order of ObjectIDs.IDs.
+	// The passed in context can be used to signal cancellation of the query.
+	QueryObjects(ctx context.Context, ids ObjectIDs) (opinion.Opinions, error)
+}
@@ -2,6 +2,7 @@ package opinion
 
 import (
 	"context"
+	"time"
 
 	"github.com/iotaledger/hive.go/identity"
 )
@@ -16,6 +17,29 @@ type OpinionGiver interface {
 	Mana() float64
 }
 
+// Prober is optionally implemented by an OpinionGiver which can cheaply check whether it is reachable.
+type Prober interface {
+	// Probe checks whether the OpinionGiver is reachable without querying it for opinions.
+	// The passed in context can be used to signal cancellation of the probe.
+	Probe(ctx context.Context) error
+}
+
+// ManaUpdateTimer is optionally implemented by an OpinionGiver which knows when its mana was last updated.
+type ManaUpdateTimer interface {
+	// ManaUpdateTime returns the time at which the mana of the OpinionGiver was last updated or the zero time, if it
+	// is unknown.
+	ManaUpdateTime() time.Time
+}
+
+// SignedQuerier is optionally implemented by an OpinionGiver which can return signed responses.
+type SignedQuerier interface {
+	// QuerySigned queries the OpinionGiver for its opinions on the given IDs and additionally
+	// returns the signed response bytes together with their signature. The response bytes must be built
+	// from the query that was sent, including a fresh nonce, so that a replayed reply fails verification.
+	// The passed in context can be used to signal cancellation of the query.
+	QuerySigned(ctx context.Context, conflictIDs []string, timestampIDs []string) (opinions Opinions, response []byte, signature []byte, err error)
+}
+
 // QueriedOpinions represents queried opinions from a given opinion giver.
 type QueriedOpinions struct {
 	// The ID of the opinion giver.
@@ -47,6 +71,8 @@ const (
 	Dislike Opinion = 1 << 1
 	// Unknown defines an unknown opinion.
 	Unknown Opinion = 1 << 2
+	// Abstain defines an explicit abstention from forming a Like or Dislike opinion.
+	Abstain Opinion = 1 << 3
 )
 
 func (o Opinion) String() string {
@@ -55,6 +81,8 @@ func (o Opinion) String() string {
 		return "Like"
 	case o == Dislike:
 		return "Dislike"
+	case o == Abstain:
+		return "Abstain"
 	}
 	return "Unknown"
 }
@@ -66,6 +94,8 @@ func ConvertInt32Opinion(x int32) Opinion {
 		return Like
 	case x == 1<<1:
 		return Dislike
+	case x == 1<<3:
+		return Abstain
 	}
 	return Unknown
 }
@@ -86,6 +116,8 @@ func ConvertOpinionToInt32ForLiveFeed(x Opinion) int32 {
 		return 1
 	case x == Dislike:
 		return 2
+	case x == Abstain:
+		return 8
 	}
 	return 4
 }
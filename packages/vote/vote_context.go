@@ -1,6 +1,11 @@
 package vote
 
-import "github.com/iotaledger/goshimmer/packages/vote/opinion"
+import (
+	"fmt"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
 
 // NewContext creates a new vote context.
 func NewContext(id string, objectType ObjectType, initOpn opinion.Opinion) *Context {
@@ -21,6 +26,42 @@ const (
 	TimestampType
 )
 
+// FinalizationReason explains why a vote context was finalized or failed.
+type FinalizationReason uint8
+
+const (
+	// NotFinalized defines the reason of a vote context which is still ongoing.
+	NotFinalized FinalizationReason = iota
+	// StableProportion defines a vote context which held its opinion for the required amount of rounds.
+	StableProportion
+	// FixedThreshold defines a vote context which held its opinion for the required amount of rounds
+	// and whose final opinion was formed using the fixed threshold of the ending rounds.
+	FixedThreshold
+	// MaxRoundsExceeded defines a vote context which failed as it was not finalized within the max amount of rounds.
+	MaxRoundsExceeded
+	// OverwhelmingProportion defines a vote context which was finalized right after the cooling off period
+	// as its liked proportion was overwhelmingly one-sided.
+	OverwhelmingProportion
+)
+
+// String returns the name of the finalization reason.
+func (r FinalizationReason) String() string {
+	switch r {
+	case NotFinalized:
+		return "NotFinalized"
+	case StableProportion:
+		return "StableProportion"
+	case FixedThreshold:
+		return "FixedThreshold"
+	case MaxRoundsExceeded:
+		return "MaxRoundsExceeded"
+	case OverwhelmingProportion:
+		return "OverwhelmingProportion"
+	default:
+		return fmt.Sprintf("FinalizationReason(%d)", uint8(r))
+	}
+}
+
 // Context is the context of votes from multiple rounds about a given item.
 type Context struct {
 	ID   string
@@ -34,6 +75,16 @@ type Context struct {
 	Opinions []opinion.Opinion
 	// Weights used for voting
 	Weights VotingWeights
+	// The number of opinions received on the last query.
+	OpinionsReceived int
+	// The number of times the vote context was voted on again after a transient failure.
+	ReVotes int
+	// The reason why the vote context was finalized or failed.
+	FinalizationReason FinalizationReason
+	// The time the vote context was enqueued for voting.
+	EnqueueTime time.Time
+	// The time the vote context was finalized, zero if it was not finalized.
+	FinalizeTime time.Time
 }
 
 // VotingWeights stores parameters used for weighted voting calculation
@@ -42,6 +93,8 @@ type VotingWeights struct {
 	TotalWeights float64
 	// Own mana from the last query
 	OwnWeight float64
+	// Base mana of opinion givers which responded to the last query
+	RespondedWeights float64
 }
 
 // AddOpinion adds the given opinion to this vote context.
@@ -55,7 +108,7 @@ func (vc *Context) LastOpinion() opinion.Opinion {
 }
 
 // IsFinalized tells whether this vote context is finalized by checking whether the opinion was held
-// for totalRoundsFinalization number of rounds.
+// for totalRoundsFinalization number of rounds. Abstentions never finalize a vote context.
 func (vc *Context) IsFinalized(coolingOffPeriod, totalRoundsFinalization int) bool {
 	// check whether we have enough opinions to say whether this vote context is finalized.
 	if len(vc.Opinions) < coolingOffPeriod+totalRoundsFinalization+1 {
@@ -64,6 +117,9 @@ func (vc *Context) IsFinalized(coolingOffPeriod, totalRoundsFinalization int) bo
 
 	// grab opinion which needs to be held for TotalRoundsFinalization number of rounds
 	candidateOpinion := vc.Opinions[len(vc.Opinions)-totalRoundsFinalization]
+	if candidateOpinion == opinion.Abstain {
+		return false
+	}
 
 	// check whether it was held for the subsequent rounds
 	for _, subsequentOpinion := range vc.Opinions[len(vc.Opinions)-totalRoundsFinalization+1:] {
@@ -74,6 +130,33 @@ func (vc *Context) IsFinalized(coolingOffPeriod, totalRoundsFinalization int) bo
 	return true
 }
 
+// IsOverwhelming tells whether the last opinion was formed after the cooling off period from a liked proportion of
+// at least the given proportion in case of a Like, or of at most 1-proportion in case of a Dislike.
+func (vc *Context) IsOverwhelming(coolingOffPeriod int, proportion float64) bool {
+	// check whether an opinion was formed after the cooling off period.
+	if len(vc.Opinions) < coolingOffPeriod+2 {
+		return false
+	}
+
+	switch vc.LastOpinion() {
+	case opinion.Like:
+		return vc.ProportionLiked >= proportion
+	case opinion.Dislike:
+		return vc.ProportionLiked <= 1-proportion
+	default:
+		return false
+	}
+}
+
+// FinalizationDuration returns the wall-clock time it took from enqueuing the vote context until its finalization
+// and whether the vote context is finalized.
+func (vc *Context) FinalizationDuration() (time.Duration, bool) {
+	if vc.FinalizeTime.IsZero() {
+		return 0, false
+	}
+	return vc.FinalizeTime.Sub(vc.EnqueueTime), true
+}
+
 // IsNew tells whether the vote context is new.
 func (vc *Context) IsNew() bool {
 	return vc.ProportionLiked == likedInit
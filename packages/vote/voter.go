@@ -1,6 +1,7 @@
 package vote
 
 import (
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -37,24 +38,132 @@ type Events struct {
 	// Fired when an Opinion couldn't be finalized.
 	Failed *events.Event
 	// Fired when a DRNGRoundBasedVoter has executed a round.
-	RoundExecuted *events.Event
+	RoundExecuted *HandlerCountingEvent
 	// Fired when internal errors occur.
 	Error *events.Event
 }
 
 // RoundStats encapsulates data about an executed round.
+// Its JSON encoding only contains the duration in milliseconds and a summary of the active vote contexts.
 type RoundStats struct {
 	// The time it took to complete a round.
-	Duration time.Duration `json:"duration"`
+	Duration time.Duration
 	// The rand number used during the round.
-	RandUsed float64 `json:"rand_used"`
+	RandUsed float64
 	// The vote contexts on which opinions were formed and queried.
 	// This list does not include the vote contexts which were finalized/aborted
 	// during the execution of the round.
 	// Create a copy of this map if you need to modify any of its elements.
-	ActiveVoteContexts map[string]*Context `json:"active_vote_contexts"`
+	ActiveVoteContexts map[string]*Context
 	// The opinions which were queried during the round per opinion giver.
-	QueriedOpinions []opinion.QueriedOpinions `json:"queried_opinions"`
+	QueriedOpinions []opinion.QueriedOpinions
+	// The fraction of the received opinions which agreed with the majority opinion per vote context ID.
+	// Vote contexts without any Like or Dislike opinion received are omitted.
+	AgreementRate map[string]float64
+}
+
+// roundStatsJSON is the JSON schema of RoundStats.
+type roundStatsJSON struct {
+	DurationMs         int64                      `json:"duration_ms"`
+	RandUsed           float64                    `json:"rand_used"`
+	ActiveVoteContexts map[string]voteContextJSON `json:"active_vote_contexts"`
+	QueriedOpinions    []opinion.QueriedOpinions  `json:"queried_opinions"`
+	AgreementRate      map[string]float64         `json:"agreement_rate,omitempty"`
+}
+
+// voteContextJSON is the JSON schema of a vote context summary within RoundStats.
+type voteContextJSON struct {
+	Type            ObjectType      `json:"type"`
+	Opinion         opinion.Opinion `json:"opinion"`
+	Rounds          int             `json:"rounds"`
+	ProportionLiked float64         `json:"proportion_liked"`
+}
+
+// MarshalJSON encodes the RoundStats with the duration in milliseconds and the active vote contexts as a map of their
+// ID to their type, last opinion, rounds and liked proportion.
+func (rs RoundStats) MarshalJSON() ([]byte, error) {
+	voteCtxs := make(map[string]voteContextJSON, len(rs.ActiveVoteContexts))
+	for id, voteCtx := range rs.ActiveVoteContexts {
+		if voteCtx == nil {
+			continue
+		}
+		lastOpinion := opinion.Unknown
+		if len(voteCtx.Opinions) > 0 {
+			lastOpinion = voteCtx.LastOpinion()
+		}
+		voteCtxs[id] = voteContextJSON{
+			Type:            voteCtx.Type,
+			Opinion:         lastOpinion,
+			Rounds:          voteCtx.Rounds,
+			ProportionLiked: voteCtx.ProportionLiked,
+		}
+	}
+
+	return json.Marshal(&roundStatsJSON{
+		DurationMs:         rs.Duration.Milliseconds(),
+		RandUsed:           rs.RandUsed,
+		ActiveVoteContexts: voteCtxs,
+		QueriedOpinions:    rs.QueriedOpinions,
+		AgreementRate:      rs.AgreementRate,
+	})
+}
+
+// UnmarshalJSON decodes RoundStats encoded with MarshalJSON.
+// The decoded vote contexts only contain their last opinion.
+func (rs *RoundStats) UnmarshalJSON(data []byte) error {
+	decoded := &roundStatsJSON{}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		return err
+	}
+
+	rs.Duration = time.Duration(decoded.DurationMs) * time.Millisecond
+	rs.RandUsed = decoded.RandUsed
+	rs.QueriedOpinions = decoded.QueriedOpinions
+	rs.AgreementRate = decoded.AgreementRate
+	rs.ActiveVoteContexts = make(map[string]*Context, len(decoded.ActiveVoteContexts))
+	for id, voteCtx := range decoded.ActiveVoteContexts {
+		rs.ActiveVoteContexts[id] = &Context{
+			ID:              id,
+			Type:            voteCtx.Type,
+			ProportionLiked: voteCtx.ProportionLiked,
+			Rounds:          voteCtx.Rounds,
+			Opinions:        []opinion.Opinion{voteCtx.Opinion},
+		}
+	}
+	return nil
+}
+
+// Snapshot returns a copy of the RoundStats which does not reference the vote contexts of the voter, so that it can
+// be retained after the round. The copied vote contexts only contain their last opinion.
+func (rs *RoundStats) Snapshot() *RoundStats {
+	snapshot := &RoundStats{
+		Duration:           rs.Duration,
+		RandUsed:           rs.RandUsed,
+		ActiveVoteContexts: make(map[string]*Context, len(rs.ActiveVoteContexts)),
+		QueriedOpinions:    append([]opinion.QueriedOpinions(nil), rs.QueriedOpinions...),
+	}
+	if rs.AgreementRate != nil {
+		snapshot.AgreementRate = make(map[string]float64, len(rs.AgreementRate))
+		for id, rate := range rs.AgreementRate {
+			snapshot.AgreementRate[id] = rate
+		}
+	}
+	for id, voteCtx := range rs.ActiveVoteContexts {
+		if voteCtx == nil {
+			continue
+		}
+		voteCtxCopy := &Context{
+			ID:              voteCtx.ID,
+			Type:            voteCtx.Type,
+			ProportionLiked: voteCtx.ProportionLiked,
+			Rounds:          voteCtx.Rounds,
+		}
+		if len(voteCtx.Opinions) > 0 {
+			voteCtxCopy.Opinions = []opinion.Opinion{voteCtx.LastOpinion()}
+		}
+		snapshot.ActiveVoteContexts[id] = voteCtxCopy
+	}
+	return snapshot
 }
 
 // OpinionEvent is the struct containing data to be passed around with Finalized and Failed events.
@@ -65,6 +174,30 @@ type OpinionEvent struct {
 	Opinion opinion.Opinion
 	// Ctx contains all relevant infos regarding the conflict.
 	Ctx Context
+	// Reason explains why the conflict was finalized or failed.
+	Reason FinalizationReason
+	// QuorumCertificate lists the opinion givers which contributed to the finalization. It is only set on Finalized
+	// events and only if the voter collects quorum certificates.
+	QuorumCertificate *QuorumCertificate
+}
+
+// QuorumCertificate lists the opinion givers whose opinions of the final round contributed to the decision on a vote
+// context, together with their mana in that round.
+type QuorumCertificate struct {
+	// Entries are the contributing opinion givers, ordered by their IDs.
+	Entries []QuorumCertificateEntry `json:"entries"`
+	// TotalMana is the sum of the mana of the contributing opinion givers.
+	TotalMana float64 `json:"total_mana"`
+}
+
+// QuorumCertificateEntry is the opinion of a single opinion giver contributing to a QuorumCertificate.
+type QuorumCertificateEntry struct {
+	// OpinionGiverID is the ID of the opinion giver.
+	OpinionGiverID string `json:"opinion_giver_id"`
+	// Opinion is the opinion of the opinion giver.
+	Opinion opinion.Opinion `json:"opinion"`
+	// Mana is the mana of the opinion giver in the final round.
+	Mana float64 `json:"mana"`
 }
 
 // OpinionCaller calls the given handler with an OpinionEvent (containing its opinions, its associated ID and context).
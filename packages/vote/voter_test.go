@@ -0,0 +1,103 @@
+package vote_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+func TestRoundStatsJSON(t *testing.T) {
+	roundStats := &vote.RoundStats{
+		Duration: 1500 * time.Millisecond,
+		RandUsed: 0.42,
+		ActiveVoteContexts: map[string]*vote.Context{
+			"conflict": {
+				ID:              "conflict",
+				Type:            vote.ConflictType,
+				ProportionLiked: 0.75,
+				Rounds:          3,
+				Opinions:        []opinion.Opinion{opinion.Like, opinion.Like, opinion.Dislike},
+			},
+			"timestamp": {
+				ID:              "timestamp",
+				Type:            vote.TimestampType,
+				ProportionLiked: 0.25,
+				Rounds:          1,
+				Opinions:        []opinion.Opinion{opinion.Like},
+			},
+		},
+		QueriedOpinions: []opinion.QueriedOpinions{
+			{
+				OpinionGiverID: "giver",
+				Opinions:       map[string]opinion.Opinion{"conflict": opinion.Dislike, "timestamp": opinion.Like},
+				TimesCounted:   2,
+			},
+		},
+	}
+
+	data, err := json.Marshal(roundStats)
+	require.NoError(t, err)
+
+	// the schema is stable
+	assert.JSONEq(t, `{
+		"duration_ms": 1500,
+		"rand_used": 0.42,
+		"active_vote_contexts": {
+			"conflict": {"type": 0, "opinion": 2, "rounds": 3, "proportion_liked": 0.75},
+			"timestamp": {"type": 1, "opinion": 1, "rounds": 1, "proportion_liked": 0.25}
+		},
+		"queried_opinions": [
+			{"opinion_giver_id": "giver", "opinions": {"conflict": 2, "timestamp": 1}, "times_counted": 2}
+		]
+	}`, string(data))
+
+	decoded := &vote.RoundStats{}
+	require.NoError(t, json.Unmarshal(data, decoded))
+	assert.Equal(t, roundStats.Duration, decoded.Duration)
+	assert.Equal(t, roundStats.RandUsed, decoded.RandUsed)
+	assert.Equal(t, roundStats.QueriedOpinions, decoded.QueriedOpinions)
+	require.Len(t, decoded.ActiveVoteContexts, len(roundStats.ActiveVoteContexts))
+	for id, voteCtx := range roundStats.ActiveVoteContexts {
+		require.Contains(t, decoded.ActiveVoteContexts, id)
+		decodedCtx := decoded.ActiveVoteContexts[id]
+		assert.Equal(t, voteCtx.ID, decodedCtx.ID)
+		assert.Equal(t, voteCtx.Type, decodedCtx.Type)
+		assert.Equal(t, voteCtx.LastOpinion(), decodedCtx.LastOpinion())
+		assert.Equal(t, voteCtx.Rounds, decodedCtx.Rounds)
+		assert.Equal(t, voteCtx.ProportionLiked, decodedCtx.ProportionLiked)
+	}
+}
+
+func TestRoundStatsSnapshot(t *testing.T) {
+	voteCtx := &vote.Context{
+		ID:              "conflict",
+		Type:            vote.ConflictType,
+		ProportionLiked: 0.75,
+		Rounds:          2,
+		Opinions:        []opinion.Opinion{opinion.Like, opinion.Dislike},
+	}
+	roundStats := &vote.RoundStats{
+		Duration:           time.Second,
+		RandUsed:           0.5,
+		ActiveVoteContexts: map[string]*vote.Context{voteCtx.ID: voteCtx},
+	}
+
+	snapshot := roundStats.Snapshot()
+	// modifying the original vote context does not alter the snapshot
+	voteCtx.Rounds++
+	voteCtx.AddOpinion(opinion.Like)
+	delete(roundStats.ActiveVoteContexts, voteCtx.ID)
+
+	assert.Equal(t, roundStats.Duration, snapshot.Duration)
+	assert.Equal(t, roundStats.RandUsed, snapshot.RandUsed)
+	require.Contains(t, snapshot.ActiveVoteContexts, "conflict")
+	snapshotCtx := snapshot.ActiveVoteContexts["conflict"]
+	assert.Equal(t, 2, snapshotCtx.Rounds)
+	assert.Equal(t, []opinion.Opinion{opinion.Dislike}, snapshotCtx.Opinions)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/iotaledger/goshimmer/packages/mana"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
+	"github.com/iotaledger/goshimmer/plugins/config"
 	manaPlugin "github.com/iotaledger/goshimmer/plugins/messagelayer"
 )
 
@@ -22,6 +23,7 @@ var (
 	manaFeedWorkerQueueSize = 50
 	manaFeedWorkerPool      *workerpool.WorkerPool
 	manaBuffer              *ManaBuffer
+	manaThrottler           *ManaThrottler
 )
 
 func configureManaFeed() {
@@ -40,14 +42,27 @@ func configureManaFeed() {
 		}
 		task.Return(nil)
 	}, workerpool.WorkerCount(manaFeedWorkerCount), workerpool.QueueSize(manaFeedWorkerQueueSize))
+
+	// coalesce the mana events to not flood the clients on a busy network
+	manaThrottler = NewManaThrottler(config.Node().Duration(CfgManaFeedMinInterval), func(msgType byte, params ...interface{}) {
+		manaFeedWorkerPool.Submit(append([]interface{}{msgType}, params...)...)
+	})
 }
 
 func runManaFeed() {
+	// every event is buffered, but only the latest one per interval is pushed to the clients
+	// events of nodes no client subscribed to are not pushed at all
 	notifyManaPledge := events.NewClosure(func(ev *mana.PledgedEvent) {
-		manaFeedWorkerPool.Submit(MsgTypeManaPledge, ev)
+		manaBuffer.StoreEvent(ev)
+		if manaSubscribed(ev.NodeID) {
+			manaThrottler.Update(MsgTypeManaPledge, ev.NodeID, ev)
+		}
 	})
 	notifyManaRevoke := events.NewClosure(func(ev *mana.RevokedEvent) {
-		manaFeedWorkerPool.Submit(MsgTypeManaRevoke, ev)
+		manaBuffer.StoreEvent(ev)
+		if manaSubscribed(ev.NodeID) {
+			manaThrottler.Update(MsgTypeManaRevoke, ev.NodeID, ev)
+		}
 	})
 	if err := daemon.BackgroundWorker("Dashboard[ManaUpdater]", func(shutdownSignal <-chan struct{}) {
 		manaBuffer = NewManaBuffer()
@@ -59,6 +74,7 @@ func runManaFeed() {
 			select {
 			case <-shutdownSignal:
 				log.Info("Stopping Dashboard[ManaUpdater] ...")
+				manaThrottler.Stop()
 				manaFeedWorkerPool.Stop()
 				manaTicker.Stop()
 				log.Info("Stopping Dashboard[ManaUpdater] ... done")
@@ -169,19 +185,17 @@ func sendManaMapOnline() {
 }
 
 func sendManaPledge(ev *mana.PledgedEvent) {
-	manaBuffer.StoreEvent(ev)
-	broadcastWsMessage(&wsmsg{
+	broadcastManaWsMessage(&wsmsg{
 		Type: MsgTypeManaPledge,
 		Data: ev.ToJSONSerializable(),
-	})
+	}, ev.NodeID)
 }
 
 func sendManaRevoke(ev *mana.RevokedEvent) {
-	manaBuffer.StoreEvent(ev)
-	broadcastWsMessage(&wsmsg{
+	broadcastManaWsMessage(&wsmsg{
 		Type: MsgTypeManaRevoke,
 		Data: ev.ToJSONSerializable(),
-	})
+	}, ev.NodeID)
 }
 
 // endregion
@@ -0,0 +1,91 @@
+package dashboard
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+)
+
+// ManaThrottler coalesces the mana feed updates of each message type and node, so that at most one update per message
+// type and node is pushed per interval. If multiple updates arrive within the interval, the latest one is pushed.
+// The updates are coalesced per node, as the clients only receive the updates of the nodes they subscribed to.
+type ManaThrottler struct {
+	interval time.Duration
+	push     func(msgType byte, params ...interface{})
+
+	lastPush map[manaThrottlerKey]time.Time
+	pending  map[manaThrottlerKey][]interface{}
+	timers   map[manaThrottlerKey]*time.Timer
+	mutex    sync.Mutex
+}
+
+// manaThrottlerKey identifies the updates which are coalesced.
+type manaThrottlerKey struct {
+	msgType byte
+	nodeID  identity.ID
+}
+
+// NewManaThrottler creates a new throttler which pushes the coalesced updates with the given function.
+func NewManaThrottler(interval time.Duration, push func(msgType byte, params ...interface{})) *ManaThrottler {
+	return &ManaThrottler{
+		interval: interval,
+		push:     push,
+		lastPush: make(map[manaThrottlerKey]time.Time),
+		pending:  make(map[manaThrottlerKey][]interface{}),
+		timers:   make(map[manaThrottlerKey]*time.Timer),
+	}
+}
+
+// Update pushes the given update of the given node right away if no update of the same message type and node was
+// pushed within the interval. Otherwise, the update replaces any pending update of the same message type and node and
+// is pushed once the interval elapsed.
+func (t *ManaThrottler) Update(msgType byte, nodeID identity.ID, params ...interface{}) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	key := manaThrottlerKey{msgType: msgType, nodeID: nodeID}
+	// a push is already scheduled, the latest update wins
+	if _, scheduled := t.timers[key]; scheduled {
+		t.pending[key] = params
+		return
+	}
+
+	sinceLastPush := time.Since(t.lastPush[key])
+	if sinceLastPush >= t.interval {
+		t.lastPush[key] = time.Now()
+		t.push(msgType, params...)
+		return
+	}
+
+	t.pending[key] = params
+	t.timers[key] = time.AfterFunc(t.interval-sinceLastPush, func() { t.flush(key) })
+}
+
+// Stop stops the throttler and drops all pending updates.
+func (t *ManaThrottler) Stop() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for key, timer := range t.timers {
+		timer.Stop()
+		delete(t.timers, key)
+		delete(t.pending, key)
+	}
+}
+
+// pushes the pending update of the given key.
+func (t *ManaThrottler) flush(key manaThrottlerKey) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	params, pending := t.pending[key]
+	if !pending {
+		return
+	}
+	delete(t.pending, key)
+	delete(t.timers, key)
+
+	t.lastPush[key] = time.Now()
+	t.push(key.msgType, params...)
+}
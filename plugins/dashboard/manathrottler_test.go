@@ -0,0 +1,86 @@
+package dashboard
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManaThrottler(t *testing.T) {
+	const interval = 100 * time.Millisecond
+
+	var (
+		mutex  sync.Mutex
+		pushes []time.Time
+		last   interface{}
+	)
+	throttler := NewManaThrottler(interval, func(msgType byte, params ...interface{}) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		assert.Equal(t, MsgTypeManaPledge, msgType)
+		pushes = append(pushes, time.Now())
+		last = params[0]
+	})
+	defer throttler.Stop()
+	nodeID := identity.GenerateIdentity().ID()
+
+	// rapid updates over five intervals
+	start := time.Now()
+	for i := 0; time.Since(start) < 5*interval; i++ {
+		throttler.Update(MsgTypeManaPledge, nodeID, i)
+		time.Sleep(time.Millisecond)
+	}
+	throttler.Update(MsgTypeManaPledge, nodeID, -1)
+
+	// the latest update is pushed once the interval elapsed
+	assert.Eventually(t, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return last == -1
+	}, 2*interval, 10*time.Millisecond)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.LessOrEqual(t, len(pushes), 7)
+	for i := 1; i < len(pushes); i++ {
+		// allow for some timer inaccuracy
+		assert.GreaterOrEqual(t, int64(pushes[i].Sub(pushes[i-1])), int64(interval-5*time.Millisecond))
+	}
+}
+
+func TestManaThrottler_Nodes(t *testing.T) {
+	const interval = 100 * time.Millisecond
+
+	var (
+		mutex  sync.Mutex
+		pushed []interface{}
+	)
+	throttler := NewManaThrottler(interval, func(msgType byte, params ...interface{}) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		pushed = append(pushed, params[0])
+	})
+	defer throttler.Stop()
+	nodeA, nodeB := identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID()
+
+	// both nodes update twice within one interval
+	throttler.Update(MsgTypeManaPledge, nodeA, "A1")
+	throttler.Update(MsgTypeManaPledge, nodeB, "B1")
+	throttler.Update(MsgTypeManaPledge, nodeA, "A2")
+	throttler.Update(MsgTypeManaPledge, nodeB, "B2")
+
+	// the first updates are pushed right away and the latest update of each node once the interval elapsed
+	assert.Eventually(t, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return len(pushed) == 4
+	}, 3*interval, 10*time.Millisecond)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, []interface{}{"A1", "B1"}, pushed[:2])
+	assert.ElementsMatch(t, []interface{}{"A2", "B2"}, pushed[2:])
+}
@@ -1,6 +1,8 @@
 package dashboard
 
 import (
+	"time"
+
 	flag "github.com/spf13/pflag"
 )
 
@@ -15,6 +17,18 @@ const (
 	CfgBasicAuthUsername = "dashboard.basic_auth.username"
 	// CfgBasicAuthPassword defines the config flag of the dashboard basic auth password.
 	CfgBasicAuthPassword = "dashboard.basic_auth.password"
+	// CfgManaFeedMinInterval defines the config flag of the minimum interval between two mana feed updates of the same type.
+	CfgManaFeedMinInterval = "dashboard.mana_feed.min_interval"
+	// CfgWebSocketPingInterval defines the config flag of the interval in which ping frames are sent to the websocket clients.
+	CfgWebSocketPingInterval = "dashboard.websocket.ping_interval"
+	// CfgWebSocketPongTimeout defines the config flag of the time after which a websocket client which did not answer a ping is disconnected.
+	CfgWebSocketPongTimeout = "dashboard.websocket.pong_timeout"
+	// CfgWSWorkerCount defines the config flag of the number of workers sending the status updates to the websocket clients.
+	CfgWSWorkerCount = "dashboard.websocket.worker_count"
+	// CfgWSWorkerQueueSize defines the config flag of the number of status updates queued for the websocket clients.
+	CfgWSWorkerQueueSize = "dashboard.websocket.worker_queue_size"
+	// CfgLogRequests defines the config flag of the dashboard request logging enabler.
+	CfgLogRequests = "dashboard.log_requests"
 )
 
 func init() {
@@ -23,4 +37,10 @@ func init() {
 	flag.Bool(CfgBasicAuthEnabled, false, "whether to enable HTTP basic auth")
 	flag.String(CfgBasicAuthUsername, "goshimmer", "HTTP basic auth username")
 	flag.String(CfgBasicAuthPassword, "goshimmer", "HTTP basic auth password")
+	flag.Duration(CfgManaFeedMinInterval, time.Second, "the minimum interval between two mana feed updates of the same type")
+	flag.Duration(CfgWebSocketPingInterval, 30*time.Second, "the interval in which ping frames are sent to the websocket clients (0 disables the keepalive)")
+	flag.Duration(CfgWebSocketPongTimeout, 10*time.Second, "the time after which a websocket client which did not answer a ping is disconnected")
+	flag.Int(CfgWSWorkerCount, 1, "the number of workers sending the status updates to the websocket clients")
+	flag.Int(CfgWSWorkerQueueSize, 250, "the number of status updates queued for the websocket clients")
+	flag.Bool(CfgLogRequests, false, "whether to log the method, path, status, latency and client IP of the requests")
 }
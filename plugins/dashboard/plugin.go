@@ -20,6 +20,7 @@ import (
 	"github.com/labstack/echo/middleware"
 
 	"github.com/iotaledger/goshimmer/packages/shutdown"
+	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/plugins/autopeering"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
 	"github.com/iotaledger/goshimmer/plugins/banner"
@@ -69,6 +70,10 @@ func configureServer() {
 	server.HideBanner = true
 	server.HidePort = true
 	server.Use(middleware.Recover())
+	if config.Node().Bool(CfgLogRequests) {
+		// the websocket is excluded, as its upgrades are too frequent
+		server.Use(requestLogger(log.Infof, "/ws"))
+	}
 
 	if config.Node().Bool(CfgBasicAuthEnabled) {
 		server.Use(middleware.BasicAuth(func(username, password string, c echo.Context) (bool, error) {
@@ -114,6 +119,13 @@ func worker(shutdownSignal <-chan struct{}) {
 	metrics.Events.ReceivedMPSUpdated.Attach(notifyStatus)
 	defer metrics.Events.ReceivedMPSUpdated.Detach(notifyStatus)
 
+	// notify the clients when the synced state of the node changes
+	notifySyncChanged := events.NewClosure(func(ev *tangle.SyncChangedEvent) {
+		broadcastWsMessage(&wsmsg{MsgTypeSyncStatusChanged, &syncstatus{Synced: ev.Synced, Time: ev.Time.UnixNano()}})
+	})
+	messagelayer.Tangle().Events.SyncChanged.Attach(notifySyncChanged)
+	defer messagelayer.Tangle().Events.SyncChanged.Detach(notifySyncChanged)
+
 	stopped := make(chan struct{})
 	bindAddr := config.Node().String(CfgBindAddress)
 	go func() {
@@ -181,6 +193,10 @@ const (
 	MsgManaDashboardAddress
 	// MsgTypeMsgOpinionFormed defines a tip info message.
 	MsgTypeMsgOpinionFormed
+	// MsgTypeSyncStatusChanged defines a message that is sent when the synced state of the node changes.
+	MsgTypeSyncStatusChanged
+	// MsgTypeNeighborRequestStats is the type of the message containing the message request statistics of the neighbors.
+	MsgTypeNeighborRequestStats
 )
 
 type wsmsg struct {
@@ -195,12 +211,18 @@ type msg struct {
 }
 
 type nodestatus struct {
-	ID      string            `json:"id"`
-	Version string            `json:"version"`
-	Uptime  int64             `json:"uptime"`
-	Synced  bool              `json:"synced"`
-	Beacons map[string]Beacon `json:"beacons"`
-	Mem     *memmetrics       `json:"mem"`
+	ID                string            `json:"id"`
+	Version           string            `json:"version"`
+	Uptime            int64             `json:"uptime"`
+	Synced            bool              `json:"synced"`
+	Beacons           map[string]Beacon `json:"beacons"`
+	Mem               *memmetrics       `json:"mem"`
+	DuplicateMessages uint64            `json:"duplicate_messages"`
+}
+
+type syncstatus struct {
+	Synced bool  `json:"synced"`
+	Time   int64 `json:"time"`
 }
 
 // Beacon contains a sync beacons detailed status.
@@ -221,11 +243,25 @@ type memmetrics struct {
 }
 
 type neighbormetric struct {
-	ID               string `json:"id"`
-	Address          string `json:"address"`
-	ConnectionOrigin string `json:"connection_origin"`
-	BytesRead        uint64 `json:"bytes_read"`
-	BytesWritten     uint64 `json:"bytes_written"`
+	ID                string `json:"id"`
+	Address           string `json:"address"`
+	ConnectionOrigin  string `json:"connection_origin"`
+	BytesRead         uint64 `json:"bytes_read"`
+	BytesWritten      uint64 `json:"bytes_written"`
+	TipCount          int    `json:"tip_count"`
+	Synced            bool   `json:"synced"`
+	LastHeartbeat     int64  `json:"last_heartbeat"`
+	DuplicateMessages uint64 `json:"duplicate_messages"`
+}
+
+type neighborrequeststats struct {
+	ID                string  `json:"id"`
+	RequestsSent      uint64  `json:"requests_sent"`
+	RequestsReceived  uint64  `json:"requests_received"`
+	ResponsesSent     uint64  `json:"responses_sent"`
+	ResponsesReceived uint64  `json:"responses_received"`
+	ResponseRatio     float64 `json:"response_ratio"`
+	ServedRatio       float64 `json:"served_ratio"`
 }
 
 type componentsmetric struct {
@@ -256,12 +292,43 @@ func neighborMetrics() []neighbormetric {
 
 		host := neighbor.Peer.IP().String()
 		port := neighbor.Peer.Services().Get(service.GossipKey).Port()
-		stats = append(stats, neighbormetric{
-			ID:               neighbor.Peer.ID().String(),
-			Address:          net.JoinHostPort(host, strconv.Itoa(port)),
-			BytesRead:        neighbor.BytesRead(),
-			BytesWritten:     neighbor.BytesWritten(),
-			ConnectionOrigin: origin,
+		metric := neighbormetric{
+			ID:                neighbor.Peer.ID().String(),
+			Address:           net.JoinHostPort(host, strconv.Itoa(port)),
+			BytesRead:         neighbor.BytesRead(),
+			BytesWritten:      neighbor.BytesWritten(),
+			ConnectionOrigin:  origin,
+			DuplicateMessages: neighbor.DuplicateMessages(),
+		}
+		if heartbeat := neighbor.LastHeartbeat(); !heartbeat.Received.IsZero() {
+			metric.TipCount = heartbeat.TipCount
+			metric.Synced = heartbeat.Synced
+			metric.LastHeartbeat = heartbeat.Received.Unix()
+		}
+		stats = append(stats, metric)
+	}
+	return stats
+}
+
+func neighborRequestStats() []neighborrequeststats {
+	var stats []neighborrequeststats
+
+	// gossip plugin might be disabled
+	neighbors := gossip.Manager().AllNeighbors()
+	if neighbors == nil {
+		return stats
+	}
+
+	for _, neighbor := range neighbors {
+		s := neighbor.RequestStats()
+		stats = append(stats, neighborrequeststats{
+			ID:                neighbor.Peer.ID().String(),
+			RequestsSent:      s.RequestsSent,
+			RequestsReceived:  s.RequestsReceived,
+			ResponsesSent:     s.ResponsesSent,
+			ResponsesReceived: s.ResponsesReceived,
+			ResponseRatio:     s.ResponseRatio(),
+			ServedRatio:       s.ServedRatio(),
 		})
 	}
 	return stats
@@ -278,6 +345,7 @@ func currentNodeStatus() *nodestatus {
 	// node status
 	status.Version = banner.AppVersion
 	status.Uptime = time.Since(nodeStartAt).Milliseconds()
+	status.DuplicateMessages = gossip.Manager().DuplicateMessages()
 
 	var beacons map[ed25519.PublicKey]messagelayer.Status
 	status.Synced, beacons = messagelayer.SyncStatus()
@@ -0,0 +1,35 @@
+package dashboard
+
+import (
+	"time"
+
+	"github.com/labstack/echo"
+)
+
+// requestLogger returns a middleware which logs the method, path, status, latency and client IP of every request with
+// the given log function. Requests to the skipped paths, e.g. the frequent websocket upgrades, are not logged.
+func requestLogger(logf func(template string, args ...interface{}), skippedPaths ...string) echo.MiddlewareFunc {
+	skipped := make(map[string]struct{}, len(skippedPaths))
+	for _, path := range skippedPaths {
+		skipped[path] = struct{}{}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if _, skip := skipped[req.URL.Path]; skip {
+				return next(c)
+			}
+
+			start := time.Now()
+			err := next(c)
+			if err != nil {
+				// let the error handler write the response so that its status is logged
+				c.Error(err)
+			}
+			logf("request: method=%s path=%s status=%d latency=%s ip=%s",
+				req.Method, req.URL.Path, c.Response().Status, time.Since(start), c.RealIP())
+			return nil
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package dashboard
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestLogger(t *testing.T) {
+	var lines []string
+	e := echo.New()
+	e.Use(requestLogger(func(template string, args ...interface{}) {
+		lines = append(lines, fmt.Sprintf(template, args...))
+	}, "/ws"))
+	e.GET("/api/info", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.GET("/ws", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	serve := func(method, path string) {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		e.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	serve(http.MethodGet, "/api/info")
+	require.Len(t, lines, 1)
+	assert.Regexp(t, `^request: method=GET path=/api/info status=200 latency=\S+ ip=192\.0\.2\.1$`, lines[0])
+
+	// the status of failed requests is logged
+	serve(http.MethodPost, "/unknown")
+	require.Len(t, lines, 2)
+	assert.Regexp(t, `^request: method=POST path=/unknown status=404 latency=\S+ ip=192\.0\.2\.1$`, lines[1])
+
+	// the skipped paths are not logged
+	serve(http.MethodGet, "/ws")
+	assert.Len(t, lines, 2)
+}
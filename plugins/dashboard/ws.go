@@ -1,27 +1,35 @@
 package dashboard
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/workerpool"
 	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
 
+	"github.com/iotaledger/goshimmer/packages/mana"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
+	"github.com/iotaledger/goshimmer/plugins/config"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
 	"github.com/iotaledger/goshimmer/plugins/metrics"
 )
 
 var (
 	// settings
-	wsSendWorkerCount     = 1
-	wsSendWorkerQueueSize = 250
 	wsSendWorkerPool      *workerpool.WorkerPool
 	webSocketWriteTimeout = time.Duration(3) * time.Second
+	wsPingInterval        time.Duration
+	wsPongTimeout         time.Duration
 
 	// clients
 	wsClientsMu    sync.RWMutex
@@ -42,21 +50,131 @@ type wsclient struct {
 	channel chan interface{}
 	// a channel which is closed when the websocket client is disconnected.
 	exit chan struct{}
+	// the node IDs the client subscribed to for mana updates, nil subscribes to all nodes.
+	manaNodeIDs      map[identity.ID]struct{}
+	manaNodeIDsMutex sync.RWMutex
+	// the issuer public keys the client subscribed to for the live feed, nil subscribes to all issuers.
+	issuers      map[ed25519.PublicKey]struct{}
+	issuersMutex sync.RWMutex
+	// keeps the connection alive, nil if the client has no connection.
+	keepalive *wsKeepalive
+}
+
+// wsControlFrameSubscribe is the type of the control frame with which a client subscribes to the mana updates
+// of the given node IDs and to the live feed messages of the given issuers. A filter which is omitted from the frame
+// is left unchanged, so that each filter can be updated on its own.
+const wsControlFrameSubscribe = "subscribe"
+
+// a control frame sent by a websocket client.
+type wsControlFrame struct {
+	Type string `json:"type"`
+	// the base58 encoded full node IDs to receive mana updates for. Empty subscribes to all nodes.
+	ManaNodeIDs *[]string `json:"manaNodeIDs"`
+	// the base58 encoded issuer public keys to receive live feed messages for. Empty subscribes to all issuers.
+	Issuers *[]string `json:"issuers"`
+}
+
+// subscribes the client to the mana updates of the given base58 encoded node IDs. Invalid node IDs are ignored and
+// if no valid node ID is given, the client is subscribed to all nodes.
+func (c *wsclient) subscribeMana(nodeIDs []string) {
+	var filter map[identity.ID]struct{}
+	for _, nodeIDStr := range nodeIDs {
+		nodeID, err := mana.IDFromStr(nodeIDStr)
+		if err != nil {
+			continue
+		}
+		if filter == nil {
+			filter = make(map[identity.ID]struct{})
+		}
+		filter[nodeID] = struct{}{}
+	}
+
+	c.manaNodeIDsMutex.Lock()
+	defer c.manaNodeIDsMutex.Unlock()
+	c.manaNodeIDs = filter
+}
+
+// tells whether the client subscribed to the mana updates of the given node.
+func (c *wsclient) subscribedToMana(nodeID identity.ID) bool {
+	c.manaNodeIDsMutex.RLock()
+	defer c.manaNodeIDsMutex.RUnlock()
+	if c.manaNodeIDs == nil {
+		return true
+	}
+	_, subscribed := c.manaNodeIDs[nodeID]
+	return subscribed
+}
+
+// subscribes the client to the live feed messages of the given base58 encoded issuer public keys. Invalid public
+// keys are ignored and if no valid public key is given, the client is subscribed to all issuers.
+func (c *wsclient) subscribeIssuers(publicKeys []string) {
+	var filter map[ed25519.PublicKey]struct{}
+	for _, publicKeyStr := range publicKeys {
+		bytes, err := base58.Decode(publicKeyStr)
+		if err != nil {
+			continue
+		}
+		publicKey, _, err := ed25519.PublicKeyFromBytes(bytes)
+		if err != nil {
+			continue
+		}
+		if filter == nil {
+			filter = make(map[ed25519.PublicKey]struct{})
+		}
+		filter[publicKey] = struct{}{}
+	}
+
+	c.issuersMutex.Lock()
+	defer c.issuersMutex.Unlock()
+	c.issuers = filter
+}
+
+// tells whether the client subscribed to the live feed messages of the given issuer.
+func (c *wsclient) subscribedToIssuer(issuer ed25519.PublicKey) bool {
+	c.issuersMutex.RLock()
+	defer c.issuersMutex.RUnlock()
+	if c.issuers == nil {
+		return true
+	}
+	_, subscribed := c.issuers[issuer]
+	return subscribed
 }
 
 func configureWebSocketWorkerPool() {
-	wsSendWorkerPool = workerpool.New(func(task workerpool.Task) {
+	wsPingInterval = config.Node().Duration(CfgWebSocketPingInterval)
+	wsPongTimeout = config.Node().Duration(CfgWebSocketPongTimeout)
+
+	var err error
+	wsSendWorkerPool, err = newWebSocketWorkerPool(config.Node().Int(CfgWSWorkerCount), config.Node().Int(CfgWSWorkerQueueSize))
+	if err != nil {
+		log.Fatalf("invalid websocket worker pool config: %s", err)
+	}
+}
+
+// newWebSocketWorkerPool creates the worker pool sending the status updates to the websocket clients.
+func newWebSocketWorkerPool(workerCount, queueSize int) (*workerpool.WorkerPool, error) {
+	if workerCount <= 0 {
+		return nil, fmt.Errorf("%s must be positive, got %d", CfgWSWorkerCount, workerCount)
+	}
+	if queueSize <= 0 {
+		return nil, fmt.Errorf("%s must be positive, got %d", CfgWSWorkerQueueSize, queueSize)
+	}
+
+	return workerpool.New(func(task workerpool.Task) {
 		switch x := task.Param(0).(type) {
+		case time.Time:
+			pingWsClients(x)
 		case uint64:
 			broadcastWsMessage(&wsmsg{MsgTypeMPSMetric, x})
 			broadcastWsMessage(&wsmsg{MsgTypeNodeStatus, currentNodeStatus()})
 			broadcastWsMessage(&wsmsg{MsgTypeNeighborMetric, neighborMetrics()})
+			broadcastWsMessage(&wsmsg{MsgTypeNeighborRequestStats, neighborRequestStats()})
 			broadcastWsMessage(&wsmsg{MsgTypeTipsMetric, messagelayer.Tangle().TipManager.StrongTipCount()})
 		case *componentsmetric:
 			broadcastWsMessage(&wsmsg{MsgTypeComponentCounterMetric, x})
 		}
 		task.Return(nil)
-	}, workerpool.WorkerCount(wsSendWorkerCount), workerpool.QueueSize(wsSendWorkerQueueSize))
+	}, workerpool.WorkerCount(workerCount), workerpool.QueueSize(queueSize)), nil
 }
 
 func runWebSocketStreams() {
@@ -77,7 +195,24 @@ func runWebSocketStreams() {
 		metrics.Events.ReceivedMPSUpdated.Attach(updateStatus)
 		metrics.Events.ComponentCounterUpdated.Attach(updateComponentCounterStatus)
 		wsSendWorkerPool.Start()
-		<-shutdownSignal
+
+		// periodically ping the websocket clients, a nil channel never fires if the keepalive is disabled
+		var pingTicks <-chan time.Time
+		if wsPingInterval > 0 {
+			pingTicker := time.NewTicker(wsPingInterval)
+			defer pingTicker.Stop()
+			pingTicks = pingTicker.C
+		}
+	loop:
+		for {
+			select {
+			case now := <-pingTicks:
+				wsSendWorkerPool.TrySubmit(now)
+			case <-shutdownSignal:
+				break loop
+			}
+		}
+
 		log.Info("Stopping Dashboard[StatusUpdate] ...")
 		metrics.Events.ReceivedMPSUpdated.Detach(updateStatus)
 		wsSendWorkerPool.Stop()
@@ -87,8 +222,17 @@ func runWebSocketStreams() {
 	}
 }
 
-// reigsters and creates a new websocket client.
-func registerWSClient() (uint64, *wsclient) {
+// wsKeepaliveTimeout returns the time within which a client must answer the next ping, i.e. the ping interval plus
+// the pong timeout. It returns zero if the keepalive is disabled.
+func wsKeepaliveTimeout() time.Duration {
+	if wsPingInterval <= 0 {
+		return 0
+	}
+	return wsPingInterval + wsPongTimeout
+}
+
+// reigsters and creates a new websocket client. The connection is kept alive by pinging it, if it is not nil.
+func registerWSClient(conn wsPingConn) (uint64, *wsclient) {
 	wsClientsMu.Lock()
 	defer wsClientsMu.Unlock()
 	clientID := nextWsClientID
@@ -96,6 +240,9 @@ func registerWSClient() (uint64, *wsclient) {
 		channel: make(chan interface{}, 2000),
 		exit:    make(chan struct{}),
 	}
+	if conn != nil {
+		wsClient.keepalive = newWsKeepalive(conn, wsKeepaliveTimeout())
+	}
 	wsClients[clientID] = wsClient
 	nextWsClientID++
 	return clientID, wsClient
@@ -135,6 +282,75 @@ func broadcastWsMessage(msg interface{}, dontDrop ...bool) {
 	}
 }
 
+// broadcasts the given mana update of the given node to all websocket clients which subscribed to it.
+func broadcastManaWsMessage(msg interface{}, nodeID identity.ID) {
+	wsClientsMu.RLock()
+	defer wsClientsMu.RUnlock()
+	for _, wsClient := range wsClients {
+		if !wsClient.subscribedToMana(nodeID) {
+			continue
+		}
+		select {
+		case wsClient.channel <- msg:
+		default:
+			// potentially drop if slow consumer
+		}
+	}
+}
+
+// broadcasts the given live feed message of the given issuer to all websocket clients which subscribed to it.
+func broadcastLiveFeedWsMessage(msg interface{}, issuer ed25519.PublicKey) {
+	wsClientsMu.RLock()
+	defer wsClientsMu.RUnlock()
+	for _, wsClient := range wsClients {
+		if !wsClient.subscribedToIssuer(issuer) {
+			continue
+		}
+		select {
+		case wsClient.channel <- msg:
+		default:
+			// potentially drop if slow consumer
+		}
+	}
+}
+
+// tells whether any websocket client subscribed to the mana updates of the given node.
+func manaSubscribed(nodeID identity.ID) bool {
+	wsClientsMu.RLock()
+	defer wsClientsMu.RUnlock()
+	for _, wsClient := range wsClients {
+		if wsClient.subscribedToMana(nodeID) {
+			return true
+		}
+	}
+	return false
+}
+
+// reads the control frames sent by the websocket client until the connection is closed.
+func readControlFrames(ws *websocket.Conn, wsClient *wsclient) {
+	for {
+		var frame wsControlFrame
+		if err := ws.ReadJSON(&frame); err != nil {
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+				// ignore malformed frames
+				continue
+			}
+			return
+		}
+		if frame.Type != wsControlFrameSubscribe {
+			continue
+		}
+		if frame.ManaNodeIDs != nil {
+			wsClient.subscribeMana(*frame.ManaNodeIDs)
+		}
+		if frame.Issuers != nil {
+			wsClient.subscribeIssuers(*frame.Issuers)
+		}
+	}
+}
+
 func sendInitialData(ws *websocket.Conn) error {
 	if err := sendAllowedManaPledge(ws); err != nil {
 		return err
@@ -170,8 +386,12 @@ func websocketRoute(c echo.Context) error {
 	ws.EnableWriteCompression(true)
 
 	// cleanup client websocket
-	clientID, wsClient := registerWSClient()
+	clientID, wsClient := registerWSClient(ws)
 	defer removeWsClient(clientID)
+	ws.SetPongHandler(func(string) error {
+		wsClient.keepalive.pong()
+		return nil
+	})
 
 	// send initial data to the connected client
 	err = sendInitialData(ws)
@@ -179,8 +399,20 @@ func websocketRoute(c echo.Context) error {
 		return err
 	}
 
+	// handle the subscriptions of the client, reading stops once the connection is closed
+	readDone := make(chan struct{})
+	go func() {
+		defer close(readDone)
+		readControlFrames(ws, wsClient)
+	}()
+
 	for {
-		msg := <-wsClient.channel
+		var msg interface{}
+		select {
+		case msg = <-wsClient.channel:
+		case <-readDone:
+			return nil
+		}
 		if err := ws.WriteJSON(msg); err != nil {
 			break
 		}
@@ -0,0 +1,231 @@
+package dashboard
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcastManaWsMessage(t *testing.T) {
+	subscribedID := identity.GenerateIdentity().ID()
+	otherID := identity.GenerateIdentity().ID()
+
+	filteredClientID, filteredClient := registerWSClient(nil)
+	defer removeWsClient(filteredClientID)
+	unfilteredClientID, unfilteredClient := registerWSClient(nil)
+	defer removeWsClient(unfilteredClientID)
+
+	// invalid node IDs are ignored
+	filteredClient.subscribeMana([]string{base58.Encode(subscribedID.Bytes()), "invalid"})
+	unfilteredClient.subscribeMana([]string{"invalid"})
+
+	broadcastManaWsMessage(subscribedID, subscribedID)
+	broadcastManaWsMessage(otherID, otherID)
+
+	assert.Equal(t, []interface{}{subscribedID}, drainWsClient(filteredClient))
+	assert.Equal(t, []interface{}{subscribedID, otherID}, drainWsClient(unfilteredClient))
+
+	// an empty subscription subscribes to all nodes again
+	filteredClient.subscribeMana(nil)
+	broadcastManaWsMessage(otherID, otherID)
+	assert.Equal(t, []interface{}{otherID}, drainWsClient(filteredClient))
+}
+
+func TestManaSubscribed(t *testing.T) {
+	subscribedID := identity.GenerateIdentity().ID()
+
+	clientID, client := registerWSClient(nil)
+	defer removeWsClient(clientID)
+
+	client.subscribeMana([]string{base58.Encode(subscribedID.Bytes())})
+	assert.True(t, manaSubscribed(subscribedID))
+	assert.False(t, manaSubscribed(identity.GenerateIdentity().ID()))
+}
+
+func TestBroadcastLiveFeedWsMessage(t *testing.T) {
+	targetIssuer := ed25519.GenerateKeyPair().PublicKey
+	otherIssuer := ed25519.GenerateKeyPair().PublicKey
+
+	filteredClientID, filteredClient := registerWSClient(nil)
+	defer removeWsClient(filteredClientID)
+	unfilteredClientID, unfilteredClient := registerWSClient(nil)
+	defer removeWsClient(unfilteredClientID)
+
+	// invalid public keys are ignored
+	filteredClient.subscribeIssuers([]string{base58.Encode(targetIssuer.Bytes()), "invalid"})
+	unfilteredClient.subscribeIssuers([]string{"invalid"})
+
+	broadcastLiveFeedWsMessage("target", targetIssuer)
+	broadcastLiveFeedWsMessage("other", otherIssuer)
+
+	assert.Equal(t, []interface{}{"target"}, drainWsClient(filteredClient))
+	assert.Equal(t, []interface{}{"target", "other"}, drainWsClient(unfilteredClient))
+
+	// an empty subscription subscribes to all issuers again
+	filteredClient.subscribeIssuers(nil)
+	broadcastLiveFeedWsMessage("other", otherIssuer)
+	assert.Equal(t, []interface{}{"other"}, drainWsClient(filteredClient))
+}
+
+func TestReadControlFrames(t *testing.T) {
+	subscribedID := identity.GenerateIdentity().ID()
+	targetIssuer := ed25519.GenerateKeyPair().PublicKey
+
+	clients := make(chan *wsclient, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer ws.Close()
+
+		clientID, wsClient := registerWSClient(nil)
+		defer removeWsClient(clientID)
+		readControlFrames(ws, wsClient)
+		clients <- wsClient
+	}))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	// each frame only updates the filter it contains
+	require.NoError(t, conn.WriteJSON(map[string]interface{}{
+		"type":        wsControlFrameSubscribe,
+		"manaNodeIDs": []string{base58.Encode(subscribedID.Bytes())},
+	}))
+	require.NoError(t, conn.WriteJSON(map[string]interface{}{
+		"type":    wsControlFrameSubscribe,
+		"issuers": []string{base58.Encode(targetIssuer.Bytes())},
+	}))
+	require.NoError(t, conn.Close())
+
+	var wsClient *wsclient
+	select {
+	case wsClient = <-clients:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "control frames were not read")
+	}
+	assert.True(t, wsClient.subscribedToMana(subscribedID))
+	assert.False(t, wsClient.subscribedToMana(identity.GenerateIdentity().ID()))
+	assert.True(t, wsClient.subscribedToIssuer(targetIssuer))
+	assert.False(t, wsClient.subscribedToIssuer(ed25519.GenerateKeyPair().PublicKey))
+}
+
+func TestPingWsClients(t *testing.T) {
+	wsPongTimeout = 10 * time.Second
+
+	responsiveConn, unresponsiveConn := &fakeWsConn{}, &fakeWsConn{}
+	responsiveClientID, responsiveClient := registerWSClient(responsiveConn)
+	defer removeWsClient(responsiveClientID)
+	unresponsiveClientID, _ := registerWSClient(unresponsiveConn)
+	defer removeWsClient(unresponsiveClientID)
+
+	start := time.Now()
+	pingWsClients(start)
+	responsiveClient.keepalive.pong()
+	assert.Equal(t, 1, responsiveConn.pings)
+	assert.Equal(t, 1, unresponsiveConn.pings)
+
+	// the unresponsive connection is pinged again until the pong timeout is reached
+	pingWsClients(start.Add(5 * time.Second))
+	responsiveClient.keepalive.pong()
+	assert.Equal(t, 2, unresponsiveConn.pings)
+	assert.False(t, unresponsiveConn.closed)
+
+	pingWsClients(start.Add(10 * time.Second))
+	assert.True(t, unresponsiveConn.closed)
+	assert.Equal(t, 2, unresponsiveConn.pings)
+	assert.False(t, responsiveConn.closed)
+	assert.Equal(t, 3, responsiveConn.pings)
+}
+
+// fakeWsConn counts the ping frames written to it.
+type fakeWsConn struct {
+	pings        int
+	closed       bool
+	readDeadline time.Time
+}
+
+func (c *fakeWsConn) WriteControl(messageType int, _ []byte, _ time.Time) error {
+	if messageType == websocket.PingMessage {
+		c.pings++
+	}
+	return nil
+}
+
+func (c *fakeWsConn) SetReadDeadline(t time.Time) error {
+	c.readDeadline = t
+	return nil
+}
+
+func (c *fakeWsConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestWsKeepaliveReadDeadline(t *testing.T) {
+	wsPingInterval, wsPongTimeout = 30*time.Second, 10*time.Second
+
+	conn := &fakeWsConn{}
+	before := time.Now()
+	clientID, client := registerWSClient(conn)
+	defer removeWsClient(clientID)
+
+	// the client must answer the first ping, which is sent after one ping interval, within the pong timeout
+	assert.False(t, conn.readDeadline.Before(before.Add(40*time.Second)))
+	assert.False(t, conn.readDeadline.After(time.Now().Add(40*time.Second)))
+
+	// every pong extends the read deadline
+	conn.readDeadline = time.Time{}
+	client.keepalive.pong()
+	assert.False(t, conn.readDeadline.IsZero())
+
+	// without pings, there is no read deadline
+	wsPingInterval = 0
+	disabledConn := &fakeWsConn{}
+	disabledClientID, disabledClient := registerWSClient(disabledConn)
+	defer removeWsClient(disabledClientID)
+	disabledClient.keepalive.pong()
+	assert.True(t, disabledConn.readDeadline.IsZero())
+}
+
+func drainWsClient(wsClient *wsclient) (msgs []interface{}) {
+	for {
+		select {
+		case msg := <-wsClient.channel:
+			msgs = append(msgs, msg)
+		default:
+			return msgs
+		}
+	}
+}
+
+func TestNewWebSocketWorkerPool(t *testing.T) {
+	const workerCount, queueSize = 3, 5
+	pool, err := newWebSocketWorkerPool(workerCount, queueSize)
+	require.NoError(t, err)
+	assert.Equal(t, workerCount, pool.GetWorkerCount())
+
+	// the pool is not started, so the queue fills up to its configured size
+	for i := 0; i < queueSize; i++ {
+		_, added := pool.TrySubmit(&componentsmetric{})
+		assert.True(t, added)
+	}
+	_, added := pool.TrySubmit(&componentsmetric{})
+	assert.False(t, added)
+	assert.Equal(t, queueSize, pool.GetPendingQueueSize())
+
+	for _, dimensions := range [][2]int{{0, queueSize}, {workerCount, 0}, {-1, -1}} {
+		_, err := newWebSocketWorkerPool(dimensions[0], dimensions[1])
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,85 @@
+package dashboard
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// wsPingConn is the part of a websocket connection needed to keep it alive.
+type wsPingConn interface {
+	WriteControl(messageType int, data []byte, deadline time.Time) error
+	SetReadDeadline(t time.Time) error
+	Close() error
+}
+
+// keeps a websocket connection alive by pinging it and closes it if the pings stay unanswered.
+type wsKeepalive struct {
+	conn wsPingConn
+	// the time within which the next pong must be read, zero disables the read deadline.
+	timeout time.Duration
+
+	mu sync.Mutex
+	// the time of the oldest unanswered ping, zero if all pings were answered.
+	pendingPing time.Time
+	closed      bool
+}
+
+// newWsKeepalive creates a keepalive for the given connection. If timeout is positive, reading from the connection
+// fails once no pong was received for that long, so that a vanished client does not block its reader forever.
+func newWsKeepalive(conn wsPingConn, timeout time.Duration) *wsKeepalive {
+	k := &wsKeepalive{conn: conn, timeout: timeout}
+	k.refreshReadDeadline()
+	return k
+}
+
+// pong marks all sent pings as answered and extends the read deadline.
+func (k *wsKeepalive) pong() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.pendingPing = time.Time{}
+	k.refreshReadDeadline()
+}
+
+// refreshReadDeadline moves the read deadline of the connection timeout into the future.
+func (k *wsKeepalive) refreshReadDeadline() {
+	if k.timeout > 0 {
+		_ = k.conn.SetReadDeadline(time.Now().Add(k.timeout))
+	}
+}
+
+// ping sends a ping frame to the client. If a previous ping stayed unanswered for pongTimeout, the connection is closed
+// instead and false is returned.
+func (k *wsKeepalive) ping(now time.Time, pongTimeout time.Duration) bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.closed {
+		return false
+	}
+	if !k.pendingPing.IsZero() && now.Sub(k.pendingPing) >= pongTimeout {
+		k.closed = true
+		_ = k.conn.Close()
+		return false
+	}
+	// a failed ping is treated like an unanswered one, i.e. the connection is closed once the pong timeout is reached
+	_ = k.conn.WriteControl(websocket.PingMessage, nil, now.Add(webSocketWriteTimeout))
+	if k.pendingPing.IsZero() {
+		k.pendingPing = now
+	}
+	return true
+}
+
+// pings all connected websocket clients and closes the connections of the ones which did not answer in time.
+func pingWsClients(now time.Time) {
+	wsClientsMu.RLock()
+	defer wsClientsMu.RUnlock()
+	for _, wsClient := range wsClients {
+		if wsClient.keepalive == nil {
+			continue
+		}
+		wsClient.keepalive.ping(now, wsPongTimeout)
+	}
+}
@@ -48,6 +48,8 @@ const (
 	CfgFaucetPreparedOutputsCount = "faucet.preparedOutputsCounts"
 	// CfgFaucetStartIndex defines from which address index the faucet should start gathering outputs.
 	CfgFaucetStartIndex = "faucet.startIndex"
+	// CfgFaucetGenesisTokenAmount defines the amount of tokens the remainder address of the faucet's seed held initially.
+	CfgFaucetGenesisTokenAmount = "faucet.genesisTokenAmount"
 )
 
 func init() {
@@ -58,6 +60,7 @@ func init() {
 	flag.Int(CfgFaucetBlacklistCapacity, 10000, "holds the maximum amount the address blacklist holds")
 	flag.Int(CfgFaucetPreparedOutputsCount, 126, "number of outputs the faucet prepares")
 	flag.Int(CfgFaucetStartIndex, 0, "address index to start faucet with")
+	flag.Int64(CfgFaucetGenesisTokenAmount, GenesisTokenAmount, "the amount of tokens the remainder address of the faucet held initially")
 }
 
 var (
@@ -112,11 +115,16 @@ func Faucet() *StateManager {
 		if preparedOutputsCount <= 0 {
 			log.Fatalf("the number of faucet prepared outputs should be more than 0")
 		}
+		genesisTokenAmount := config.Node().Int64(CfgFaucetGenesisTokenAmount)
+		if genesisTokenAmount < MinimumFaucetBalance {
+			log.Fatalf("the genesis token amount of the faucet must be at least %d", int64(MinimumFaucetBalance))
+		}
 		_faucet = NewStateManager(
 			uint64(tokensPerRequest),
 			walletseed.NewSeed(seedBytes),
 			uint64(preparedOutputsCount),
 			time.Duration(maxTxBookedAwaitTime)*time.Second,
+			uint64(genesisTokenAmount),
 		)
 	})
 	return _faucet
@@ -65,6 +65,8 @@ type StateManager struct {
 
 	// the amount of tokens to send to every request
 	tokensPerRequest uint64
+	// the amount of tokens the remainder address of the seed held initially
+	genesisTokenAmount uint64
 	// number of funding outputs to prepare if fundingOutputs is exhausted
 	preparedOutputsCount uint64
 	// the seed instance of the faucet holding the tokens
@@ -84,6 +86,7 @@ func NewStateManager(
 	seed *walletseed.Seed,
 	preparedOutputsCount uint64,
 	maxTxBookedTime time.Duration,
+	genesisTokenAmount uint64,
 ) *StateManager {
 	// currently the max number of outputs in a tx is 127, therefore, when creating the splitting tx, we can have at most
 	// 126 prepared outputs (+1 remainder output).
@@ -101,6 +104,7 @@ func NewStateManager(
 		preparedOutputsCount: preparedOutputsCount,
 		seed:                 seed,
 		maxTxBookedAwaitTime: maxTxBookedTime,
+		genesisTokenAmount:   genesisTokenAmount,
 	}
 
 	return res
@@ -152,7 +156,7 @@ func (s *StateManager) DeriveStateFromTangle(startIndex int) (err error) {
 		return xerrors.Errorf("can't find an output on address %s that has at least %d tokens", remainderAddress.Base58(), int(MinimumFaucetBalance))
 	}
 
-	endIndex := (GenesisTokenAmount - foundRemainderOutput.Balance) / s.tokensPerRequest
+	endIndex := (s.genesisTokenAmount - foundRemainderOutput.Balance) / s.tokensPerRequest
 	log.Infof("%d indices have already been used based on found remainder output", endIndex)
 
 	log.Infof("Looking for prepared outputs in the Tangle...")
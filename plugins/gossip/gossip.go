@@ -2,15 +2,19 @@ package gossip
 
 import (
 	"errors"
+	"fmt"
 	"net"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/netutil"
 	"github.com/iotaledger/hive.go/types"
 
+	"github.com/iotaledger/goshimmer/packages/clock"
 	"github.com/iotaledger/goshimmer/packages/gossip"
 	"github.com/iotaledger/goshimmer/packages/gossip/server"
 	"github.com/iotaledger/goshimmer/packages/tangle"
@@ -48,7 +52,64 @@ func createManager() {
 	if err := lPeer.UpdateService(service.GossipKey, "tcp", gossipPort); err != nil {
 		log.Fatalf("could not update services: %s", err)
 	}
-	mgr = gossip.NewManager(lPeer, loadMessage, log)
+
+	allowedPeers, err := parsePeerIDs(config.Node().Strings(CfgGossipAllowedPeers))
+	if err != nil {
+		log.Fatalf("Invalid %s: %s", CfgGossipAllowedPeers, err)
+	}
+	deniedPeers, err := parsePeerIDs(config.Node().Strings(CfgGossipDeniedPeers))
+	if err != nil {
+		log.Fatalf("Invalid %s: %s", CfgGossipDeniedPeers, err)
+	}
+
+	// the tangle.Requester re-triggers the requests of missing messages, so each escalation of the manager has to be
+	// given up before the next trigger, otherwise the trigger is ignored and the message is requested less often
+	requestRetryInterval := config.Node().Duration(CfgGossipRequestRetryInterval)
+	requestMaxAttempts := config.Node().Int(CfgGossipRequestMaxAttempts)
+	if requestRetryInterval*time.Duration(requestMaxAttempts) > tangle.DefaultRetryInterval {
+		log.Fatalf("%s times %s must not exceed the retry interval of the message requester (%s)",
+			CfgGossipRequestRetryInterval, CfgGossipRequestMaxAttempts, tangle.DefaultRetryInterval)
+	}
+
+	mgr = gossip.NewManager(lPeer, loadMessage, log,
+		gossip.RequestRetryInterval(requestRetryInterval),
+		gossip.RequestMaxAttempts(requestMaxAttempts),
+		gossip.RequestFanout(config.Node().Int(CfgGossipRequestFanout)),
+		gossip.MaxOutstandingRequests(config.Node().Int(CfgGossipRequestMaxOutstanding)),
+		gossip.InboundManaPrioritization(config.Node().Int(CfgGossipMaxInboundNeighbors), consensusMana),
+		gossip.Heartbeats(config.Node().Duration(CfgGossipHeartbeatInterval), heartbeatStatus),
+		gossip.PeerFilter(allowedPeers, deniedPeers),
+		gossip.FairSending(config.Node().Bool(CfgGossipFairSending)),
+		gossip.NeighborBandwidthLimit(config.Node().Int(CfgGossipBandwidthLimit), config.Node().Int(CfgGossipBandwidthBurst)),
+		gossip.MessageExists(messageExists),
+	)
+}
+
+// parses the given base58 encoded peer IDs.
+func parsePeerIDs(encodedIDs []string) ([]identity.ID, error) {
+	ids := make([]identity.ID, 0, len(encodedIDs))
+	for _, encodedID := range encodedIDs {
+		id, err := identity.ParseID(encodedID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse peer ID %s: %w", encodedID, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// returns the tip count and sync state of the node, which are announced to the neighbors in the heartbeats.
+func heartbeatStatus() (tipCount int, synced bool) {
+	return messagelayer.Tangle().TipManager.StrongTipCount(), messagelayer.Tangle().Synced()
+}
+
+// returns the consensus mana of the given node, nodes without mana are treated as having zero mana.
+func consensusMana(nodeID identity.ID) float64 {
+	consensusMana, _, err := messagelayer.GetConsensusMana(nodeID)
+	if err != nil {
+		return 0
+	}
+	return consensusMana
 }
 
 func start(shutdownSignal <-chan struct{}) {
@@ -72,9 +133,15 @@ func start(shutdownSignal <-chan struct{}) {
 	}
 	defer listener.Close()
 
-	srv := server.ServeTCP(lPeer, listener, log)
+	srvOpts := []server.Option{server.Checksum(config.Node().Bool(CfgGossipChecksum))}
+	if config.Node().Bool(CfgGossipCompression) {
+		srvOpts = append(srvOpts, server.Compression(server.CompressionGzip))
+	}
+	srv := server.ServeTCP(lPeer, listener, log, srvOpts...)
 	defer srv.Close()
 
+	// assure that the autopeering selection is always stopped before the gossip manager
+	mgr.OnBeforeClose(func() { autopeering.Selection().Close() })
 	mgr.Start(srv)
 	defer mgr.Close()
 
@@ -85,19 +152,32 @@ func start(shutdownSignal <-chan struct{}) {
 
 	<-shutdownSignal
 	log.Info("Stopping " + PluginName + " ...")
-
-	// assure that the autopeering selection is always stopped before the gossip manager
-	autopeering.Selection().Close()
 }
 
 // loads the given message from the message layer and returns it or an error if not found.
 func loadMessage(msgID tangle.MessageID) ([]byte, error) {
+	return loadMessageFromStorage(messagelayer.Tangle().Storage, msgID, maxMessageAge, clock.SyncedTime())
+}
+
+// returns whether the given message is stored, regardless of its age.
+func messageExists(msgID tangle.MessageID) bool {
 	cachedMessage := messagelayer.Tangle().Storage.Message(msgID)
 	defer cachedMessage.Release()
+	return cachedMessage.Exists()
+}
+
+// loads the given message from the storage. Messages issued more than maxAge before now are treated as not found, so
+// that history already pruned by other nodes is not reintroduced. A maxAge of zero disables the check.
+func loadMessageFromStorage(storage *tangle.Storage, msgID tangle.MessageID, maxAge time.Duration, now time.Time) ([]byte, error) {
+	cachedMessage := storage.Message(msgID)
+	defer cachedMessage.Release()
 	if !cachedMessage.Exists() {
 		return nil, ErrMessageNotFound
 	}
 	msg := cachedMessage.Unwrap()
+	if maxAge > 0 && now.Sub(msg.IssuingTime()) > maxAge {
+		return nil, ErrMessageNotFound
+	}
 	return msg.Bytes(), nil
 }
 
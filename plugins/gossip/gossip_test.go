@@ -0,0 +1,42 @@
+package gossip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+func TestLoadMessageMaxAge(t *testing.T) {
+	testTangle := tangle.New()
+	defer testTangle.Shutdown()
+
+	now := time.Now()
+	newMessage := func(issuingTime time.Time) *tangle.Message {
+		return tangle.NewMessage([]tangle.MessageID{tangle.EmptyMessageID}, []tangle.MessageID{}, issuingTime, ed25519.PublicKey{}, 0, payload.NewGenericDataPayload([]byte("test")), 0, ed25519.Signature{})
+	}
+	freshMsg := newMessage(now.Add(-time.Second))
+	agedMsg := newMessage(now.Add(-time.Hour))
+	testTangle.Storage.StoreMessage(freshMsg)
+	testTangle.Storage.StoreMessage(agedMsg)
+
+	msgBytes, err := loadMessageFromStorage(testTangle.Storage, freshMsg.ID(), time.Minute, now)
+	require.NoError(t, err)
+	assert.Equal(t, freshMsg.Bytes(), msgBytes)
+
+	_, err = loadMessageFromStorage(testTangle.Storage, agedMsg.ID(), time.Minute, now)
+	assert.Equal(t, ErrMessageNotFound, err)
+
+	// without a max age, all stored messages are served
+	msgBytes, err = loadMessageFromStorage(testTangle.Storage, agedMsg.ID(), 0, now)
+	require.NoError(t, err)
+	assert.Equal(t, agedMsg.Bytes(), msgBytes)
+
+	_, err = loadMessageFromStorage(testTangle.Storage, newMessage(now).ID(), 0, now)
+	assert.Equal(t, ErrMessageNotFound, err)
+}
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	flag "github.com/spf13/pflag"
+
+	"github.com/iotaledger/goshimmer/packages/gossip"
 )
 
 const (
@@ -13,10 +15,57 @@ const (
 	CfgGossipAgeThreshold = "gossip.ageThreshold"
 	// CfgGossipTipsBroadcastInterval the interval in which the oldest known tip is re-broadcast.
 	CfgGossipTipsBroadcastInterval = "gossip.tipsBroadcaster.interval"
+	// CfgGossipRequestRetryInterval defines the time after which an unanswered message request is re-sent to additional neighbors.
+	// Together with CfgGossipRequestMaxAttempts, it must not exceed the retry interval of the tangle's message requester.
+	CfgGossipRequestRetryInterval = "gossip.messageRequest.retryInterval"
+	// CfgGossipRequestMaxAttempts defines the number of attempts before a message request is given up, until the
+	// tangle's message requester triggers it again.
+	CfgGossipRequestMaxAttempts = "gossip.messageRequest.maxAttempts"
+	// CfgGossipRequestFanout defines the number of additional neighbors queried in each retry of a message request.
+	CfgGossipRequestFanout = "gossip.messageRequest.fanout"
+	// CfgGossipRequestMaxOutstanding defines the maximum number of outstanding message requests. Further requests are
+	// queued until outstanding ones are resolved.
+	CfgGossipRequestMaxOutstanding = "gossip.messageRequest.maxOutstanding"
+	// CfgGossipMaxInboundNeighbors defines the maximum number of inbound neighbors. When exceeded, the inbound neighbor
+	// with the lowest consensus mana is dropped.
+	CfgGossipMaxInboundNeighbors = "gossip.manaPrioritization.maxInboundNeighbors"
+	// CfgGossipHeartbeatInterval defines the interval in which heartbeats containing the tip count and sync state are
+	// sent to all neighbors which advertised the support of heartbeats during the handshake.
+	CfgGossipHeartbeatInterval = "gossip.heartbeat.interval"
+	// CfgGossipAllowedPeers defines the IDs of the peers accepted as inbound neighbors. If empty, all peers are accepted.
+	CfgGossipAllowedPeers = "gossip.peerFilter.allow"
+	// CfgGossipDeniedPeers defines the IDs of the peers that are never accepted as inbound neighbors.
+	CfgGossipDeniedPeers = "gossip.peerFilter.deny"
+	// CfgGossipFairSending defines whether the outbound packets are distributed over the neighbors in round-robin order.
+	CfgGossipFairSending = "gossip.fairSending"
+	// CfgGossipChecksum defines whether a checksum is appended to the gossip packets exchanged with neighbors supporting it.
+	CfgGossipChecksum = "gossip.checksum"
+	// CfgGossipCompression defines whether the gossip packets are compressed with neighbors supporting it.
+	CfgGossipCompression = "gossip.compression"
+	// CfgGossipBandwidthLimit defines the maximum number of bytes per second written to each neighbor.
+	CfgGossipBandwidthLimit = "gossip.bandwidthLimit.bytesPerSecond"
+	// CfgGossipBandwidthBurst defines the number of bytes which can be written to a neighbor at once, exceeding the limit.
+	CfgGossipBandwidthBurst = "gossip.bandwidthLimit.burst"
+	// CfgGossipMaxMessageAge defines the maximum age (time since issuance) of a message to be served to neighbors.
+	CfgGossipMaxMessageAge = "gossip.maxMessageAge"
 )
 
 func init() {
 	flag.Int(CfgGossipPort, 14666, "tcp port for gossip connection")
 	flag.Duration(CfgGossipAgeThreshold, 1*time.Minute, "message age threshold for gossip")
 	flag.Duration(CfgGossipTipsBroadcastInterval, 10*time.Second, "the interval in which the oldest known tip is re-broadcast")
+	flag.Duration(CfgGossipRequestRetryInterval, gossip.DefaultRequestRetryInterval, "the time after which an unanswered message request is re-sent to additional neighbors")
+	flag.Int(CfgGossipRequestMaxAttempts, gossip.DefaultRequestMaxAttempts, "the number of attempts before a message request is given up")
+	flag.Int(CfgGossipRequestFanout, gossip.DefaultRequestFanout, "the number of additional neighbors queried in each retry of a message request, the first attempt queries all neighbors (0 queries all additional neighbors)")
+	flag.Int(CfgGossipRequestMaxOutstanding, gossip.DefaultMaxOutstandingRequests, "the maximum number of outstanding message requests before further requests are queued (0 disables the limit)")
+	flag.Int(CfgGossipMaxInboundNeighbors, 0, "the maximum number of inbound neighbors before the one with the lowest consensus mana is dropped (0 disables the limit)")
+	flag.Duration(CfgGossipHeartbeatInterval, 10*time.Second, "the interval in which heartbeats are sent to all neighbors supporting them (0 disables heartbeats)")
+	flag.StringSlice(CfgGossipAllowedPeers, nil, "the IDs of the peers accepted as inbound neighbors (empty accepts all peers)")
+	flag.StringSlice(CfgGossipDeniedPeers, nil, "the IDs of the peers that are never accepted as inbound neighbors")
+	flag.Bool(CfgGossipFairSending, false, "whether the outbound packets are distributed over the neighbors in round-robin order")
+	flag.Bool(CfgGossipChecksum, true, "whether a checksum is appended to the gossip packets exchanged with neighbors supporting it")
+	flag.Bool(CfgGossipCompression, false, "whether the gossip packets are compressed with neighbors supporting it")
+	flag.Int(CfgGossipBandwidthLimit, 0, "the maximum number of bytes per second written to each neighbor (0 disables the limit)")
+	flag.Int(CfgGossipBandwidthBurst, 64*1024, "the number of bytes which can be written to a neighbor at once, exceeding the bandwidth limit")
+	flag.Duration(CfgGossipMaxMessageAge, 0, "the maximum age (time since issuance) of a message to be served to neighbors (0 serves all stored messages)")
 }
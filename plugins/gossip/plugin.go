@@ -10,6 +10,7 @@ import (
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
+	"github.com/mr-tron/base58"
 
 	"github.com/iotaledger/goshimmer/packages/clock"
 	"github.com/iotaledger/goshimmer/packages/gossip"
@@ -30,6 +31,7 @@ var (
 
 	log                     *logger.Logger
 	ageThreshold            time.Duration
+	maxMessageAge           time.Duration
 	tipsBroadcasterInterval time.Duration
 
 	requestedMsgs *requestedMessages
@@ -46,6 +48,7 @@ func Plugin() *node.Plugin {
 func configure(*node.Plugin) {
 	log = logger.NewLogger(PluginName)
 	ageThreshold = config.Node().Duration(CfgGossipAgeThreshold)
+	maxMessageAge = config.Node().Duration(CfgGossipMaxMessageAge)
 	tipsBroadcasterInterval = config.Node().Duration(CfgGossipTipsBroadcastInterval)
 	requestedMsgs = newRequestedMessages()
 
@@ -149,13 +152,22 @@ func configureMessageLayer() {
 		})
 	}))
 
-	// request missing messages
+	// request missing messages, the Requester re-triggers the request until the message is stored
 	messagelayer.Tangle().Requester.Events.SendRequest.Attach(events.NewClosure(func(sendRequest *tangle.SendRequestEvent) {
 		mgr.RequestMessage(sendRequest.ID[:])
 	}))
 
 	messagelayer.Tangle().Storage.Events.MissingMessageStored.Attach(events.NewClosure(requestedMsgs.append))
 
+	// stop the outstanding request once a missing message has been received
+	messagelayer.Tangle().Storage.Events.MissingMessageStored.Attach(events.NewClosure(func(messageID tangle.MessageID) {
+		mgr.StopMessageRequest(messageID[:])
+	}))
+
+	mgr.Events().MessageRequestFailed.Attach(events.NewClosure(func(messageID []byte) {
+		log.Debugw("message request stayed unanswered", "msg-id", base58.Encode(messageID))
+	}))
+
 	// delete the message from requestedMsgs if it's invalid, otherwise it will always be in the list and never get removed in some cases.
 	messagelayer.Tangle().Events.MessageInvalid.Attach(events.NewClosure(func(messageID tangle.MessageID) { requestedMsgs.delete(messageID) }))
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/iotaledger/hive.go/autopeering/peer"
 	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
@@ -49,6 +50,8 @@ var (
 	voterServer         *votenet.VoterServer
 	registry            *statement.Registry
 	registryOnce        sync.Once
+	// the public keys of the queried peers by the string representation of their ID
+	opinionGiverKeys sync.Map
 )
 
 // ConsensusPlugin returns the consensus plugin.
@@ -84,7 +87,18 @@ func runConsensusPlugin(plugin *node.Plugin) {
 // Voter returns the DRNGRoundBasedVoter instance used by the FPC plugin.
 func Voter() vote.DRNGRoundBasedVoter {
 	voterOnce.Do(func() {
-		voter = fpc.New(OpinionGiverFunc, OwnManaRetriever)
+		paras := fpc.DefaultParameters()
+		paras.ProbeBeforeRound = FPCParameters.ProbeBeforeRound
+		paras.RoundStatsBufferSize = FPCParameters.RoundStatsBufferSize
+		paras.HealthWindow = FPCParameters.HealthWindow
+		paras.ManaRecencyHalfLife = FPCParameters.ManaRecencyHalfLife
+		if FPCParameters.SamplingAuditLog {
+			paras.SamplingAuditLog = ConsensusPlugin().LogDebugf
+		}
+		if FPCParameters.SignResponses {
+			paras.VerifyResponse = verifyOpinionGiverResponse
+		}
+		voter = fpc.New(OpinionGiverFunc, OwnManaRetriever, paras)
 	})
 	return voter
 }
@@ -149,6 +163,11 @@ func runFPC(plugin *node.Plugin) {
 				metrics.Events().FPCOutboundBytes,
 				metrics.Events().QueryReceived,
 			)
+			if FPCParameters.SignResponses {
+				voterServer.SetSigner(func(data []byte) []byte {
+					return local.GetInstance().Sign(data).Bytes()
+				})
+			}
 
 			go func() {
 				plugin.LogInfof("%s started, bind-address=%s", ServerWorkerName, bindAddr)
@@ -172,7 +191,10 @@ func runFPC(plugin *node.Plugin) {
 		}
 	}
 
-	if err := daemon.BackgroundWorker("FPCRoundsInitiator", func(shutdownSignal <-chan struct{}) {
+	// manual rounds are triggered via the webapi instead of the round initiator
+	if FPCParameters.ManualRounds {
+		plugin.LogWarnf("FPC rounds are only executed manually")
+	} else if err := daemon.BackgroundWorker("FPCRoundsInitiator", func(shutdownSignal <-chan struct{}) {
 		plugin.LogInfof("Started FPC round initiator")
 		defer plugin.LogInfof("Stopped FPC round initiator")
 		unixTsPRNG := prng.NewUnixTimestampPRNG(FPCParameters.RoundInterval)
@@ -222,6 +244,8 @@ type OpinionGiver struct {
 	view *statement.View
 	pog  *PeerOpinionGiver
 	mana float64
+	// the time of the last consensus mana change of the node, zero if unknown.
+	manaUpdateTime time.Time
 }
 
 // OpinionGivers is a map of OpinionGiver.
@@ -249,6 +273,41 @@ func (o *OpinionGiver) Query(ctx context.Context, conflictIDs []string, timestam
 	return o.pog.Query(ctx, conflictIDs, timestampIDs)
 }
 
+// QueryObjects retrieves the opinions about the given objects of any type. Statements only carry opinions about
+// conflicts and timestamps, so the peer is queried directly if there are objects of other types.
+func (o *OpinionGiver) QueryObjects(ctx context.Context, objectIDs vote.ObjectIDs) (opinion.Opinions, error) {
+	for objectType, ids := range objectIDs {
+		if len(ids) > 0 && objectType != vote.ConflictType && objectType != vote.TimestampType {
+			return o.pog.QueryObjects(ctx, objectIDs)
+		}
+	}
+	return o.Query(ctx, objectIDs[vote.ConflictType], objectIDs[vote.TimestampType])
+}
+
+// QuerySigned queries the peer directly for its opinions about the given conflicts and timestamps and returns its
+// signed reply. Statements are not used, as they are not signed by the reply of a query.
+func (o *OpinionGiver) QuerySigned(ctx context.Context, conflictIDs []string, timestampIDs []string) (opinion.Opinions, []byte, []byte, error) {
+	return o.pog.QuerySigned(ctx, conflictIDs, timestampIDs)
+}
+
+// Probe checks whether the opinion giver is reachable, i.e. whether it recently issued a statement
+// or a connection to its FPC service can be established.
+func (o *OpinionGiver) Probe(ctx context.Context) error {
+	if o.view != nil && o.view.LastStatementReceivedTimestamp.Add(2*time.Duration(FPCParameters.RoundInterval)*time.Second).After(clockPkg.SyncedTime()) {
+		return nil
+	}
+	if o.pog == nil {
+		return fmt.Errorf("unable to probe, PeerOpinionGiver is nil")
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", o.pog.Address())
+	if err != nil {
+		return fmt.Errorf("unable to connect to FPC service: %w", err)
+	}
+	return conn.Close()
+}
+
 // ID returns the identifier of the underlying Peer.
 func (o *OpinionGiver) ID() identity.ID {
 	return o.id
@@ -259,6 +318,11 @@ func (o *OpinionGiver) Mana() float64 {
 	return o.mana
 }
 
+// ManaUpdateTime returns the time of the last consensus mana change of the opinion giver, zero if it is unknown.
+func (o *OpinionGiver) ManaUpdateTime() time.Time {
+	return o.manaUpdateTime
+}
+
 // OpinionGiverFunc returns a slice of opinion givers.
 func OpinionGiverFunc() (givers []opinion.OpinionGiver, err error) {
 	opinionGiversMap := make(map[identity.ID]*OpinionGiver)
@@ -280,9 +344,10 @@ func OpinionGiverFunc() (givers []opinion.OpinionGiver, err error) {
 			manaValue = manaAmount
 		}
 		opinionGiversMap[v.ID()] = &OpinionGiver{
-			id:   v.ID(),
-			view: v,
-			mana: manaValue,
+			id:             v.ID(),
+			view:           v,
+			mana:           manaValue,
+			manaUpdateTime: ConsensusManaChangeTime(v.ID()),
 		}
 	}
 
@@ -301,12 +366,14 @@ func OpinionGiverFunc() (givers []opinion.OpinionGiver, err error) {
 				manaValue = v
 			}
 			opinionGiversMap[p.ID()] = &OpinionGiver{
-				id:   p.ID(),
-				view: nil,
-				mana: manaValue,
+				id:             p.ID(),
+				view:           nil,
+				mana:           manaValue,
+				manaUpdateTime: ConsensusManaChangeTime(p.ID()),
 			}
 		}
 		opinionGiversMap[p.ID()].pog = &PeerOpinionGiver{p: p}
+		opinionGiverKeys.Store(p.ID().String(), p.PublicKey())
 	}
 
 	for _, v := range opinionGiversMap {
@@ -327,8 +394,26 @@ type PeerOpinionGiver struct {
 
 // Query queries another node for its opinion.
 func (pog *PeerOpinionGiver) Query(ctx context.Context, conflictIDs []string, timestampIDs []string) (opinion.Opinions, error) {
+	opinions, _, _, err := pog.QuerySigned(ctx, conflictIDs, timestampIDs)
+	return opinions, err
+}
+
+// QuerySigned queries another node for its opinion and returns the signed bytes of its reply together with the
+// signature. The signature is empty if the node does not sign its replies.
+func (pog *PeerOpinionGiver) QuerySigned(ctx context.Context, conflictIDs []string, timestampIDs []string) (opinion.Opinions, []byte, []byte, error) {
+	return pog.querySignedObjects(ctx, vote.ObjectIDs{vote.ConflictType: conflictIDs, vote.TimestampType: timestampIDs})
+}
+
+// QueryObjects queries another node for its opinions about the given objects of any type.
+// Nodes which don't support typed IDs only answer the conflicts and timestamps, their opinion on other objects is Unknown.
+func (pog *PeerOpinionGiver) QueryObjects(ctx context.Context, objectIDs vote.ObjectIDs) (opinion.Opinions, error) {
+	opinions, _, _, err := pog.querySignedObjects(ctx, objectIDs)
+	return opinions, err
+}
+
+func (pog *PeerOpinionGiver) querySignedObjects(ctx context.Context, objectIDs vote.ObjectIDs) (opinion.Opinions, []byte, []byte, error) {
 	if pog == nil {
-		return nil, fmt.Errorf("unable to query opinions, PeerOpinionGiver is nil")
+		return nil, nil, nil, fmt.Errorf("unable to query opinions, PeerOpinionGiver is nil")
 	}
 
 	var opts []grpc.DialOption
@@ -337,7 +422,7 @@ func (pog *PeerOpinionGiver) Query(ctx context.Context, conflictIDs []string, ti
 	// connect to the FPC service
 	conn, err := grpc.Dial(pog.Address(), opts...)
 	if err != nil {
-		return nil, fmt.Errorf("unable to connect to FPC service: %w", err)
+		return nil, nil, nil, fmt.Errorf("unable to connect to FPC service: %w", err)
 	}
 	defer func() {
 		cerr := conn.Close()
@@ -346,15 +431,21 @@ func (pog *PeerOpinionGiver) Query(ctx context.Context, conflictIDs []string, ti
 		}
 	}()
 
+	// a fresh nonce binds a signed reply to this query, so that it can't be replayed
+	nonce, err := votenet.NewQueryNonce()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("unable to create query nonce: %w", err)
+	}
+
 	client := votenet.NewVoterQueryClient(conn)
-	query := &votenet.QueryRequest{ConflictIDs: conflictIDs, TimestampIDs: timestampIDs}
+	query := votenet.NewQueryRequest(objectIDs, nonce)
 	reply, err := client.Opinion(ctx, query)
 	if err != nil {
 		metrics.Events().QueryReplyError.Trigger(&metrics.QueryReplyErrorEvent{
 			ID:           pog.p.ID().String(),
-			OpinionCount: len(conflictIDs) + len(timestampIDs),
+			OpinionCount: objectIDs.Len(),
 		})
-		return nil, fmt.Errorf("unable to query opinions: %w", err)
+		return nil, nil, nil, fmt.Errorf("unable to query opinions: %w", err)
 	}
 
 	metrics.Events().FPCInboundBytes.Trigger(uint64(proto.Size(reply)))
@@ -365,8 +456,14 @@ func (pog *PeerOpinionGiver) Query(ctx context.Context, conflictIDs []string, ti
 	for i, intOpn := range reply.Opinion {
 		opinions[i] = opinion.ConvertInt32Opinion(intOpn)
 	}
+	// nodes which don't support typed IDs ignore them and only answer the conflicts and timestamps
+	if len(query.TypedIDs) > 0 && len(opinions) == len(query.ConflictIDs)+len(query.TimestampIDs) {
+		for len(opinions) < objectIDs.Len() {
+			opinions = append(opinions, opinion.Unknown)
+		}
+	}
 
-	return opinions, err
+	return opinions, votenet.ReplySigningBytes(query, reply.Opinion), reply.Signature, err
 }
 
 // ID returns the identifier of the underlying Peer.
@@ -380,6 +477,22 @@ func (pog *PeerOpinionGiver) Address() string {
 	return net.JoinHostPort(pog.p.IP().String(), strconv.Itoa(fpcServicePort))
 }
 
+// verifies the signature of the reply of the queried peer with the given ID against its public key.
+func verifyOpinionGiverResponse(giverID string, response []byte, sig []byte) error {
+	publicKey, ok := opinionGiverKeys.Load(giverID)
+	if !ok {
+		return fmt.Errorf("unknown public key of peer %s", giverID)
+	}
+	signature, _, err := ed25519.SignatureFromBytes(sig)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	if !publicKey.(ed25519.PublicKey).VerifySignature(response, signature) {
+		return fmt.Errorf("signature of peer %s does not match", giverID)
+	}
+	return nil
+}
+
 // endregion /////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region OwnWeightsRetriever/////////////////////////////////////////////////////////////////////////////////////
@@ -1,12 +1,23 @@
 package messagelayer
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	walletseed "github.com/iotaledger/goshimmer/client/wallet/packages/seed"
 	"github.com/iotaledger/goshimmer/packages/tangle/payload"
 	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/fpc"
+	votenet "github.com/iotaledger/goshimmer/packages/vote/net"
 	"github.com/iotaledger/goshimmer/packages/vote/opinion"
 	"github.com/iotaledger/goshimmer/packages/vote/statement"
 )
@@ -42,3 +53,68 @@ func TestMakeStatement(t *testing.T) {
 	// if max payload size exceeded MockBroadcastStatement will panic
 	makeStatement(stats, MockBroadcastStatement)
 }
+
+func TestPeerOpinionGiverQueryObjects(t *testing.T) {
+	const customType vote.ObjectType = 5
+
+	// reserve a free port for the FPC service
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+
+	opinionRetriever := func(id string, objectType vote.ObjectType) opinion.Opinion {
+		switch {
+		case objectType == vote.ConflictType && id == "conflict":
+			return opinion.Like
+		case objectType == vote.TimestampType && id == "timestamp":
+			return opinion.Dislike
+		case objectType == customType && id == "custom":
+			return opinion.Like
+		default:
+			return opinion.Unknown
+		}
+	}
+	voterServer := votenet.New(fpc.New(nil, nil), opinionRetriever, net.JoinHostPort("127.0.0.1", fmt.Sprint(port)), nil, nil, nil)
+	go func() { _ = voterServer.Run() }()
+	defer voterServer.Shutdown()
+
+	services := service.New()
+	services.Update(service.PeeringKey, "udp", port)
+	services.Update(service.FPCKey, "tcp", port)
+	pog := &PeerOpinionGiver{p: peer.NewPeer(identity.GenerateIdentity(), net.IPv4(127, 0, 0, 1), services)}
+
+	objectIDs := vote.ObjectIDs{
+		vote.ConflictType:  {"conflict"},
+		vote.TimestampType: {"timestamp"},
+		customType:         {"custom", "unknown"},
+	}
+	var opinions opinion.Opinions
+	require.Eventually(t, func() bool {
+		opinions, err = pog.QueryObjects(context.Background(), objectIDs)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, opinion.Opinions{opinion.Like, opinion.Dislike, opinion.Like, opinion.Unknown}, opinions)
+
+	// the conflicts and timestamps are still answered through the untyped fields
+	opinions, err = pog.Query(context.Background(), []string{"conflict"}, []string{"timestamp"})
+	require.NoError(t, err)
+	assert.Equal(t, opinion.Opinions{opinion.Like, opinion.Dislike}, opinions)
+}
+
+func TestOpinionGiverManaUpdateTime(t *testing.T) {
+	nodeID := identity.GenerateIdentity().ID()
+	assert.True(t, ConsensusManaChangeTime(nodeID).IsZero())
+
+	// only the latest consensus mana change is retained, regardless of the order the events arrive in
+	now := time.Now()
+	recordConsensusManaChange(nodeID, now)
+	recordConsensusManaChange(nodeID, now.Add(-time.Minute))
+	assert.Equal(t, now, ConsensusManaChangeTime(nodeID))
+
+	var opinionGiver opinion.OpinionGiver = &OpinionGiver{id: nodeID, mana: 1, manaUpdateTime: ConsensusManaChangeTime(nodeID)}
+	updateTimer, ok := opinionGiver.(opinion.ManaUpdateTimer)
+	require.True(t, ok)
+	assert.Equal(t, now, updateTimer.ManaUpdateTime())
+	assert.Equal(t, 0.5, fpc.ManaRecencyFactor(opinionGiver, time.Minute, now.Add(time.Minute)))
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/iotaledger/hive.go/node"
 	"github.com/iotaledger/hive.go/objectstorage"
 	"go.uber.org/atomic"
+	"golang.org/x/xerrors"
 
 	db_pkg "github.com/iotaledger/goshimmer/packages/database"
 	"github.com/iotaledger/goshimmer/packages/gossip"
@@ -52,6 +53,12 @@ var (
 	onPledgeEventClosure                       *events.Closure
 	onRevokeEventClosure                       *events.Closure
 	debuggingEnabled                           bool
+	checkpointStore                            *mana.CheckpointStore
+	rankTracker                                *mana.RankTracker
+	manaTimeline                               *mana.Timeline
+	// the time of the last consensus mana pledge or revoke by node ID
+	consensusManaChangeTimes   = make(map[identity.ID]time.Time)
+	consensusManaChangeTimesMu sync.RWMutex
 )
 
 // Plugin gets the plugin instance.
@@ -100,6 +107,20 @@ func configureManaPlugin(*node.Plugin) {
 
 	debuggingEnabled = ManaParameters.DebuggingEnabled
 
+	if ManaParameters.CheckpointInterval > 0 {
+		checkpointStore = mana.NewCheckpointStore(ManaParameters.CheckpointDirectory, ManaParameters.CheckpointRetention)
+	}
+	if ManaParameters.RankThreshold > 0 {
+		rankTracker = mana.NewRankTracker(local.GetInstance().ID(), ManaParameters.RankThreshold)
+		rankTracker.RankChanged.Attach(events.NewClosure(func(ev *mana.RankChangedEvent) {
+			manaLogger.Infof("Consensus mana rank crossed top %d: %d -> %d", ev.Threshold, ev.OldRank, ev.NewRank)
+		}))
+	}
+
+	if ManaParameters.TimelineSize > 0 {
+		manaTimeline = mana.NewTimeline(ManaParameters.TimelineSize)
+	}
+
 	configureEvents()
 }
 
@@ -112,6 +133,7 @@ func configureEvents() {
 
 func logPledgeEvent(ev *mana.PledgedEvent) {
 	if ev.ManaType == mana.ConsensusMana {
+		recordConsensusManaChange(ev.NodeID, ev.Time)
 		consensusEventsLogStorage.Store(ev.ToPersistable()).Release()
 		consensusEventsLogsStorageSize.Inc()
 	}
@@ -119,11 +141,30 @@ func logPledgeEvent(ev *mana.PledgedEvent) {
 
 func logRevokeEvent(ev *mana.RevokedEvent) {
 	if ev.ManaType == mana.ConsensusMana {
+		recordConsensusManaChange(ev.NodeID, ev.Time)
 		consensusEventsLogStorage.Store(ev.ToPersistable()).Release()
 		consensusEventsLogsStorageSize.Inc()
 	}
 }
 
+// recordConsensusManaChange records the time of a consensus mana pledge or revoke for the given node, unless a later
+// change was already recorded.
+func recordConsensusManaChange(nodeID identity.ID, t time.Time) {
+	consensusManaChangeTimesMu.Lock()
+	defer consensusManaChangeTimesMu.Unlock()
+	if t.After(consensusManaChangeTimes[nodeID]) {
+		consensusManaChangeTimes[nodeID] = t
+	}
+}
+
+// ConsensusManaChangeTime returns the time of the last consensus mana pledge or revoke of the given node since the
+// node was started, or the zero time if there was none.
+func ConsensusManaChangeTime(nodeID identity.ID) time.Time {
+	consensusManaChangeTimesMu.RLock()
+	defer consensusManaChangeTimesMu.RUnlock()
+	return consensusManaChangeTimes[nodeID]
+}
+
 func onTransactionConfirmed(msgID tangle.MessageID) {
 	var tx *ledgerstate.Transaction
 	isTx := false
@@ -224,6 +265,28 @@ func runManaPlugin(_ *node.Plugin) {
 		defer cleanupTicker.Stop()
 		readStoredManaVectors()
 		pruneStorages()
+
+		var checkpointTicker <-chan time.Time
+		if checkpointStore != nil {
+			readManaCheckpoint()
+			ticker := time.NewTicker(ManaParameters.CheckpointInterval)
+			defer ticker.Stop()
+			checkpointTicker = ticker.C
+		}
+
+		var rankTicker <-chan time.Time
+		if rankTracker != nil {
+			ticker := time.NewTicker(ManaParameters.RankCheckInterval)
+			defer ticker.Stop()
+			rankTicker = ticker.C
+		}
+
+		var timelineTicker <-chan time.Time
+		if manaTimeline != nil {
+			ticker := time.NewTicker(ManaParameters.TimelineInterval)
+			defer ticker.Stop()
+			timelineTicker = ticker.C
+		}
 		for {
 			select {
 			case <-shutdownSignal:
@@ -232,12 +295,19 @@ func runManaPlugin(_ *node.Plugin) {
 				mana.Events().Pledged.Detach(onRevokeEventClosure)
 				Tangle().ConsensusManager.Events.TransactionConfirmed.Detach(onTransactionConfirmedClosure)
 				storeManaVectors()
+				writeManaCheckpoint()
 				shutdownStorages()
 				return
 			case <-ticker.C:
 				pruneConsensusEventLogsStorage()
 			case <-cleanupTicker.C:
 				cleanupManaVectors()
+			case <-checkpointTicker:
+				writeManaCheckpoint()
+			case <-rankTicker:
+				updateOwnManaRank()
+			case t := <-timelineTicker:
+				addManaTimelineSnapshot(t)
 			}
 		}
 	}, shutdown.PriorityMana); err != nil {
@@ -245,6 +315,45 @@ func runManaPlugin(_ *node.Plugin) {
 	}
 }
 
+// RankTracker returns the tracker of the local node's consensus mana rank, or nil if rank tracking is disabled.
+// Its RankChanged event is triggered whenever the rank crosses ManaParameters.RankThreshold.
+func RankTracker() *mana.RankTracker {
+	return rankTracker
+}
+
+// updateOwnManaRank recomputes the consensus mana rank of the local node.
+func updateOwnManaRank() {
+	nodes, _, err := GetHighestManaNodes(mana.ConsensusMana, 0)
+	if err != nil {
+		if !xerrors.Is(err, ErrQueryNotAllowed) {
+			manaLogger.Errorf("error while computing the mana rank: %s", err)
+		}
+		return
+	}
+	rankTracker.Update(nodes)
+}
+
+// ManaTimeline returns the retained snapshots of the access and consensus mana maps, or nil if the timeline is disabled.
+func ManaTimeline() *mana.Timeline {
+	return manaTimeline
+}
+
+// addManaTimelineSnapshot adds the access and consensus mana maps at the given time to the mana timeline.
+func addManaTimelineSnapshot(t time.Time) {
+	manaMaps := make(map[mana.Type]mana.NodeMap)
+	for _, manaType := range []mana.Type{mana.AccessMana, mana.ConsensusMana} {
+		manaMap, _, err := GetManaMap(manaType, t)
+		if err != nil {
+			if !xerrors.Is(err, ErrQueryNotAllowed) {
+				manaLogger.Errorf("error while taking a mana timeline snapshot: %s", err)
+			}
+			return
+		}
+		manaMaps[manaType] = manaMap
+	}
+	manaTimeline.Add(t, manaMaps)
+}
+
 func readStoredManaVectors() {
 	for vectorType := range baseManaVectors {
 		storages[vectorType].ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
@@ -269,6 +378,43 @@ func storeManaVectors() {
 	}
 }
 
+// readManaCheckpoint restores the mana vectors from the most recent valid checkpoint.
+// As a checkpoint is also written on shutdown, it is never older than the vectors read from the storage.
+func readManaCheckpoint() {
+	persistables, t, err := checkpointStore.LoadLatest()
+	if err != nil {
+		if !xerrors.Is(err, mana.ErrNoValidCheckpoint) {
+			manaLogger.Errorf("error while loading mana checkpoint: %s", err)
+		}
+		return
+	}
+	for _, p := range persistables {
+		if p.ManaType != mana.AccessMana && p.ManaType != mana.ConsensusMana {
+			continue
+		}
+		baseManaVector := baseManaVectors[p.ManaType]
+		if err := baseManaVector.FromPersistable(p); err != nil {
+			manaLogger.Errorf("error while restoring %s mana vector from checkpoint: %s", p.ManaType.String(), err)
+		}
+	}
+	manaLogger.Infof("Restored mana vectors from checkpoint taken at %s", t)
+}
+
+// writeManaCheckpoint writes a checkpoint of the access and consensus mana vectors to disk.
+// The research vectors share the same persistable type and are therefore not checkpointed.
+func writeManaCheckpoint() {
+	if checkpointStore == nil {
+		return
+	}
+	var persistables []*mana.PersistableBaseMana
+	for _, vectorType := range []mana.Type{mana.AccessMana, mana.ConsensusMana} {
+		persistables = append(persistables, baseManaVectors[vectorType].ToPersistables()...)
+	}
+	if err := checkpointStore.Write(time.Now(), persistables); err != nil {
+		manaLogger.Errorf("error while writing mana checkpoint: %s", err)
+	}
+}
+
 func pruneStorages() {
 	for vectorType := range baseManaVectors {
 		_ = storages[vectorType].Prune()
@@ -311,7 +457,20 @@ func GetManaMap(manaType mana.Type, optionalUpdateTime ...time.Time) (mana.NodeM
 	if !QueryAllowed() {
 		return mana.NodeMap{}, time.Now(), ErrQueryNotAllowed
 	}
-	return baseManaVectors[manaType].GetManaMap(optionalUpdateTime...)
+	return baseManaVectors[manaType].GetManaMapParallel(ManaParameters.ManaMapWorkers, optionalUpdateTime...)
+}
+
+// UpdateAllManaVectors updates all entries of the access and consensus base mana vectors wrt to `t`.
+// It returns the number of updated nodes per mana type.
+func UpdateAllManaVectors(t time.Time) (map[mana.Type]int, error) {
+	updated := make(map[mana.Type]int)
+	for _, manaType := range []mana.Type{mana.AccessMana, mana.ConsensusMana} {
+		if err := baseManaVectors[manaType].UpdateAll(t); err != nil {
+			return nil, err
+		}
+		updated[manaType] = baseManaVectors[manaType].Size()
+	}
+	return updated, nil
 }
 
 // GetAccessMana returns the access mana of the node specified.
@@ -40,6 +40,30 @@ var FPCParameters = struct {
 
 	// TotalRoundsFinalization The amount of rounds a vote context's opinion needs to stay the same to be considered final. Also called 'l'.
 	TotalRoundsFinalization int `default:"10" usage:"The number of rounds opinion needs to stay the same to become final (l)."`
+
+	// ProbeBeforeRound defines whether the reachability of the opinion givers is probed before each round.
+	ProbeBeforeRound bool `default:"false" usage:"if unreachable opinion givers should be excluded from sampling by probing them before each round"`
+
+	// RoundStatsBufferSize defines how many of the most recent rounds are retained for the rounds webapi endpoint.
+	RoundStatsBufferSize int `default:"100" usage:"the number of recent FPC rounds to retain (0 disables the retention)"`
+
+	// SamplingAuditLog defines whether every sampling decision of the opinion givers is logged at debug level.
+	SamplingAuditLog bool `default:"false" usage:"if every sampling decision of the opinion givers should be logged at debug level"`
+
+	// ManualRounds defines whether FPC rounds are only executed on demand via the webapi instead of periodically.
+	// It is meant for step-through testing and must not be enabled in production.
+	ManualRounds bool `default:"false" usage:"if FPC rounds should only be executed on demand via the webapi (testing only)"`
+
+	// HealthWindow defines the number of most recent queries per opinion giver whose timeout rate scales down its
+	// weight in the sampling.
+	HealthWindow int `default:"0" usage:"the number of recent queries per opinion giver whose timeout rate scales down its sampling weight (0 disables it)"`
+
+	// ManaRecencyHalfLife defines the time since the last consensus mana change of an opinion giver after which its
+	// weight in the sampling is halved.
+	ManaRecencyHalfLife time.Duration `default:"0s" usage:"the time since the last consensus mana change of an opinion giver after which its sampling weight is halved (0 disables it)"`
+
+	// SignResponses defines whether the replies to FPC queries are signed and the replies of queried peers are verified.
+	SignResponses bool `default:"false" usage:"if FPC query replies should be signed and the replies of queried peers verified"`
 }{}
 
 // StatementParameters contains the configuration parameters used by the FPC statements in the tangle.
@@ -106,6 +130,22 @@ var ManaParameters = struct {
 	VectorsCleanupInterval time.Duration `default:"30m" usage:"interval to cleanup empty mana nodes from the mana vectors"`
 	// DebuggingEnabled defines if the mana plugin responds to queries while not being in sync or not.
 	DebuggingEnabled bool `default:"false" usage:"if mana plugin responds to queries while not in sync"`
+	// CheckpointInterval defines the interval in which the mana vectors are checkpointed to disk. 0 disables checkpoints.
+	CheckpointInterval time.Duration `default:"0s" usage:"interval to checkpoint the mana vectors to disk, 0 disables checkpoints"`
+	// CheckpointRetention defines how many mana vector checkpoints are kept on disk.
+	CheckpointRetention int `default:"3" usage:"number of mana vector checkpoints to keep"`
+	// CheckpointDirectory defines the directory the mana vector checkpoints are written to.
+	CheckpointDirectory string `default:"manacheckpoints" usage:"directory to write the mana vector checkpoints to"`
+	// RankThreshold defines the consensus mana rank whose crossing by the local node triggers an event. 0 disables it.
+	RankThreshold int `default:"100" usage:"consensus mana rank of the local node whose crossing triggers an event, 0 disables it"`
+	// RankCheckInterval defines the interval in which the consensus mana rank of the local node is recomputed.
+	RankCheckInterval time.Duration `default:"1m" usage:"interval to recompute the consensus mana rank of the local node"`
+	// ManaMapWorkers defines the number of workers computing the mana maps. More than one worker computes them in parallel.
+	ManaMapWorkers int `default:"1" usage:"number of workers computing the mana maps, more than one computes them in parallel"`
+	// TimelineSize defines how many snapshots of the mana maps are retained for the mana timeline. 0 disables the timeline.
+	TimelineSize int `default:"0" usage:"number of mana map snapshots retained for the mana timeline, 0 disables it"`
+	// TimelineInterval defines the interval in which snapshots of the mana maps are taken for the mana timeline.
+	TimelineInterval time.Duration `default:"1m" usage:"interval to take mana map snapshots for the mana timeline"`
 }{}
 
 func init() {
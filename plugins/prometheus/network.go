@@ -4,6 +4,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/iotaledger/goshimmer/plugins/autopeering"
+	"github.com/iotaledger/goshimmer/plugins/gossip"
 	"github.com/iotaledger/goshimmer/plugins/metrics"
 )
 
@@ -15,6 +16,7 @@ var (
 	gossipOutboundBytes      prometheus.Gauge
 	autopeeringInboundBytes  prometheus.Gauge
 	autopeeringOutboundBytes prometheus.Gauge
+	gossipMessageRequests    *prometheus.GaugeVec
 )
 
 func registerNetworkMetrics() {
@@ -47,6 +49,11 @@ func registerNetworkMetrics() {
 		Help: "traffic_Analysis client TX network traffic [bytes].",
 	})
 
+	gossipMessageRequests = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gossip_message_requests",
+		Help: "Number of outstanding and locally queued gossip message requests.",
+	}, []string{"state"})
+
 	registry.MustRegister(fpcInboundBytes)
 	registry.MustRegister(fpcOutboundBytes)
 	registry.MustRegister(analysisOutboundBytes)
@@ -54,6 +61,7 @@ func registerNetworkMetrics() {
 	registry.MustRegister(autopeeringOutboundBytes)
 	registry.MustRegister(gossipInboundBytes)
 	registry.MustRegister(gossipOutboundBytes)
+	registry.MustRegister(gossipMessageRequests)
 
 	addCollect(collectNetworkMetrics)
 }
@@ -66,4 +74,6 @@ func collectNetworkMetrics() {
 	autopeeringOutboundBytes.Set(float64(autopeering.Conn.TXBytes()))
 	gossipInboundBytes.Set(float64(metrics.GossipInboundBytes()))
 	gossipOutboundBytes.Set(float64(metrics.GossipOutboundBytes()))
+	gossipMessageRequests.WithLabelValues("outstanding").Set(float64(gossip.Manager().RequestQueueSize()))
+	gossipMessageRequests.WithLabelValues("pending").Set(float64(gossip.Manager().PendingRequestQueueSize()))
 }
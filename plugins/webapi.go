@@ -8,6 +8,8 @@ import (
 	"github.com/iotaledger/goshimmer/plugins/webapi/data"
 	"github.com/iotaledger/goshimmer/plugins/webapi/drng"
 	"github.com/iotaledger/goshimmer/plugins/webapi/faucet"
+	"github.com/iotaledger/goshimmer/plugins/webapi/fpc"
+	"github.com/iotaledger/goshimmer/plugins/webapi/gossip"
 	"github.com/iotaledger/goshimmer/plugins/webapi/healthz"
 	"github.com/iotaledger/goshimmer/plugins/webapi/info"
 	"github.com/iotaledger/goshimmer/plugins/webapi/ledgerstate"
@@ -26,9 +28,11 @@ var WebAPI = node.Plugins(
 	healthz.Plugin(),
 	message.Plugin(),
 	autopeering.Plugin(),
+	gossip.Plugin(),
 	info.Plugin(),
 	value.Plugin(),
 	tools.Plugin(),
 	mana.Plugin(),
+	fpc.Plugin(),
 	ledgerstate.Plugin(),
 )
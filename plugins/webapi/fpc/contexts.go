@@ -0,0 +1,92 @@
+package fpc
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+// voteContextTypes maps the values of the type query parameter to the vote context types.
+var voteContextTypes = map[string]vote.ObjectType{
+	"conflict":  vote.ConflictType,
+	"timestamp": vote.TimestampType,
+}
+
+// objectTypeName returns the name of the given object type used by the API. Object types without a name, e.g. custom
+// ones, are named by their number.
+func objectTypeName(objectType vote.ObjectType) string {
+	for name, t := range voteContextTypes {
+		if t == objectType {
+			return name
+		}
+	}
+	return strconv.Itoa(int(objectType))
+}
+
+// parseObjectType returns the object type with the given name or number.
+func parseObjectType(name string) (vote.ObjectType, bool) {
+	if objectType, ok := voteContextTypes[name]; ok {
+		return objectType, true
+	}
+	number, err := strconv.ParseUint(name, 10, 8)
+	if err != nil {
+		return 0, false
+	}
+	return vote.ObjectType(number), true
+}
+
+// activeVoteContextsProvider provides a snapshot of the active vote contexts.
+type activeVoteContextsProvider interface {
+	ActiveVoteContexts() map[string]*vote.Context
+}
+
+// contextsHandler returns a handler which answers with the active vote contexts sorted by their ID.
+// The optional type query parameter (conflict, timestamp or the number of a custom object type) filters the vote
+// contexts by their type.
+func contextsHandler(provider activeVoteContextsProvider) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var (
+			objectType vote.ObjectType
+			filter     bool
+		)
+		if param := c.QueryParam("type"); param != "" {
+			if objectType, filter = parseObjectType(param); !filter {
+				return c.JSON(http.StatusBadRequest, jsonmodels.FPCVoteContextsResponse{Error: "invalid type: " + param})
+			}
+		}
+
+		voteCtxs := make([]jsonmodels.FPCVoteContext, 0)
+		for _, voteCtx := range provider.ActiveVoteContexts() {
+			if filter && voteCtx.Type != objectType {
+				continue
+			}
+			voteCtxs = append(voteCtxs, voteContextToJSON(voteCtx))
+		}
+		sort.Slice(voteCtxs, func(i, j int) bool { return voteCtxs[i].ID < voteCtxs[j].ID })
+
+		return c.JSON(http.StatusOK, jsonmodels.FPCVoteContextsResponse{VoteContexts: voteCtxs})
+	}
+}
+
+func voteContextToJSON(voteCtx *vote.Context) jsonmodels.FPCVoteContext {
+	lastOpinion := opinion.Unknown
+	if len(voteCtx.Opinions) > 0 {
+		lastOpinion = voteCtx.LastOpinion()
+	}
+	return jsonmodels.FPCVoteContext{
+		ID:               voteCtx.ID,
+		Type:             objectTypeName(voteCtx.Type),
+		Rounds:           voteCtx.Rounds,
+		Opinion:          lastOpinion.String(),
+		ProportionLiked:  voteCtx.ProportionLiked,
+		OwnWeight:        voteCtx.Weights.OwnWeight,
+		TotalWeights:     voteCtx.Weights.TotalWeights,
+		RespondedWeights: voteCtx.Weights.RespondedWeights,
+	}
+}
@@ -0,0 +1,103 @@
+package fpc
+
+import (
+	"github.com/labstack/echo"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+)
+
+// metricsProvider provides the state of FPC exported as metrics.
+type metricsProvider interface {
+	activeVoteContextsProvider
+	QueueLength() int
+	LastRoundSuccessful() bool
+	Counters() (likeFinal, dislikeFinal, failed uint64)
+	AvgRoundsToFinalize() (like, dislike float64)
+}
+
+var (
+	activeVoteContextsDesc = prometheus.NewDesc(
+		"fpc_active_vote_contexts",
+		"number of currently active vote contexts",
+		[]string{"type"}, nil,
+	)
+	queueLengthDesc = prometheus.NewDesc(
+		"fpc_queue_length",
+		"number of vote contexts enqueued for the next round",
+		nil, nil,
+	)
+	lastRoundSuccessfulDesc = prometheus.NewDesc(
+		"fpc_last_round_successful",
+		"1 if the most recent round was performed successfully, 0 otherwise",
+		nil, nil,
+	)
+	finalizedVoteContextsDesc = prometheus.NewDesc(
+		"fpc_finalized_vote_contexts_total",
+		"number of vote contexts finalized since the start of the node",
+		[]string{"opinion"}, nil,
+	)
+	failedVoteContextsDesc = prometheus.NewDesc(
+		"fpc_failed_vote_contexts_total",
+		"number of vote contexts failed to be finalized since the start of the node",
+		nil, nil,
+	)
+	avgRoundsToFinalizeDesc = prometheus.NewDesc(
+		"fpc_avg_rounds_to_finalize",
+		"average number of rounds it took to finalize vote contexts since the start of the node",
+		[]string{"opinion"}, nil,
+	)
+)
+
+// metricsCollector collects the FPC metrics from a metricsProvider on every scrape.
+type metricsCollector struct {
+	provider metricsProvider
+}
+
+// Describe implements prometheus.Collector.
+func (m *metricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- activeVoteContextsDesc
+	ch <- queueLengthDesc
+	ch <- lastRoundSuccessfulDesc
+	ch <- finalizedVoteContextsDesc
+	ch <- failedVoteContextsDesc
+	ch <- avgRoundsToFinalizeDesc
+}
+
+// Collect implements prometheus.Collector.
+func (m *metricsCollector) Collect(ch chan<- prometheus.Metric) {
+	// the built-in object types are always exported, custom ones only while they have active vote contexts
+	activeVoteContexts := map[vote.ObjectType]int{vote.ConflictType: 0, vote.TimestampType: 0}
+	for _, voteCtx := range m.provider.ActiveVoteContexts() {
+		activeVoteContexts[voteCtx.Type]++
+	}
+	for objectType, count := range activeVoteContexts {
+		ch <- prometheus.MustNewConstMetric(activeVoteContextsDesc, prometheus.GaugeValue, float64(count), objectTypeName(objectType))
+	}
+
+	ch <- prometheus.MustNewConstMetric(queueLengthDesc, prometheus.GaugeValue, float64(m.provider.QueueLength()))
+
+	lastRoundSuccessful := 0.
+	if m.provider.LastRoundSuccessful() {
+		lastRoundSuccessful = 1
+	}
+	ch <- prometheus.MustNewConstMetric(lastRoundSuccessfulDesc, prometheus.GaugeValue, lastRoundSuccessful)
+
+	likeFinal, dislikeFinal, failed := m.provider.Counters()
+	ch <- prometheus.MustNewConstMetric(finalizedVoteContextsDesc, prometheus.CounterValue, float64(likeFinal), "like")
+	ch <- prometheus.MustNewConstMetric(finalizedVoteContextsDesc, prometheus.CounterValue, float64(dislikeFinal), "dislike")
+	ch <- prometheus.MustNewConstMetric(failedVoteContextsDesc, prometheus.CounterValue, float64(failed))
+
+	avgLike, avgDislike := m.provider.AvgRoundsToFinalize()
+	ch <- prometheus.MustNewConstMetric(avgRoundsToFinalizeDesc, prometheus.GaugeValue, avgLike, "like")
+	ch <- prometheus.MustNewConstMetric(avgRoundsToFinalizeDesc, prometheus.GaugeValue, avgDislike, "dislike")
+}
+
+// metricsHandler returns a handler which answers with the FPC metrics of the given provider in the Prometheus
+// text exposition format.
+func metricsHandler(provider metricsProvider) echo.HandlerFunc {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(&metricsCollector{provider: provider})
+	return echo.WrapHandler(promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+}
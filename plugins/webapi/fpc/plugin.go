@@ -0,0 +1,73 @@
+package fpc
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/iotaledger/hive.go/node"
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+// PluginName is the name of the web API FPC endpoint plugin.
+const PluginName = "WebAPI FPC Endpoint"
+
+var (
+	// plugin is the plugin instance of the web API FPC endpoint plugin.
+	plugin *node.Plugin
+	once   sync.Once
+)
+
+// Plugin gets the plugin instance.
+func Plugin() *node.Plugin {
+	once.Do(func() {
+		plugin = node.NewPlugin(PluginName, node.Enabled, configure)
+	})
+	return plugin
+}
+
+func configure(_ *node.Plugin) {
+	webapi.Server().POST("consensus/fpc/opinions", opinionsHandler(messagelayer.Voter()))
+	if rounds, ok := messagelayer.Voter().(recentRoundsProvider); ok {
+		webapi.Server().GET("consensus/fpc/rounds", roundsHandler(rounds))
+	}
+	if voteCtxs, ok := messagelayer.Voter().(activeVoteContextsProvider); ok {
+		webapi.Server().GET("consensus/fpc/contexts", contextsHandler(voteCtxs))
+	}
+	if metrics, ok := messagelayer.Voter().(metricsProvider); ok {
+		webapi.Server().GET("consensus/fpc/metrics", metricsHandler(metrics))
+	}
+	if messagelayer.FPCParameters.ManualRounds {
+		webapi.Server().POST("consensus/fpc/round", roundHandler(messagelayer.Voter()))
+	}
+}
+
+// opinionsHandler returns a handler which answers with the intermediate opinions of the given voter on the requested IDs.
+// IDs which are not under vote are reported as Unknown.
+func opinionsHandler(voter vote.Voter) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var request jsonmodels.FPCOpinionsRequest
+		if err := c.Bind(&request); err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.FPCOpinionsResponse{Error: err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, jsonmodels.FPCOpinionsResponse{
+			Conflicts:  intermediateOpinions(voter, request.ConflictIDs),
+			Timestamps: intermediateOpinions(voter, request.TimestampIDs),
+		})
+	}
+}
+
+func intermediateOpinions(voter vote.Voter, ids []string) []jsonmodels.FPCOpinion {
+	opinions := make([]jsonmodels.FPCOpinion, len(ids))
+	for i, id := range ids {
+		// the opinion is Unknown if there is no vote ongoing for the ID
+		o, _ := voter.IntermediateOpinion(id)
+		opinions[i] = jsonmodels.FPCOpinion{ID: id, Opinion: o.String()}
+	}
+	return opinions
+}
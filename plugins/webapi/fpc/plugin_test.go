@@ -0,0 +1,359 @@
+package fpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/fpc"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+func TestOpinionsHandler(t *testing.T) {
+	opinionGiverFunc := func() ([]opinion.OpinionGiver, error) {
+		return nil, nil
+	}
+	ownWeightRetrieverFunc := func() (float64, error) {
+		return 0, nil
+	}
+	voter := fpc.New(opinionGiverFunc, ownWeightRetrieverFunc)
+	require.NoError(t, voter.Vote("conflictA", vote.ConflictType, opinion.Like))
+	require.NoError(t, voter.Vote("conflictB", vote.ConflictType, opinion.Dislike))
+	require.NoError(t, voter.Vote("timestampA", vote.TimestampType, opinion.Like))
+	// the round moves the enqueued items to the active vote contexts, it fails as there is nobody to query
+	assert.True(t, errors.Is(voter.Round(0.5), fpc.ErrNoOpinionGiversAvailable))
+
+	body := `{"conflictIDs":["conflictA","conflictB","conflictC"],"timestampIDs":["timestampA"]}`
+	req := httptest.NewRequest(http.MethodPost, "/consensus/fpc/opinions", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	require.NoError(t, opinionsHandler(voter)(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res jsonmodels.FPCOpinionsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, []jsonmodels.FPCOpinion{
+		{ID: "conflictA", Opinion: opinion.Like.String()},
+		{ID: "conflictB", Opinion: opinion.Dislike.String()},
+		{ID: "conflictC", Opinion: opinion.Unknown.String()},
+	}, res.Conflicts)
+	assert.Equal(t, []jsonmodels.FPCOpinion{
+		{ID: "timestampA", Opinion: opinion.Like.String()},
+	}, res.Timestamps)
+}
+
+func TestOpinionsHandlerBadRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/consensus/fpc/opinions", strings.NewReader("{"))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	require.NoError(t, opinionsHandler(fpc.New(nil, nil))(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestRoundsHandler(t *testing.T) {
+	paras := fpc.DefaultParameters()
+	paras.RoundStatsBufferSize = 3
+	rounds := fpc.New(func() ([]opinion.OpinionGiver, error) { return nil, nil }, func() (float64, error) { return 0, nil }, paras)
+	// without any vote contexts the rounds succeed without querying anybody
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, rounds.Round(float64(i)/10))
+	}
+
+	getRounds := func(query string) ([]float64, int) {
+		req := httptest.NewRequest(http.MethodGet, "/consensus/fpc/rounds"+query, nil)
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(req, rec)
+		require.NoError(t, roundsHandler(rounds)(c))
+
+		var res jsonmodels.FPCRoundsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		randsUsed := make([]float64, len(res.Rounds))
+		for i, roundStats := range res.Rounds {
+			randsUsed[i] = roundStats.RandUsed
+		}
+		return randsUsed, rec.Code
+	}
+
+	// the newest rounds come first
+	randsUsed, code := getRounds("?limit=2")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []float64{0.5, 0.4}, randsUsed)
+
+	// the limit is capped to the buffered rounds
+	randsUsed, code = getRounds("?limit=50")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []float64{0.5, 0.4, 0.3}, randsUsed)
+
+	randsUsed, code = getRounds("")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []float64{0.5, 0.4, 0.3}, randsUsed)
+
+	_, code = getRounds("?limit=abc")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestContextsHandler(t *testing.T) {
+	voter := fpc.New(func() ([]opinion.OpinionGiver, error) { return nil, nil }, func() (float64, error) { return 0, nil })
+	require.NoError(t, voter.Vote("conflictB", vote.ConflictType, opinion.Dislike))
+	require.NoError(t, voter.Vote("conflictA", vote.ConflictType, opinion.Like))
+	require.NoError(t, voter.Vote("timestampA", vote.TimestampType, opinion.Like))
+	require.NoError(t, voter.Vote("customA", vote.TimestampType+1, opinion.Like))
+	// the round moves the enqueued items to the active vote contexts, it fails as there is nobody to query
+	assert.True(t, errors.Is(voter.Round(0.5), fpc.ErrNoOpinionGiversAvailable))
+
+	getContexts := func(query string) ([]jsonmodels.FPCVoteContext, int) {
+		req := httptest.NewRequest(http.MethodGet, "/consensus/fpc/contexts"+query, nil)
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(req, rec)
+		require.NoError(t, contextsHandler(voter)(c))
+
+		var res jsonmodels.FPCVoteContextsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		return res.VoteContexts, rec.Code
+	}
+
+	voteCtxs, code := getContexts("")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []jsonmodels.FPCVoteContext{
+		{ID: "conflictA", Type: "conflict", Rounds: 1, Opinion: opinion.Like.String(), ProportionLiked: -1},
+		{ID: "conflictB", Type: "conflict", Rounds: 1, Opinion: opinion.Dislike.String(), ProportionLiked: -1},
+		{ID: "customA", Type: "2", Rounds: 1, Opinion: opinion.Like.String(), ProportionLiked: -1},
+		{ID: "timestampA", Type: "timestamp", Rounds: 1, Opinion: opinion.Like.String(), ProportionLiked: -1},
+	}, voteCtxs)
+
+	voteCtxs, code = getContexts("?type=conflict")
+	assert.Equal(t, http.StatusOK, code)
+	require.Len(t, voteCtxs, 2)
+	assert.Equal(t, "conflictA", voteCtxs[0].ID)
+	assert.Equal(t, "conflictB", voteCtxs[1].ID)
+
+	voteCtxs, code = getContexts("?type=timestamp")
+	assert.Equal(t, http.StatusOK, code)
+	require.Len(t, voteCtxs, 1)
+	assert.Equal(t, "timestampA", voteCtxs[0].ID)
+
+	// custom object types are filtered by their number
+	voteCtxs, code = getContexts("?type=2")
+	assert.Equal(t, http.StatusOK, code)
+	require.Len(t, voteCtxs, 1)
+	assert.Equal(t, "customA", voteCtxs[0].ID)
+
+	_, code = getContexts("?type=unknown")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestMetricsHandler(t *testing.T) {
+	voter := fpc.New(func() ([]opinion.OpinionGiver, error) { return nil, nil }, func() (float64, error) { return 0, nil })
+	require.NoError(t, voter.Vote("conflictA", vote.ConflictType, opinion.Like))
+	require.NoError(t, voter.Vote("conflictB", vote.ConflictType, opinion.Dislike))
+	require.NoError(t, voter.Vote("timestampA", vote.TimestampType, opinion.Like))
+	require.NoError(t, voter.Vote("customA", vote.TimestampType+1, opinion.Like))
+	// the round moves the enqueued items to the active vote contexts, it fails as there is nobody to query
+	assert.True(t, errors.Is(voter.Round(0.5), fpc.ErrNoOpinionGiversAvailable))
+	require.NoError(t, voter.Vote("conflictC", vote.ConflictType, opinion.Like))
+
+	req := httptest.NewRequest(http.MethodGet, "/consensus/fpc/metrics", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	require.NoError(t, metricsHandler(voter)(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	body := rec.Body.String()
+	for _, name := range []string{
+		"fpc_active_vote_contexts",
+		"fpc_queue_length",
+		"fpc_last_round_successful",
+		"fpc_avg_rounds_to_finalize",
+	} {
+		assert.Contains(t, body, "# TYPE "+name+" gauge\n")
+	}
+	for _, name := range []string{
+		"fpc_finalized_vote_contexts_total",
+		"fpc_failed_vote_contexts_total",
+	} {
+		assert.Contains(t, body, "# TYPE "+name+" counter\n")
+	}
+	assert.Contains(t, body, "fpc_active_vote_contexts{type=\"conflict\"} 2\n")
+	assert.Contains(t, body, "fpc_active_vote_contexts{type=\"timestamp\"} 1\n")
+	assert.Contains(t, body, "fpc_active_vote_contexts{type=\"2\"} 1\n")
+	assert.Contains(t, body, "fpc_queue_length 1\n")
+	assert.Contains(t, body, "fpc_last_round_successful 0\n")
+	assert.Contains(t, body, "fpc_finalized_vote_contexts_total{opinion=\"like\"} 0\n")
+	assert.Contains(t, body, "fpc_failed_vote_contexts_total 0\n")
+}
+
+// likingOpinionGiver is an opinion giver which likes everything.
+type likingOpinionGiver struct {
+	id identity.ID
+}
+
+func (o *likingOpinionGiver) Query(_ context.Context, conflictIDs []string, timestampIDs []string) (opinion.Opinions, error) {
+	opinions := make(opinion.Opinions, len(conflictIDs)+len(timestampIDs))
+	for i := range opinions {
+		opinions[i] = opinion.Like
+	}
+	return opinions, nil
+}
+
+func (o *likingOpinionGiver) ID() identity.ID {
+	return o.id
+}
+
+func (o *likingOpinionGiver) Mana() float64 {
+	return 1
+}
+
+func TestRoundHandler(t *testing.T) {
+	opinionGiver := &likingOpinionGiver{id: identity.GenerateIdentity().ID()}
+	voter := fpc.New(func() ([]opinion.OpinionGiver, error) { return []opinion.OpinionGiver{opinionGiver}, nil }, func() (float64, error) { return 0, nil })
+	require.NoError(t, voter.Vote("conflictA", vote.ConflictType, opinion.Dislike))
+
+	executeRound := func(body string) (jsonmodels.FPCRoundResponse, int) {
+		req := httptest.NewRequest(http.MethodPost, "/consensus/fpc/round", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(req, rec)
+		require.NoError(t, roundHandler(voter)(c))
+
+		var res jsonmodels.FPCRoundResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		return res, rec.Code
+	}
+
+	// the first round queries the opinion of the vote context
+	res, code := executeRound(`{"rand":0.25}`)
+	assert.Equal(t, http.StatusOK, code)
+	require.NotNil(t, res.Round)
+	assert.Equal(t, 0.25, res.Round.RandUsed)
+	require.Contains(t, res.Round.ActiveVoteContexts, "conflictA")
+	assert.Equal(t, 1, res.Round.ActiveVoteContexts["conflictA"].Rounds)
+	assert.Equal(t, 1., res.Round.ActiveVoteContexts["conflictA"].ProportionLiked)
+	require.Len(t, res.Round.QueriedOpinions, 1)
+	assert.Equal(t, opinionGiver.ID().String(), res.Round.QueriedOpinions[0].OpinionGiverID)
+
+	// without a random number one is generated, the second round forms the opinion
+	res, code = executeRound("")
+	assert.Equal(t, http.StatusOK, code)
+	require.NotNil(t, res.Round)
+	assert.GreaterOrEqual(t, res.Round.RandUsed, 0.)
+	assert.LessOrEqual(t, res.Round.RandUsed, 1.)
+	require.Contains(t, res.Round.ActiveVoteContexts, "conflictA")
+	assert.Equal(t, 2, res.Round.ActiveVoteContexts["conflictA"].Rounds)
+	assert.Equal(t, opinion.Like, res.Round.ActiveVoteContexts["conflictA"].LastOpinion())
+
+	// the handler does not keep observing the rounds
+	assert.False(t, voter.Events().RoundExecuted.HasHandlers())
+
+	_, code = executeRound(`{"rand":1.5}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	_, code = executeRound("{")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// errors of the round are returned
+	failing := fpc.New(func() ([]opinion.OpinionGiver, error) { return nil, nil }, func() (float64, error) { return 0, nil })
+	require.NoError(t, failing.Vote("conflictA", vote.ConflictType, opinion.Like))
+	req := httptest.NewRequest(http.MethodPost, "/consensus/fpc/round", nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, roundHandler(failing)(echo.New().NewContext(req, rec)))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	res = jsonmodels.FPCRoundResponse{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Nil(t, res.Round)
+	assert.Contains(t, res.Error, fpc.ErrNoOpinionGiversAvailable.Error())
+}
+
+func TestRoundHandlerNoStats(t *testing.T) {
+	executeRound := func(voter vote.DRNGRoundBasedVoter) (jsonmodels.FPCRoundResponse, int) {
+		req := httptest.NewRequest(http.MethodPost, "/consensus/fpc/round", nil)
+		rec := httptest.NewRecorder()
+		require.NoError(t, roundHandler(voter)(echo.New().NewContext(req, rec)))
+
+		var res jsonmodels.FPCRoundResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		return res, rec.Code
+	}
+
+	// a paused voter only counts the round
+	opinionGiver := &likingOpinionGiver{id: identity.GenerateIdentity().ID()}
+	paused := fpc.New(func() ([]opinion.OpinionGiver, error) { return []opinion.OpinionGiver{opinionGiver}, nil }, func() (float64, error) { return 0, nil })
+	require.NoError(t, paused.Vote("conflictA", vote.ConflictType, opinion.Like))
+	paused.Pause()
+	res, code := executeRound(paused)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Nil(t, res.Round)
+	assert.Contains(t, res.Error, "paused")
+
+	// a round without sufficient responses reports why it failed
+	paras := fpc.DefaultParameters()
+	paras.MinResponseFraction = 1
+	unresponsive := fpc.New(func() ([]opinion.OpinionGiver, error) {
+		return []opinion.OpinionGiver{&failingOpinionGiver{id: identity.GenerateIdentity().ID()}}, nil
+	}, func() (float64, error) { return 0, nil }, paras)
+	require.NoError(t, unresponsive.Vote("conflictA", vote.ConflictType, opinion.Like))
+	res, code = executeRound(unresponsive)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Nil(t, res.Round)
+	assert.Contains(t, res.Error, fpc.ErrInsufficientResponses.Error())
+}
+
+func TestRoundHandlerConcurrent(t *testing.T) {
+	opinionGiver := &likingOpinionGiver{id: identity.GenerateIdentity().ID()}
+	voter := fpc.New(func() ([]opinion.OpinionGiver, error) { return []opinion.OpinionGiver{opinionGiver}, nil }, func() (float64, error) { return 0, nil })
+	require.NoError(t, voter.Vote("conflictA", vote.ConflictType, opinion.Like))
+	handler := roundHandler(voter)
+
+	// every request answers with the stats of its own round
+	const requests = 10
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(r float64) {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodPost, "/consensus/fpc/round", strings.NewReader(fmt.Sprintf(`{"rand":%f}`, r)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			assert.NoError(t, handler(echo.New().NewContext(req, rec)))
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var res jsonmodels.FPCRoundResponse
+			if assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res)) && assert.NotNil(t, res.Round) {
+				assert.Equal(t, r, res.Round.RandUsed)
+			}
+		}(float64(i) / requests)
+	}
+	wg.Wait()
+}
+
+// failingOpinionGiver is an opinion giver which can never be queried.
+type failingOpinionGiver struct {
+	id identity.ID
+}
+
+func (o *failingOpinionGiver) Query(context.Context, []string, []string) (opinion.Opinions, error) {
+	return nil, errors.New("unreachable")
+}
+
+func (o *failingOpinionGiver) ID() identity.ID {
+	return o.id
+}
+
+func (o *failingOpinionGiver) Mana() float64 {
+	return 1
+}
@@ -0,0 +1,69 @@
+package fpc
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+// roundHandler returns a handler which executes a single round of the given voter and answers with the stats of the
+// round. The random number of the round can be given in the request, otherwise a random one is generated.
+// The voter must not execute rounds concurrently, i.e. its rounds must only be triggered manually. Concurrent requests
+// are executed one after the other, so that each request answers with the stats of its own round. If the round did
+// not produce any stats, e.g. as the voting is paused or too few opinion givers responded, the request fails.
+func roundHandler(voter vote.DRNGRoundBasedVoter) echo.HandlerFunc {
+	var roundMutex sync.Mutex
+	return func(c echo.Context) error {
+		var request jsonmodels.FPCRoundRequest
+		// the body is optional
+		if c.Request().ContentLength != 0 {
+			if err := c.Bind(&request); err != nil {
+				return c.JSON(http.StatusBadRequest, jsonmodels.FPCRoundResponse{Error: err.Error()})
+			}
+		}
+
+		r := rand.Float64()
+		if request.Rand != nil {
+			if *request.Rand < 0 || *request.Rand > 1 {
+				return c.JSON(http.StatusBadRequest, jsonmodels.FPCRoundResponse{Error: fmt.Sprintf("invalid rand: %f", *request.Rand)})
+			}
+			r = *request.Rand
+		}
+
+		roundMutex.Lock()
+		defer roundMutex.Unlock()
+
+		var roundStats *vote.RoundStats
+		roundExecuted := events.NewClosure(func(stats *vote.RoundStats) {
+			roundStats = stats.Snapshot()
+		})
+		voter.Events().RoundExecuted.Attach(roundExecuted)
+		defer voter.Events().RoundExecuted.Detach(roundExecuted)
+		// the last error of the round tells why it did not produce any stats
+		var roundErr error
+		roundError := events.NewClosure(func(err error) {
+			roundErr = err
+		})
+		voter.Events().Error.Attach(roundError)
+		defer voter.Events().Error.Detach(roundError)
+
+		if err := voter.Round(r); err != nil {
+			return c.JSON(http.StatusInternalServerError, jsonmodels.FPCRoundResponse{Error: err.Error()})
+		}
+		if roundStats == nil {
+			if roundErr == nil {
+				roundErr = errors.New("no round was executed, e.g. as the voting is paused")
+			}
+			return c.JSON(http.StatusServiceUnavailable, jsonmodels.FPCRoundResponse{Error: fmt.Sprintf("round produced no stats: %s", roundErr)})
+		}
+		return c.JSON(http.StatusOK, jsonmodels.FPCRoundResponse{Round: roundStats})
+	}
+}
@@ -0,0 +1,32 @@
+package fpc
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+// recentRoundsProvider provides the stats of the most recent FPC rounds.
+type recentRoundsProvider interface {
+	RecentRounds(limit int) []*vote.RoundStats
+}
+
+// roundsHandler returns a handler which answers with the stats of the most recent FPC rounds, newest first.
+// The optional limit query parameter caps the number of returned rounds.
+func roundsHandler(provider recentRoundsProvider) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		limit := 0
+		if param := c.QueryParam("limit"); param != "" {
+			var err error
+			if limit, err = strconv.Atoi(param); err != nil || limit < 0 {
+				return c.JSON(http.StatusBadRequest, jsonmodels.FPCRoundsResponse{Error: "invalid limit: " + param})
+			}
+		}
+
+		return c.JSON(http.StatusOK, jsonmodels.FPCRoundsResponse{Rounds: provider.RecentRounds(limit)})
+	}
+}
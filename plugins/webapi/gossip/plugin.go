@@ -0,0 +1,119 @@
+package gossip
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/gossip"
+	gossipPlugin "github.com/iotaledger/goshimmer/plugins/gossip"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+// PluginName is the name of the web API gossip endpoint plugin.
+const PluginName = "WebAPI gossip Endpoint"
+
+var (
+	// plugin is the plugin instance of the web API gossip endpoint plugin.
+	plugin *node.Plugin
+	once   sync.Once
+)
+
+// Plugin gets the plugin instance.
+func Plugin() *node.Plugin {
+	once.Do(func() {
+		plugin = node.NewPlugin(PluginName, node.Enabled, configure)
+	})
+	return plugin
+}
+
+func configure(_ *node.Plugin) {
+	webapi.Server().GET("gossip/neighbors", neighborsHandler(func() []*gossip.Neighbor {
+		return gossipPlugin.Manager().AllNeighbors()
+	}))
+	webapi.Server().GET("gossip/neighbors/:id", neighborHandler(func() []*gossip.Neighbor {
+		return gossipPlugin.Manager().AllNeighbors()
+	}, func(id identity.ID) []time.Time {
+		return gossipPlugin.Manager().DisconnectHistory(id)
+	}))
+}
+
+// neighborsHandler returns a handler which lists the neighbors returned by the given function together with their metrics.
+func neighborsHandler(neighborsFunc func() []*gossip.Neighbor) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		neighbors := make([]jsonmodels.GossipNeighbor, 0)
+		for _, nbr := range neighborsFunc() {
+			neighbors = append(neighbors, neighborFromGossip(nbr))
+		}
+		return c.JSON(http.StatusOK, jsonmodels.GossipNeighborsResponse{Neighbors: neighbors})
+	}
+}
+
+// neighborHandler returns a handler which returns the detailed metrics of the neighbor with the ID given in the path,
+// which is looked up in the neighbors returned by the given function. The disconnects of the neighbor are returned by
+// the given disconnect history function.
+func neighborHandler(neighborsFunc func() []*gossip.Neighbor, disconnectHistoryFunc func(identity.ID) []time.Time) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param("id")
+		for _, nbr := range neighborsFunc() {
+			if nbr.ID().String() != id {
+				continue
+			}
+			details := neighborDetailsFromGossip(nbr)
+			for _, disconnect := range disconnectHistoryFunc(nbr.ID()) {
+				details.Disconnects = append(details.Disconnects, disconnect.Unix())
+			}
+			return c.JSON(http.StatusOK, jsonmodels.GossipNeighborResponse{Neighbor: details})
+		}
+		return c.JSON(http.StatusNotFound, jsonmodels.GossipNeighborResponse{Error: fmt.Sprintf("neighbor %s not found", id)})
+	}
+}
+
+func neighborDetailsFromGossip(nbr *gossip.Neighbor) *jsonmodels.GossipNeighborDetails {
+	requestStats := nbr.RequestStats()
+	var lastHeartbeat int64
+	if received := nbr.LastHeartbeat().Received; !received.IsZero() {
+		lastHeartbeat = received.Unix()
+	}
+	return &jsonmodels.GossipNeighborDetails{
+		GossipNeighbor:        neighborFromGossip(nbr),
+		ConnectionEstablished: nbr.ConnectionEstablished().Unix(),
+		PacketsSent:           nbr.PacketsSent(),
+		SendRate:              nbr.SendRate(),
+		BytesSaved:            nbr.BytesSaved(),
+		ChecksumFailures:      nbr.ChecksumFailures(),
+		DuplicateMessages:     nbr.DuplicateMessages(),
+		RequestsSent:          requestStats.RequestsSent,
+		RequestsReceived:      requestStats.RequestsReceived,
+		ResponsesSent:         requestStats.ResponsesSent,
+		ResponsesReceived:     requestStats.ResponsesReceived,
+		LastHeartbeat:         lastHeartbeat,
+		Disconnects:           make([]int64, 0),
+	}
+}
+
+func neighborFromGossip(nbr *gossip.Neighbor) jsonmodels.GossipNeighbor {
+	origin := "Inbound"
+	if nbr.IsOutbound() {
+		origin = "Outbound"
+	}
+
+	host := nbr.Peer.IP().String()
+	port := nbr.Peer.Services().Get(service.GossipKey).Port()
+	return jsonmodels.GossipNeighbor{
+		ID:               nbr.Peer.ID().String(),
+		Address:          net.JoinHostPort(host, strconv.Itoa(port)),
+		ConnectionOrigin: origin,
+		BytesRead:        nbr.BytesRead(),
+		BytesWritten:     nbr.BytesWritten(),
+	}
+}
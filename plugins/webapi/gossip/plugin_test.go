@@ -0,0 +1,153 @@
+package gossip
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/gossip"
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+var log = logger.NewExampleLogger("webapi/gossip")
+
+func TestNeighborsHandler(t *testing.T) {
+	a, b := net.Pipe()
+	defer a.Close()
+	defer b.Close()
+
+	neighbors := []*gossip.Neighbor{
+		gossip.NewNeighbor(newTestPeer("A", 14666), a, log),
+		gossip.NewNeighbor(newTestPeer("B", 14667), b, log),
+	}
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/gossip/neighbors", nil), rec)
+
+	handler := neighborsHandler(func() []*gossip.Neighbor { return neighbors })
+	require.NoError(t, handler(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res jsonmodels.GossipNeighborsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	require.Len(t, res.Neighbors, len(neighbors))
+	for i, nbr := range neighbors {
+		assert.Equal(t, nbr.ID().String(), res.Neighbors[i].ID)
+		assert.Equal(t, gossip.GetAddress(nbr.Peer), res.Neighbors[i].Address)
+		assert.Equal(t, "Inbound", res.Neighbors[i].ConnectionOrigin)
+		assert.EqualValues(t, 0, res.Neighbors[i].BytesRead)
+		assert.EqualValues(t, 0, res.Neighbors[i].BytesWritten)
+	}
+}
+
+func TestNeighborsHandlerEmpty(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/gossip/neighbors", nil), rec)
+
+	handler := neighborsHandler(func() []*gossip.Neighbor { return nil })
+	require.NoError(t, handler(c))
+
+	var res jsonmodels.GossipNeighborsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.NotNil(t, res.Neighbors)
+	assert.Empty(t, res.Neighbors)
+}
+
+func TestNeighborHandler(t *testing.T) {
+	a, b := net.Pipe()
+	defer a.Close()
+	defer b.Close()
+
+	neighbors := []*gossip.Neighbor{
+		gossip.NewNeighbor(newTestPeer("A", 14666), a, log),
+		gossip.NewNeighbor(newTestPeer("B", 14667), b, log),
+	}
+	disconnects := []time.Time{time.Unix(1000, 0), time.Unix(2000, 0)}
+	handler := neighborHandler(func() []*gossip.Neighbor { return neighbors }, func(id identity.ID) []time.Time {
+		if id == neighbors[1].ID() {
+			return disconnects
+		}
+		return nil
+	})
+
+	t.Run("CASE: Known neighbor", func(t *testing.T) {
+		nbr := neighbors[1]
+		rec := httptest.NewRecorder()
+		c := newNeighborContext(rec, nbr.ID().String())
+		require.NoError(t, handler(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var res jsonmodels.GossipNeighborResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Empty(t, res.Error)
+		require.NotNil(t, res.Neighbor)
+		assert.Equal(t, nbr.ID().String(), res.Neighbor.ID)
+		assert.Equal(t, gossip.GetAddress(nbr.Peer), res.Neighbor.Address)
+		assert.Equal(t, "Inbound", res.Neighbor.ConnectionOrigin)
+		assert.Equal(t, nbr.ConnectionEstablished().Unix(), res.Neighbor.ConnectionEstablished)
+		assert.EqualValues(t, 0, res.Neighbor.PacketsSent)
+		assert.EqualValues(t, 0, res.Neighbor.DuplicateMessages)
+		assert.EqualValues(t, 0, res.Neighbor.RequestsSent)
+		assert.EqualValues(t, 0, res.Neighbor.LastHeartbeat)
+		assert.Equal(t, []int64{1000, 2000}, res.Neighbor.Disconnects)
+	})
+
+	t.Run("CASE: Neighbor without disconnects", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		c := newNeighborContext(rec, neighbors[0].ID().String())
+		require.NoError(t, handler(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var res jsonmodels.GossipNeighborResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		require.NotNil(t, res.Neighbor)
+		assert.Equal(t, neighbors[0].ID().String(), res.Neighbor.ID)
+		assert.NotNil(t, res.Neighbor.Disconnects)
+		assert.Empty(t, res.Neighbor.Disconnects)
+	})
+
+	t.Run("CASE: Unknown neighbor", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		c := newNeighborContext(rec, newTestPeer("C", 14668).ID().String())
+		require.NoError(t, handler(c))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+
+		var res jsonmodels.GossipNeighborResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Nil(t, res.Neighbor)
+		assert.NotEmpty(t, res.Error)
+	})
+}
+
+func newNeighborContext(rec *httptest.ResponseRecorder, id string) echo.Context {
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/gossip/neighbors/"+id, nil), rec)
+	c.SetPath("/gossip/neighbors/:id")
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+	return c
+}
+
+func newTestPeer(name string, port int) *peer.Peer {
+	services := service.New()
+	services.Update(service.PeeringKey, "tcp", port)
+	services.Update(service.GossipKey, "tcp", port)
+
+	var publicKey ed25519.PublicKey
+	copy(publicKey[:], name)
+
+	return peer.NewPeer(identity.New(publicKey), net.IPv4(127, 0, 0, 1), services)
+}
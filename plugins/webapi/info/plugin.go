@@ -39,6 +39,7 @@ func Plugin() *node.Plugin {
 
 func configure(_ *node.Plugin) {
 	webapi.Server().GET("info", getInfo)
+	webapi.Server().GET("status/synced", getSyncedStatus)
 }
 
 // getInfo returns the info of the node
@@ -134,3 +135,11 @@ func getInfo(c echo.Context) error {
 		ManaDecay:               mana.Decay,
 	})
 }
+
+// getSyncedStatus returns the synced state of the node and the time of its last transition.
+func getSyncedStatus(c echo.Context) error {
+	return c.JSON(http.StatusOK, jsonmodels.SyncedStatusResponse{
+		Synced:         messagelayer.Tangle().Synced(),
+		LastTransition: messagelayer.Tangle().SyncedChangedTime(),
+	})
+}
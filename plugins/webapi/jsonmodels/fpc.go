@@ -0,0 +1,58 @@
+package jsonmodels
+
+import "github.com/iotaledger/goshimmer/packages/vote"
+
+// FPCOpinionsRequest is the request to query the node's current opinions on the given IDs.
+type FPCOpinionsRequest struct {
+	ConflictIDs  []string `json:"conflictIDs"`
+	TimestampIDs []string `json:"timestampIDs"`
+}
+
+// FPCOpinionsResponse contains the node's current opinions on the requested IDs.
+type FPCOpinionsResponse struct {
+	Conflicts  []FPCOpinion `json:"conflicts,omitempty"`
+	Timestamps []FPCOpinion `json:"timestamps,omitempty"`
+	Error      string       `json:"error,omitempty"`
+}
+
+// FPCOpinion contains the current opinion on a single ID.
+type FPCOpinion struct {
+	ID      string `json:"id"`
+	Opinion string `json:"opinion"`
+}
+
+// FPCRoundsResponse contains the stats of the most recent FPC rounds, newest first.
+type FPCRoundsResponse struct {
+	Rounds []*vote.RoundStats `json:"rounds,omitempty"`
+	Error  string             `json:"error,omitempty"`
+}
+
+// FPCRoundRequest is the request to execute a single FPC round.
+type FPCRoundRequest struct {
+	// Rand is the random number used in the round, a random one is generated if it is omitted.
+	Rand *float64 `json:"rand,omitempty"`
+}
+
+// FPCRoundResponse contains the stats of the executed FPC round.
+type FPCRoundResponse struct {
+	Round *vote.RoundStats `json:"round,omitempty"`
+	Error string           `json:"error,omitempty"`
+}
+
+// FPCVoteContextsResponse contains the active FPC vote contexts.
+type FPCVoteContextsResponse struct {
+	VoteContexts []FPCVoteContext `json:"voteContexts,omitempty"`
+	Error        string           `json:"error,omitempty"`
+}
+
+// FPCVoteContext contains the state of an active FPC vote context.
+type FPCVoteContext struct {
+	ID               string  `json:"id"`
+	Type             string  `json:"type"`
+	Rounds           int     `json:"rounds"`
+	Opinion          string  `json:"opinion"`
+	ProportionLiked  float64 `json:"proportionLiked"`
+	OwnWeight        float64 `json:"ownWeight"`
+	TotalWeights     float64 `json:"totalWeights"`
+	RespondedWeights float64 `json:"respondedWeights"`
+}
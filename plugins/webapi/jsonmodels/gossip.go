@@ -0,0 +1,41 @@
+package jsonmodels
+
+// GossipNeighborsResponse contains information about the connected gossip neighbors.
+type GossipNeighborsResponse struct {
+	Neighbors []GossipNeighbor `json:"neighbors"`
+	Error     string           `json:"error,omitempty"`
+}
+
+// GossipNeighbor contains the metrics of a single gossip neighbor.
+type GossipNeighbor struct {
+	ID               string `json:"id"`
+	Address          string `json:"address"`
+	ConnectionOrigin string `json:"connectionOrigin"`
+	BytesRead        uint64 `json:"bytesRead"`
+	BytesWritten     uint64 `json:"bytesWritten"`
+}
+
+// GossipNeighborResponse contains the detailed metrics of a single gossip neighbor.
+type GossipNeighborResponse struct {
+	Neighbor *GossipNeighborDetails `json:"neighbor,omitempty"`
+	Error    string                 `json:"error,omitempty"`
+}
+
+// GossipNeighborDetails contains the detailed metrics of a single gossip neighbor.
+type GossipNeighborDetails struct {
+	GossipNeighbor
+	ConnectionEstablished int64   `json:"connectionEstablished"`
+	PacketsSent           uint64  `json:"packetsSent"`
+	SendRate              float64 `json:"sendRate"`
+	BytesSaved            uint64  `json:"bytesSaved"`
+	ChecksumFailures      uint64  `json:"checksumFailures"`
+	DuplicateMessages     uint64  `json:"duplicateMessages"`
+	RequestsSent          uint64  `json:"requestsSent"`
+	RequestsReceived      uint64  `json:"requestsReceived"`
+	ResponsesSent         uint64  `json:"responsesSent"`
+	ResponsesReceived     uint64  `json:"responsesReceived"`
+	// LastHeartbeat is the time the last heartbeat was received, zero if none has been received yet.
+	LastHeartbeat int64 `json:"lastHeartbeat"`
+	// Disconnects are the times of the most recent disconnects of the neighbor, oldest first.
+	Disconnects []int64 `json:"disconnects"`
+}
@@ -51,3 +51,11 @@ type Mana struct {
 	Consensus          float64   `json:"consensus"`
 	ConsensusTimestamp time.Time `json:"consensusTimestamp"`
 }
+
+// SyncedStatusResponse holds the synced state of the node and the time of its last transition.
+type SyncedStatusResponse struct {
+	// whether the node is synchronized
+	Synced bool `json:"synced"`
+	// time of the last transition of the synced state, zero if it never changed
+	LastTransition time.Time `json:"lastTransition"`
+}
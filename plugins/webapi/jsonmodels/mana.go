@@ -18,6 +18,14 @@ type GetManaResponse struct {
 	ConsensusTimestamp int64   `json:"consensusTimestamp"`
 }
 
+// RefreshManaResponse is the response of a mana refresh request.
+type RefreshManaResponse struct {
+	Error          string `json:"error,omitempty"`
+	Timestamp      int64  `json:"timestamp"`
+	AccessNodes    int    `json:"accessNodes"`
+	ConsensusNodes int    `json:"consensusNodes"`
+}
+
 // GetAllManaResponse is the request to a getAllManaHandler request.
 type GetAllManaResponse struct {
 	Access             []mana.NodeStr `json:"access"`
@@ -112,7 +120,96 @@ type GetPercentileResponse struct {
 	ShortNodeID        string  `json:"shortNodeID"`
 	NodeID             string  `json:"nodeID"`
 	Access             float64 `json:"access"`
+	AccessWeighted     float64 `json:"accessWeighted"`
+	AccessTimestamp    int64   `json:"accessTimestamp"`
+	Consensus          float64 `json:"consensus"`
+	ConsensusWeighted  float64 `json:"consensusWeighted"`
+	ConsensusTimestamp int64   `json:"consensusTimestamp"`
+}
+
+// GetConcentrationResponse holds the concentration of the mana distribution.
+type GetConcentrationResponse struct {
+	Error               string  `json:"error,omitempty"`
+	Type                string  `json:"type"`
+	Threshold           float64 `json:"threshold"`
+	NakamotoCoefficient int     `json:"nakamotoCoefficient"`
+	GiniCoefficient     float64 `json:"giniCoefficient"`
+	Timestamp           int64   `json:"timestamp"`
+}
+
+// GetAggregateResponse holds the aggregates of a mana vector.
+type GetAggregateResponse struct {
+	Error       string  `json:"error,omitempty"`
+	Type        string  `json:"type"`
+	TotalMana   float64 `json:"totalMana"`
+	Nodes       int     `json:"nodes"`
+	Mean        float64 `json:"mean"`
+	Median      float64 `json:"median"`
+	OnlineMana  float64 `json:"onlineMana"`
+	OnlineNodes int     `json:"onlineNodes"`
+	Timestamp   int64   `json:"timestamp"`
+}
+
+// GetOwnManaResponse holds the mana and the mana ranks of the node itself.
+type GetOwnManaResponse struct {
+	Error              string  `json:"error,omitempty"`
+	ShortNodeID        string  `json:"shortNodeID"`
+	NodeID             string  `json:"nodeID"`
+	Access             float64 `json:"access"`
+	AccessRank         int     `json:"accessRank"`
 	AccessTimestamp    int64   `json:"accessTimestamp"`
 	Consensus          float64 `json:"consensus"`
+	ConsensusRank      int     `json:"consensusRank"`
 	ConsensusTimestamp int64   `json:"consensusTimestamp"`
 }
+
+// GetManaTimelineRequest is the request object of mana/timeline.
+type GetManaTimelineRequest struct {
+	NodeID string `json:"nodeID"`
+}
+
+// GetManaTimelineResponse holds the mana of a node at each retained mana snapshot, oldest first.
+type GetManaTimelineResponse struct {
+	Error       string              `json:"error,omitempty"`
+	ShortNodeID string              `json:"shortNodeID"`
+	NodeID      string              `json:"nodeID"`
+	Timeline    []ManaTimelinePoint `json:"timeline"`
+}
+
+// ManaTimelinePoint holds the mana of a node at the time of a mana snapshot.
+type ManaTimelinePoint struct {
+	Timestamp int64   `json:"timestamp"`
+	Access    float64 `json:"access"`
+	Consensus float64 `json:"consensus"`
+}
+
+// GetManaChangesResponse holds the nodes whose mana changed since a mana snapshot, sorted by descending delta.
+type GetManaChangesResponse struct {
+	Error     string       `json:"error,omitempty"`
+	Type      string       `json:"type"`
+	Since     int64        `json:"since"`
+	Timestamp int64        `json:"timestamp"`
+	Changes   []ManaChange `json:"changes"`
+}
+
+// ManaChange holds the current mana of a node and its change since a mana snapshot.
+type ManaChange struct {
+	ShortNodeID string  `json:"shortNodeID"`
+	NodeID      string  `json:"nodeID"`
+	Mana        float64 `json:"mana"`
+	Delta       float64 `json:"delta"`
+}
+
+// GetAllowedPledgeResponse holds the node IDs that access and consensus mana can be pledged to.
+type GetAllowedPledgeResponse struct {
+	Error     string         `json:"error,omitempty"`
+	Access    AllowedPledges `json:"access"`
+	Consensus AllowedPledges `json:"consensus"`
+}
+
+// AllowedPledges holds the node IDs that a type of mana can be pledged to. If AllowAll is set, mana can be pledged to
+// any node.
+type AllowedPledges struct {
+	AllowAll bool     `json:"allowAll"`
+	NodeIDs  []string `json:"nodeIDs"`
+}
@@ -0,0 +1,69 @@
+package mana
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+// onlineNodesFunc defines a function that returns the mana of the online nodes, e.g. messagelayer.GetOnlineNodes.
+type onlineNodesFunc func(manaType mana.Type) ([]mana.Node, time.Time, error)
+
+// aggregateHandler returns a handler that returns aggregates of the access or consensus mana vector: the total mana,
+// the number of nodes, the mean and median mana and the mana held by the online nodes.
+func aggregateHandler(getManaMap manaMapFunc, getOnlineNodes onlineNodesFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		manaType, err := manaTypeParam(c)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.GetAggregateResponse{Error: err.Error()})
+		}
+		manaMap, t, err := getManaMap(manaType)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.GetAggregateResponse{Error: err.Error()})
+		}
+		onlineNodes, _, err := getOnlineNodes(manaType)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.GetAggregateResponse{Error: err.Error()})
+		}
+
+		values := make([]float64, 0, len(manaMap))
+		var total float64
+		for _, value := range manaMap {
+			values = append(values, value)
+			total += value
+		}
+		var mean float64
+		if len(values) > 0 {
+			mean = total / float64(len(values))
+		}
+
+		return c.JSON(http.StatusOK, jsonmodels.GetAggregateResponse{
+			Type:        manaType.String(),
+			TotalMana:   total,
+			Nodes:       len(values),
+			Mean:        mean,
+			Median:      median(values),
+			OnlineMana:  totalMana(onlineNodes),
+			OnlineNodes: len(onlineNodes),
+			Timestamp:   t.Unix(),
+		})
+	}
+}
+
+// median returns the median of the given values, which are sorted in place. It is 0 if there are no values.
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sort.Float64s(values)
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+	return values[mid]
+}
@@ -0,0 +1,76 @@
+package mana
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+func TestAggregateHandler(t *testing.T) {
+	bmv, err := mana.NewBaseManaVector(mana.ConsensusMana)
+	require.NoError(t, err)
+	nodes := make([]identity.ID, 0)
+	for _, value := range []float64{40, 30, 20, 6, 4} {
+		id := identity.GenerateIdentity().ID()
+		nodes = append(nodes, id)
+		bmv.SetMana(id, &mana.ConsensusBaseMana{BaseMana1: value, EffectiveBaseMana1: value, LastUpdated: time.Now().Add(-time.Minute)})
+	}
+	getManaMap := func(manaType mana.Type, optionalUpdateTime ...time.Time) (mana.NodeMap, time.Time, error) {
+		assert.Equal(t, mana.ConsensusMana, manaType)
+		return bmv.GetManaMap(optionalUpdateTime...)
+	}
+	// only the first and the last node are online
+	getOnlineNodes := func(manaType mana.Type) ([]mana.Node, time.Time, error) {
+		manaMap, updateTime, err := getManaMap(manaType)
+		return []mana.Node{{ID: nodes[0], Mana: manaMap[nodes[0]]}, {ID: nodes[4], Mana: manaMap[nodes[4]]}}, updateTime, err
+	}
+
+	rec := serveAggregate(t, getManaMap, getOnlineNodes, "type=consensus")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var res jsonmodels.GetAggregateResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+
+	// the effective mana decays slightly until the request, hence the aggregates are compared with a tolerance
+	assert.Equal(t, "Consensus", res.Type)
+	assert.Equal(t, 5, res.Nodes)
+	assert.InDelta(t, 100, res.TotalMana, 1)
+	assert.InDelta(t, 20, res.Mean, 0.2)
+	assert.InDelta(t, 20, res.Median, 0.2)
+	assert.Equal(t, 2, res.OnlineNodes)
+	assert.InDelta(t, 44, res.OnlineMana, 0.5)
+
+	t.Run("invalid type", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serveAggregate(t, getManaMap, getOnlineNodes, "type=weighted").Code)
+	})
+	t.Run("online nodes not available", func(t *testing.T) {
+		getOnlineNodes := func(mana.Type) ([]mana.Node, time.Time, error) {
+			return nil, time.Now(), errors.New("query not allowed")
+		}
+		assert.Equal(t, http.StatusBadRequest, serveAggregate(t, getManaMap, getOnlineNodes, "").Code)
+	})
+}
+
+func TestMedian(t *testing.T) {
+	assert.Zero(t, median(nil))
+	assert.Equal(t, 3., median([]float64{5, 3, 1}))
+	assert.Equal(t, 2.5, median([]float64{4, 1, 3, 2}))
+}
+
+func serveAggregate(t *testing.T, getManaMap manaMapFunc, getOnlineNodes onlineNodesFunc, query string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/mana/aggregate?"+query, nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	require.NoError(t, aggregateHandler(getManaMap, getOnlineNodes)(c))
+	return rec
+}
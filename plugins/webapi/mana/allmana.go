@@ -14,6 +14,12 @@ import (
 
 // getAllManaHandler handles the request.
 func getAllManaHandler(c echo.Context) error {
+	normalized, err := normalizedParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetAllManaResponse{
+			Error: err.Error(),
+		})
+	}
 	t := time.Now()
 	access, tAccess, err := manaPlugin.GetManaMap(mana.AccessMana, t)
 	if err != nil {
@@ -21,6 +27,9 @@ func getAllManaHandler(c echo.Context) error {
 			Error: err.Error(),
 		})
 	}
+	if normalized {
+		access = access.Normalize()
+	}
 	accessList := access.ToNodeStrList()
 	sort.Slice(accessList, func(i, j int) bool {
 		return accessList[i].Mana > accessList[j].Mana
@@ -31,6 +40,9 @@ func getAllManaHandler(c echo.Context) error {
 			Error: err.Error(),
 		})
 	}
+	if normalized {
+		consensus = consensus.Normalize()
+	}
 	consensusList := consensus.ToNodeStrList()
 	sort.Slice(consensusList, func(i, j int) bool {
 		return consensusList[i].Mana > consensusList[j].Mana
@@ -0,0 +1,42 @@
+package mana
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+	manaPlugin "github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+// allowedPledgeFunc defines a function that returns the nodes the given mana type can be pledged to, e.g.
+// messagelayer.GetAllowedPledgeNodes.
+type allowedPledgeFunc func(manaType mana.Type) manaPlugin.AllowedPledge
+
+// allowedPledgeHandler returns a handler that returns the node IDs that access and consensus mana can be pledged to.
+// These are the same lists which are shown on the dashboard.
+func allowedPledgeHandler(getAllowedPledgeNodes allowedPledgeFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, jsonmodels.GetAllowedPledgeResponse{
+			Access:    allowedPledges(getAllowedPledgeNodes(mana.AccessMana)),
+			Consensus: allowedPledges(getAllowedPledgeNodes(mana.ConsensusMana)),
+		})
+	}
+}
+
+// converts the given allowed pledge nodes into their JSON model with the base58 encoded node IDs in sorted order.
+func allowedPledges(allowed manaPlugin.AllowedPledge) jsonmodels.AllowedPledges {
+	nodeIDs := make([]string, 0, allowed.Allowed.Size())
+	allowed.Allowed.ForEach(func(element interface{}) {
+		nodeIDs = append(nodeIDs, base58.Encode(element.(identity.ID).Bytes()))
+	})
+	sort.Strings(nodeIDs)
+	return jsonmodels.AllowedPledges{
+		AllowAll: !allowed.IsFilterEnabled,
+		NodeIDs:  nodeIDs,
+	}
+}
@@ -0,0 +1,58 @@
+package mana
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/iotaledger/hive.go/datastructure/set"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+	manaPlugin "github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+func TestAllowedPledgeHandler(t *testing.T) {
+	localID, otherID := identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID()
+	access := manaPlugin.AllowedPledge{IsFilterEnabled: true, Allowed: set.New(false)}
+	access.Allowed.Add(localID)
+	access.Allowed.Add(otherID)
+	consensus := manaPlugin.AllowedPledge{IsFilterEnabled: false, Allowed: set.New(false)}
+	consensus.Allowed.Add(localID)
+	getAllowedPledgeNodes := func(manaType mana.Type) manaPlugin.AllowedPledge {
+		if manaType == mana.AccessMana {
+			return access
+		}
+		return consensus
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/mana/allowedPledge", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	require.NoError(t, allowedPledgeHandler(getAllowedPledgeNodes)(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res jsonmodels.GetAllowedPledgeResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+
+	accessNodeIDs := []string{base58.Encode(localID.Bytes()), base58.Encode(otherID.Bytes())}
+	sort.Strings(accessNodeIDs)
+	assert.Equal(t, jsonmodels.GetAllowedPledgeResponse{
+		Access: jsonmodels.AllowedPledges{
+			AllowAll: false,
+			NodeIDs:  accessNodeIDs,
+		},
+		Consensus: jsonmodels.AllowedPledges{
+			AllowAll: true,
+			NodeIDs:  []string{base58.Encode(localID.Bytes())},
+		},
+	}, res)
+}
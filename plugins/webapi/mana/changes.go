@@ -0,0 +1,88 @@
+package mana
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+// changesHandler returns a handler that returns the nodes whose access or consensus mana changed since the mana
+// snapshot of the timeline taken at or before the requested unix timestamp, together with the change of their mana.
+// Nodes whose mana did not change by more than the optional threshold are omitted.
+func changesHandler(getManaMap manaMapFunc, getTimeline timelineFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		manaType, err := manaTypeParam(c)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.GetManaChangesResponse{Error: err.Error()})
+		}
+		since, err := strconv.ParseInt(c.QueryParam("since"), 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.GetManaChangesResponse{Error: fmt.Sprintf("invalid since timestamp: %s", err)})
+		}
+		var threshold float64
+		if thresholdStr := c.QueryParam("threshold"); thresholdStr != "" {
+			if threshold, err = strconv.ParseFloat(thresholdStr, 64); err != nil {
+				return c.JSON(http.StatusBadRequest, jsonmodels.GetManaChangesResponse{Error: err.Error()})
+			}
+			if threshold < 0 {
+				return c.JSON(http.StatusBadRequest, jsonmodels.GetManaChangesResponse{Error: fmt.Sprintf("threshold %f must not be negative", threshold)})
+			}
+		}
+
+		timeline := getTimeline()
+		if timeline == nil {
+			return c.JSON(http.StatusNotFound, jsonmodels.GetManaChangesResponse{Error: "mana timeline is disabled"})
+		}
+		pastManaMap, snapshotTime, ok := timeline.ManaMapAt(manaType, time.Unix(since, 0))
+		if !ok {
+			return c.JSON(http.StatusNotFound, jsonmodels.GetManaChangesResponse{Error: "no mana snapshot retained yet"})
+		}
+		manaMap, t, err := getManaMap(manaType)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.GetManaChangesResponse{Error: err.Error()})
+		}
+
+		res := jsonmodels.GetManaChangesResponse{
+			Type:      manaType.String(),
+			Since:     snapshotTime.Unix(),
+			Timestamp: t.Unix(),
+			Changes:   make([]jsonmodels.ManaChange, 0),
+		}
+		addChange := func(ID identity.ID, delta float64) {
+			if delta == 0 || math.Abs(delta) <= threshold {
+				return
+			}
+			res.Changes = append(res.Changes, jsonmodels.ManaChange{
+				ShortNodeID: ID.String(),
+				NodeID:      base58.Encode(ID.Bytes()),
+				Mana:        manaMap[ID],
+				Delta:       delta,
+			})
+		}
+		for ID, value := range manaMap {
+			addChange(ID, value-pastManaMap[ID])
+		}
+		// nodes which lost all of their mana
+		for ID, value := range pastManaMap {
+			if _, exists := manaMap[ID]; !exists {
+				addChange(ID, -value)
+			}
+		}
+		sort.Slice(res.Changes, func(i, j int) bool {
+			if res.Changes[i].Delta != res.Changes[j].Delta {
+				return res.Changes[i].Delta > res.Changes[j].Delta
+			}
+			return res.Changes[i].NodeID < res.Changes[j].NodeID
+		})
+		return c.JSON(http.StatusOK, res)
+	}
+}
@@ -0,0 +1,87 @@
+package mana
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+func TestChangesHandler(t *testing.T) {
+	gainedID, lostID, departedID, newID, unchangedID := identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID(),
+		identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID()
+
+	start := time.Unix(1614924235, 0)
+	timeline := mana.NewTimeline(3)
+	timeline.Add(start, map[mana.Type]mana.NodeMap{
+		mana.AccessMana: {gainedID: 10, lostID: 10, departedID: 5, unchangedID: 7},
+	})
+	timeline.Add(start.Add(time.Minute), map[mana.Type]mana.NodeMap{
+		mana.AccessMana: {gainedID: 20, lostID: 10, departedID: 5, unchangedID: 7},
+	})
+	now := start.Add(2 * time.Minute)
+	getManaMap := func(manaType mana.Type, _ ...time.Time) (mana.NodeMap, time.Time, error) {
+		assert.Equal(t, mana.AccessMana, manaType)
+		return mana.NodeMap{gainedID: 30, lostID: 8, newID: 1, unchangedID: 7}, now, nil
+	}
+
+	getChanges := func(getTimeline timelineFunc, query string) (jsonmodels.GetManaChangesResponse, int) {
+		req := httptest.NewRequest(http.MethodGet, "/mana/changes?"+query, nil)
+		rec := httptest.NewRecorder()
+		require.NoError(t, changesHandler(getManaMap, getTimeline)(echo.New().NewContext(req, rec)))
+
+		var res jsonmodels.GetManaChangesResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		return res, rec.Code
+	}
+	change := func(ID identity.ID, value, delta float64) jsonmodels.ManaChange {
+		return jsonmodels.ManaChange{ShortNodeID: ID.String(), NodeID: base58.Encode(ID.Bytes()), Mana: value, Delta: delta}
+	}
+	getTimeline := func() *mana.Timeline { return timeline }
+
+	// changes since the first snapshot, unchanged nodes are omitted
+	res, code := getChanges(getTimeline, "type=access&since="+strconv.FormatInt(start.Add(30*time.Second).Unix(), 10))
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, jsonmodels.GetManaChangesResponse{
+		Type:      "Access",
+		Since:     start.Unix(),
+		Timestamp: now.Unix(),
+		Changes: []jsonmodels.ManaChange{
+			change(gainedID, 30, 20),
+			change(newID, 1, 1),
+			change(lostID, 8, -2),
+			change(departedID, 0, -5),
+		},
+	}, res)
+
+	// changes since the second snapshot, exceeding the threshold
+	res, code = getChanges(getTimeline, "type=access&threshold=1&since="+strconv.FormatInt(start.Add(time.Minute).Unix(), 10))
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, start.Add(time.Minute).Unix(), res.Since)
+	assert.Equal(t, []jsonmodels.ManaChange{
+		change(gainedID, 30, 10),
+		change(lostID, 8, -2),
+		change(departedID, 0, -5),
+	}, res.Changes)
+
+	for _, query := range []string{"type=access", "type=access&since=yesterday", "type=weighted&since=0", "type=access&since=0&threshold=-1"} {
+		_, code = getChanges(getTimeline, query)
+		assert.Equal(t, http.StatusBadRequest, code, query)
+	}
+
+	_, code = getChanges(func() *mana.Timeline { return nil }, "type=access&since=0")
+	assert.Equal(t, http.StatusNotFound, code)
+	_, code = getChanges(func() *mana.Timeline { return mana.NewTimeline(1) }, "type=access&since=0")
+	assert.Equal(t, http.StatusNotFound, code)
+}
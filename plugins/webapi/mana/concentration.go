@@ -0,0 +1,106 @@
+package mana
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+// defaultConcentrationThreshold is the share of the total mana used for the Nakamoto coefficient, if none is requested.
+const defaultConcentrationThreshold = 0.5
+
+// highestManaNodesFractionFunc defines a function that returns the highest mana nodes owning the given share of the
+// total mana, e.g. messagelayer.GetHighestManaNodesFraction.
+type highestManaNodesFractionFunc func(manaType mana.Type, p float64) ([]mana.Node, time.Time, error)
+
+// concentrationHandler returns a handler that returns the concentration of the access or consensus mana distribution:
+// the Nakamoto coefficient, i.e. the minimum number of nodes owning at least the threshold share of the total mana,
+// and the Gini coefficient of the distribution.
+func concentrationHandler(getHighestManaNodesFraction highestManaNodesFractionFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		manaType, err := manaTypeParam(c)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.GetConcentrationResponse{Error: err.Error()})
+		}
+		threshold := defaultConcentrationThreshold
+		if thresholdStr := c.QueryParam("threshold"); thresholdStr != "" {
+			if threshold, err = strconv.ParseFloat(thresholdStr, 64); err != nil {
+				return c.JSON(http.StatusBadRequest, jsonmodels.GetConcentrationResponse{Error: err.Error()})
+			}
+			if threshold <= 0 || threshold > 1 {
+				return c.JSON(http.StatusBadRequest, jsonmodels.GetConcentrationResponse{Error: fmt.Sprintf("threshold %f must be within (0,1]", threshold)})
+			}
+		}
+
+		// a fraction of zero returns all nodes
+		allNodes, t, err := getHighestManaNodesFraction(manaType, 0)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.GetConcentrationResponse{Error: err.Error()})
+		}
+		var nakamoto int
+		if totalMana(allNodes) > 0 {
+			highestNodes, _, err := getHighestManaNodesFraction(manaType, threshold)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, jsonmodels.GetConcentrationResponse{Error: err.Error()})
+			}
+			nakamoto = len(highestNodes)
+		}
+
+		return c.JSON(http.StatusOK, jsonmodels.GetConcentrationResponse{
+			Type:                manaType.String(),
+			Threshold:           threshold,
+			NakamotoCoefficient: nakamoto,
+			GiniCoefficient:     giniCoefficient(allNodes),
+			Timestamp:           t.Unix(),
+		})
+	}
+}
+
+// manaTypeParam parses the "type" query parameter, which is either access or consensus. It defaults to consensus.
+func manaTypeParam(c echo.Context) (mana.Type, error) {
+	switch typeStr := c.QueryParam("type"); typeStr {
+	case "", "consensus":
+		return mana.ConsensusMana, nil
+	case "access":
+		return mana.AccessMana, nil
+	default:
+		return 0, fmt.Errorf("invalid mana type %q, must be access or consensus", typeStr)
+	}
+}
+
+func totalMana(nodes []mana.Node) (total float64) {
+	for _, node := range nodes {
+		total += node.Mana
+	}
+	return
+}
+
+// giniCoefficient returns the Gini coefficient of the mana of the given nodes. It is 0 if all nodes have the same mana
+// and approaches 1 if a single node owns all the mana.
+func giniCoefficient(nodes []mana.Node) float64 {
+	total := totalMana(nodes)
+	if len(nodes) == 0 || total <= 0 {
+		return 0
+	}
+
+	values := make([]float64, len(nodes))
+	for i, node := range nodes {
+		values[i] = node.Mana
+	}
+	sort.Float64s(values)
+
+	// G = 2*sum(i*x_i) / (n*sum(x_i)) - (n+1)/n, with x sorted ascending and i starting at 1
+	var weightedSum float64
+	for i, value := range values {
+		weightedSum += float64(i+1) * value
+	}
+	n := float64(len(values))
+	return 2*weightedSum/(n*total) - (n+1)/n
+}
@@ -0,0 +1,84 @@
+package mana
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+func TestConcentrationHandler(t *testing.T) {
+	// a skewed distribution in which a single node owns 70% of the mana
+	bmv, err := mana.NewBaseManaVector(mana.ConsensusMana)
+	require.NoError(t, err)
+	for _, value := range []float64{70, 10, 10, 5, 5} {
+		bmv.SetMana(identity.GenerateIdentity().ID(), &mana.ConsensusBaseMana{BaseMana1: value, EffectiveBaseMana1: value, LastUpdated: time.Now().Add(-time.Minute)})
+	}
+	getHighestManaNodesFraction := func(manaType mana.Type, p float64) ([]mana.Node, time.Time, error) {
+		assert.Equal(t, mana.ConsensusMana, manaType)
+		return bmv.GetHighestManaNodesFraction(p)
+	}
+
+	tests := []struct {
+		query    string
+		expected jsonmodels.GetConcentrationResponse
+	}{
+		{
+			query:    "type=consensus",
+			expected: jsonmodels.GetConcentrationResponse{Type: "Consensus", Threshold: 0.5, NakamotoCoefficient: 1, GiniCoefficient: 0.54},
+		},
+		{
+			query:    "threshold=0.8",
+			expected: jsonmodels.GetConcentrationResponse{Type: "Consensus", Threshold: 0.8, NakamotoCoefficient: 2, GiniCoefficient: 0.54},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			rec := serveConcentration(t, getHighestManaNodesFraction, test.query)
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var res jsonmodels.GetConcentrationResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.InDelta(t, test.expected.GiniCoefficient, res.GiniCoefficient, 1e-9)
+			res.GiniCoefficient, res.Timestamp = test.expected.GiniCoefficient, 0
+			assert.Equal(t, test.expected, res)
+		})
+	}
+
+	for _, query := range []string{"type=weighted", "threshold=0", "threshold=1.5", "threshold=half"} {
+		t.Run(query, func(t *testing.T) {
+			assert.Equal(t, http.StatusBadRequest, serveConcentration(t, getHighestManaNodesFraction, query).Code)
+		})
+	}
+}
+
+func TestGiniCoefficient(t *testing.T) {
+	nodes := func(values ...float64) (result []mana.Node) {
+		for _, value := range values {
+			result = append(result, mana.Node{ID: identity.GenerateIdentity().ID(), Mana: value})
+		}
+		return
+	}
+	assert.Zero(t, giniCoefficient(nil))
+	assert.Zero(t, giniCoefficient(nodes(0, 0)))
+	assert.InDelta(t, 0, giniCoefficient(nodes(10, 10, 10, 10)), 1e-9)
+	// a single node owning all the mana out of n nodes yields (n-1)/n
+	assert.InDelta(t, 0.75, giniCoefficient(nodes(0, 0, 0, 100)), 1e-9)
+}
+
+func serveConcentration(t *testing.T, getHighestManaNodesFraction highestManaNodesFractionFunc, query string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/mana/concentration?"+query, nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	require.NoError(t, concentrationHandler(getHighestManaNodesFraction)(c))
+	return rec
+}
@@ -0,0 +1,53 @@
+package mana
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
+	"golang.org/x/xerrors"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+// manaMapFunc defines a function that returns the mana map of the given mana type, e.g. messagelayer.GetManaMap.
+type manaMapFunc func(manaType mana.Type, optionalUpdateTime ...time.Time) (mana.NodeMap, time.Time, error)
+
+// ownManaHandler returns a handler that returns the access and consensus mana of the node with the given ID together
+// with its ranks. Nodes without mana have zero mana and rank 0.
+func ownManaHandler(localID func() identity.ID, getManaMap manaMapFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ID := localID()
+		t := time.Now()
+		access, tAccess, err := getManaMap(mana.AccessMana, t)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.GetOwnManaResponse{Error: err.Error()})
+		}
+		accessRank, err := access.GetRank(ID)
+		if err != nil && !xerrors.Is(err, mana.ErrNodeNotFoundInBaseManaVector) {
+			return c.JSON(http.StatusBadRequest, jsonmodels.GetOwnManaResponse{Error: err.Error()})
+		}
+		consensus, tConsensus, err := getManaMap(mana.ConsensusMana, t)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.GetOwnManaResponse{Error: err.Error()})
+		}
+		consensusRank, err := consensus.GetRank(ID)
+		if err != nil && !xerrors.Is(err, mana.ErrNodeNotFoundInBaseManaVector) {
+			return c.JSON(http.StatusBadRequest, jsonmodels.GetOwnManaResponse{Error: err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, jsonmodels.GetOwnManaResponse{
+			ShortNodeID:        ID.String(),
+			NodeID:             base58.Encode(ID.Bytes()),
+			Access:             access[ID],
+			AccessRank:         accessRank,
+			AccessTimestamp:    tAccess.Unix(),
+			Consensus:          consensus[ID],
+			ConsensusRank:      consensusRank,
+			ConsensusTimestamp: tConsensus.Unix(),
+		})
+	}
+}
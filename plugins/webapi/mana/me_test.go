@@ -0,0 +1,50 @@
+package mana
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+func TestOwnManaHandler(t *testing.T) {
+	localID, otherID := identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID()
+	manaMaps := map[mana.Type]mana.NodeMap{
+		mana.AccessMana:    {localID: 10, otherID: 20},
+		mana.ConsensusMana: {localID: 30, otherID: 20},
+	}
+	updateTime := time.Unix(1614924295, 0)
+	getManaMap := func(manaType mana.Type, _ ...time.Time) (mana.NodeMap, time.Time, error) {
+		return manaMaps[manaType], updateTime, nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/mana/me", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	require.NoError(t, ownManaHandler(func() identity.ID { return localID }, getManaMap)(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res jsonmodels.GetOwnManaResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, jsonmodels.GetOwnManaResponse{
+		ShortNodeID:        localID.String(),
+		NodeID:             base58.Encode(localID.Bytes()),
+		Access:             10,
+		AccessRank:         2,
+		AccessTimestamp:    updateTime.Unix(),
+		Consensus:          30,
+		ConsensusRank:      1,
+		ConsensusTimestamp: updateTime.Unix(),
+	}, res)
+}
@@ -27,10 +27,25 @@ func nHighestHandler(c echo.Context, manaType mana.Type) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, jsonmodels.GetNHighestResponse{Error: err.Error()})
 	}
+	normalized, err := normalizedParam(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetNHighestResponse{Error: err.Error()})
+	}
 	highestNodes, t, err := manaPlugin.GetHighestManaNodes(manaType, uint(number))
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, jsonmodels.GetNHighestResponse{Error: err.Error()})
 	}
+	if normalized {
+		// the shares are relative to the total mana of all nodes, not only the highest ones
+		manaMap, _, err := manaPlugin.GetManaMap(manaType, t)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.GetNHighestResponse{Error: err.Error()})
+		}
+		shares := manaMap.Normalize()
+		for i := range highestNodes {
+			highestNodes[i].Mana = shares[highestNodes[i].ID]
+		}
+	}
 	var res []mana.NodeStr
 	for _, n := range highestNodes {
 		res = append(res, n.ToNodeStr())
@@ -0,0 +1,17 @@
+package mana
+
+import (
+	"strconv"
+
+	"github.com/labstack/echo"
+)
+
+// normalizedParam returns whether the normalized query parameter requests the mana values to be returned as shares of
+// the total mana instead of absolute values.
+func normalizedParam(c echo.Context) (bool, error) {
+	param := c.QueryParam("normalized")
+	if param == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(param)
+}
@@ -0,0 +1,16 @@
+package mana
+
+import (
+	"time"
+
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	// CfgPercentileCacheTTL defines the config flag of the time the mana maps of the percentile endpoint are reused.
+	CfgPercentileCacheTTL = "webapi.mana.percentileCacheTTL"
+)
+
+func init() {
+	flag.Duration(CfgPercentileCacheTTL, 5*time.Second, "the time the mana maps computed for the percentile endpoint are reused (0 disables the cache)")
+}
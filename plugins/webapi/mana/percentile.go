@@ -2,62 +2,127 @@ package mana
 
 import (
 	"net/http"
+	"sync"
 	"time"
 
+	"github.com/iotaledger/hive.go/identity"
 	"github.com/labstack/echo"
 	"github.com/mr-tron/base58"
 	"golang.org/x/xerrors"
 
 	"github.com/iotaledger/goshimmer/packages/mana"
-	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
-	manaPlugin "github.com/iotaledger/goshimmer/plugins/messagelayer"
 	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
 )
 
-// getPercentileHandler handles the request.
-func getPercentileHandler(c echo.Context) error {
-	var request jsonmodels.GetPercentileRequest
-	if err := c.Bind(&request); err != nil {
-		return c.JSON(http.StatusBadRequest, jsonmodels.GetPercentileResponse{Error: err.Error()})
-	}
-	ID, err := mana.IDFromStr(request.NodeID)
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, jsonmodels.GetPercentileResponse{Error: err.Error()})
+// manaMapCache reuses the access and consensus mana maps computed for a timestamp for all requests whose timestamp
+// truncated to the TTL is the same.
+type manaMapCache struct {
+	ttl        time.Duration
+	getManaMap manaMapFunc
+
+	mu sync.Mutex
+	// the truncated timestamp the cached maps were computed for.
+	key                 time.Time
+	access, consensus   mana.NodeMap
+	tAccess, tConsensus time.Time
+}
+
+// newManaMapCache creates a new manaMapCache. A TTL of zero disables the cache.
+func newManaMapCache(ttl time.Duration, getManaMap manaMapFunc) *manaMapCache {
+	return &manaMapCache{
+		ttl:        ttl,
+		getManaMap: getManaMap,
 	}
-	if request.NodeID == "" {
-		ID = local.GetInstance().ID()
+}
+
+// get returns the access and consensus mana maps for the given timestamp together with their update times.
+func (m *manaMapCache) get(t time.Time) (access mana.NodeMap, tAccess time.Time, consensus mana.NodeMap, tConsensus time.Time, err error) {
+	if m.ttl <= 0 {
+		return m.compute(t)
 	}
-	t := time.Now()
-	access, tAccess, err := manaPlugin.GetManaMap(mana.AccessMana, t)
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, jsonmodels.GetPercentileResponse{Error: err.Error()})
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := t.Truncate(m.ttl)
+	if m.access != nil && m.key.Equal(key) {
+		return m.access, m.tAccess, m.consensus, m.tConsensus, nil
 	}
-	accessPercentile, err := access.GetPercentile(ID)
-	if err != nil {
-		if xerrors.Is(err, mana.ErrNodeNotFoundInBaseManaVector) {
-			accessPercentile = 0
-		} else {
-			return c.JSON(http.StatusBadRequest, jsonmodels.GetManaResponse{Error: err.Error()})
-		}
+	if access, tAccess, consensus, tConsensus, err = m.compute(t); err != nil {
+		return
 	}
-	consensus, tConsensus, err := manaPlugin.GetManaMap(mana.ConsensusMana, t)
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, jsonmodels.GetPercentileResponse{Error: err.Error()})
+	m.key, m.access, m.tAccess, m.consensus, m.tConsensus = key, access, tAccess, consensus, tConsensus
+	return
+}
+
+func (m *manaMapCache) compute(t time.Time) (access mana.NodeMap, tAccess time.Time, consensus mana.NodeMap, tConsensus time.Time, err error) {
+	if access, tAccess, err = m.getManaMap(mana.AccessMana, t); err != nil {
+		return
 	}
-	consensusPercentile, err := consensus.GetPercentile(ID)
-	if err != nil {
-		if xerrors.Is(err, mana.ErrNodeNotFoundInBaseManaVector) {
-			consensusPercentile = 0
-		} else {
-			return c.JSON(http.StatusBadRequest, jsonmodels.GetManaResponse{Error: err.Error()})
+	consensus, tConsensus, err = m.getManaMap(mana.ConsensusMana, t)
+	return
+}
+
+// percentileHandler returns a handler that returns the access and consensus mana percentiles of the requested node,
+// or of the node with the given local ID if none is requested. The mana maps are taken from the given cache.
+func percentileHandler(localID func() identity.ID, cache *manaMapCache) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var request jsonmodels.GetPercentileRequest
+		if err := c.Bind(&request); err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.GetPercentileResponse{Error: err.Error()})
+		}
+		ID, err := mana.IDFromStr(request.NodeID)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.GetPercentileResponse{Error: err.Error()})
+		}
+		if request.NodeID == "" {
+			ID = localID()
+		}
+		access, tAccess, consensus, tConsensus, err := cache.get(time.Now())
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.GetPercentileResponse{Error: err.Error()})
+		}
+		accessPercentile, err := access.GetPercentile(ID)
+		if err != nil {
+			if xerrors.Is(err, mana.ErrNodeNotFoundInBaseManaVector) {
+				accessPercentile = 0
+			} else {
+				return c.JSON(http.StatusBadRequest, jsonmodels.GetManaResponse{Error: err.Error()})
+			}
+		}
+		accessWeighted, err := access.GetManaWeightedPercentile(ID)
+		if err != nil {
+			if xerrors.Is(err, mana.ErrNodeNotFoundInBaseManaVector) {
+				accessWeighted = 0
+			} else {
+				return c.JSON(http.StatusBadRequest, jsonmodels.GetManaResponse{Error: err.Error()})
+			}
+		}
+		consensusPercentile, err := consensus.GetPercentile(ID)
+		if err != nil {
+			if xerrors.Is(err, mana.ErrNodeNotFoundInBaseManaVector) {
+				consensusPercentile = 0
+			} else {
+				return c.JSON(http.StatusBadRequest, jsonmodels.GetManaResponse{Error: err.Error()})
+			}
+		}
+		consensusWeighted, err := consensus.GetManaWeightedPercentile(ID)
+		if err != nil {
+			if xerrors.Is(err, mana.ErrNodeNotFoundInBaseManaVector) {
+				consensusWeighted = 0
+			} else {
+				return c.JSON(http.StatusBadRequest, jsonmodels.GetManaResponse{Error: err.Error()})
+			}
 		}
+		return c.JSON(http.StatusOK, jsonmodels.GetPercentileResponse{
+			ShortNodeID:        ID.String(),
+			NodeID:             base58.Encode(ID.Bytes()),
+			Access:             accessPercentile,
+			AccessWeighted:     accessWeighted,
+			AccessTimestamp:    tAccess.Unix(),
+			Consensus:          consensusPercentile,
+			ConsensusWeighted:  consensusWeighted,
+			ConsensusTimestamp: tConsensus.Unix(),
+		})
 	}
-	return c.JSON(http.StatusOK, jsonmodels.GetPercentileResponse{
-		ShortNodeID:        ID.String(),
-		NodeID:             base58.Encode(ID.Bytes()),
-		Access:             accessPercentile,
-		AccessTimestamp:    tAccess.Unix(),
-		Consensus:          consensusPercentile,
-		ConsensusTimestamp: tConsensus.Unix(),
-	})
 }
@@ -0,0 +1,60 @@
+package mana
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+func TestPercentileHandlerCache(t *testing.T) {
+	localID, otherID := identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID()
+	manaMaps := map[mana.Type]mana.NodeMap{
+		mana.AccessMana:    {localID: 10, otherID: 20},
+		mana.ConsensusMana: {localID: 30, otherID: 20},
+	}
+	computations := make(map[mana.Type]int)
+	getManaMap := func(manaType mana.Type, optionalUpdateTime ...time.Time) (mana.NodeMap, time.Time, error) {
+		computations[manaType]++
+		return manaMaps[manaType], optionalUpdateTime[0], nil
+	}
+	cache := newManaMapCache(time.Hour, getManaMap)
+	handler := percentileHandler(func() identity.ID { return localID }, cache)
+
+	getPercentile := func() jsonmodels.GetPercentileResponse {
+		req := httptest.NewRequest(http.MethodGet, "/mana/percentile", nil)
+		rec := httptest.NewRecorder()
+		require.NoError(t, handler(echo.New().NewContext(req, rec)))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var res jsonmodels.GetPercentileResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		return res
+	}
+
+	first := getPercentile()
+	second := getPercentile()
+	assert.Equal(t, first, second)
+	assert.Equal(t, map[mana.Type]int{mana.AccessMana: 1, mana.ConsensusMana: 1}, computations)
+
+	// a timestamp in a different TTL window bypasses the cache
+	_, _, _, _, err := cache.get(time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, map[mana.Type]int{mana.AccessMana: 2, mana.ConsensusMana: 2}, computations)
+
+	// without a TTL, the maps are computed for every request
+	cache = newManaMapCache(0, getManaMap)
+	handler = percentileHandler(func() identity.ID { return localID }, cache)
+	getPercentile()
+	getPercentile()
+	assert.Equal(t, map[mana.Type]int{mana.AccessMana: 4, mana.ConsensusMana: 4}, computations)
+}
@@ -3,8 +3,12 @@ package mana
 import (
 	"sync"
 
+	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/node"
 
+	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
+	"github.com/iotaledger/goshimmer/plugins/config"
+	manaPlugin "github.com/iotaledger/goshimmer/plugins/messagelayer"
 	"github.com/iotaledger/goshimmer/plugins/webapi"
 )
 
@@ -30,11 +34,21 @@ func configure(_ *node.Plugin) {
 	webapi.Server().GET("mana/all", getAllManaHandler)
 	webapi.Server().GET("/mana/access/nhighest", getNHighestAccessHandler)
 	webapi.Server().GET("/mana/consensus/nhighest", getNHighestConsensusHandler)
-	webapi.Server().GET("/mana/percentile", getPercentileHandler)
+	webapi.Server().GET("/mana/percentile", percentileHandler(
+		func() identity.ID { return local.GetInstance().ID() },
+		newManaMapCache(config.Node().Duration(CfgPercentileCacheTTL), manaPlugin.GetManaMap),
+	))
+	webapi.Server().GET("/mana/concentration", concentrationHandler(manaPlugin.GetHighestManaNodesFraction))
+	webapi.Server().GET("/mana/aggregate", aggregateHandler(manaPlugin.GetManaMap, manaPlugin.GetOnlineNodes))
+	webapi.Server().GET("/mana/allowedPledge", allowedPledgeHandler(manaPlugin.GetAllowedPledgeNodes))
+	webapi.Server().GET("/mana/timeline", timelineHandler(func() identity.ID { return local.GetInstance().ID() }, manaPlugin.ManaTimeline))
+	webapi.Server().GET("/mana/changes", changesHandler(manaPlugin.GetManaMap, manaPlugin.ManaTimeline))
+	webapi.Server().GET("/mana/me", ownManaHandler(func() identity.ID { return local.GetInstance().ID() }, manaPlugin.GetManaMap))
 	webapi.Server().GET("/mana/access/online", getOnlineAccessHandler)
 	webapi.Server().GET("/mana/consensus/online", getOnlineConsensusHandler)
 	webapi.Server().GET("/mana/pending", GetPendingMana)
 	webapi.Server().GET("/mana/consensus/past", getPastConsensusManaVectorHandler)
 	webapi.Server().GET("/mana/consensus/logs", getEventLogsHandler)
 	webapi.Server().GET("/mana/consensus/metadata", getPastConsensusVectorMetadataHandler)
+	webapi.Server().POST("/mana/refresh", refreshHandler(manaPlugin.UpdateAllManaVectors, webapi.BasicAuthConfigured()))
 }
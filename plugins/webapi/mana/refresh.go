@@ -0,0 +1,35 @@
+package mana
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+// errRefreshForbidden is returned if the mana vectors may not be refreshed, as the web API is not protected by basic auth.
+var errRefreshForbidden = errors.New("refreshing the mana vectors requires basic auth to be enabled with non-default credentials")
+
+// refreshHandler returns the handler which updates the mana vectors to the current time using updateFunc. The request
+// is refused unless authorized is set, i.e. unless the web API is protected by basic auth with non-default credentials.
+func refreshHandler(updateFunc func(t time.Time) (map[mana.Type]int, error), authorized bool) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !authorized {
+			return c.JSON(http.StatusForbidden, jsonmodels.RefreshManaResponse{Error: errRefreshForbidden.Error()})
+		}
+		t := time.Now()
+		updated, err := updateFunc(t)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.RefreshManaResponse{Error: err.Error()})
+		}
+		return c.JSON(http.StatusOK, jsonmodels.RefreshManaResponse{
+			Timestamp:      t.Unix(),
+			AccessNodes:    updated[mana.AccessMana],
+			ConsensusNodes: updated[mana.ConsensusMana],
+		})
+	}
+}
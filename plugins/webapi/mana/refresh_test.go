@@ -0,0 +1,88 @@
+package mana
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+func TestRefreshHandler(t *testing.T) {
+	lastUpdated := time.Now().Add(-6 * time.Hour)
+	nodeID := identity.GenerateIdentity().ID()
+
+	vectors := make(map[mana.Type]mana.BaseManaVector)
+	vectors[mana.AccessMana], _ = mana.NewBaseManaVector(mana.AccessMana)
+	vectors[mana.AccessMana].SetMana(nodeID, &mana.AccessBaseMana{
+		BaseMana2:          10,
+		EffectiveBaseMana2: 10,
+		LastUpdated:        lastUpdated,
+	})
+	vectors[mana.ConsensusMana], _ = mana.NewBaseManaVector(mana.ConsensusMana)
+	vectors[mana.ConsensusMana].SetMana(nodeID, &mana.ConsensusBaseMana{
+		BaseMana1:          10,
+		EffectiveBaseMana1: 0,
+		LastUpdated:        lastUpdated,
+	})
+	updateFunc := func(t time.Time) (map[mana.Type]int, error) {
+		updated := make(map[mana.Type]int)
+		for manaType, bmv := range vectors {
+			if err := bmv.UpdateAll(t); err != nil {
+				return nil, err
+			}
+			updated[manaType] = bmv.Size()
+		}
+		return updated, nil
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/mana/refresh", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	require.NoError(t, refreshHandler(updateFunc, true)(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res jsonmodels.RefreshManaResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Empty(t, res.Error)
+	assert.Equal(t, 1, res.AccessNodes)
+	assert.Equal(t, 1, res.ConsensusNodes)
+
+	// the stored values are updated up to the applied timestamp, with the default half life of 6 hours
+	vectors[mana.AccessMana].ForEach(func(id identity.ID, bm mana.BaseMana) bool {
+		assert.Equal(t, res.Timestamp, bm.LastUpdate().Unix())
+		assert.InDelta(t, 5.0, bm.BaseValue(), 0.01)
+		return true
+	})
+	vectors[mana.ConsensusMana].ForEach(func(id identity.ID, bm mana.BaseMana) bool {
+		assert.Equal(t, res.Timestamp, bm.LastUpdate().Unix())
+		assert.InDelta(t, 5.0, bm.EffectiveValue(), 0.01)
+		return true
+	})
+}
+
+func TestRefreshHandlerForbidden(t *testing.T) {
+	updateFunc := func(t time.Time) (map[mana.Type]int, error) {
+		panic("the mana vectors must not be updated")
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/mana/refresh", nil)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	require.NoError(t, refreshHandler(updateFunc, false)(c))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	var res jsonmodels.RefreshManaResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, errRefreshForbidden.Error(), res.Error)
+}
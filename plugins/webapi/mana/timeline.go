@@ -0,0 +1,53 @@
+package mana
+
+import (
+	"net/http"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+// timelineFunc defines a function that returns the mana timeline, or nil if it is disabled, e.g.
+// messagelayer.ManaTimeline.
+type timelineFunc func() *mana.Timeline
+
+// timelineHandler returns a handler that returns the access and consensus mana of the requested node, or of the node
+// with the given local ID if none is requested, at each snapshot retained in the mana timeline.
+func timelineHandler(localID func() identity.ID, getTimeline timelineFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var request jsonmodels.GetManaTimelineRequest
+		if err := c.Bind(&request); err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.GetManaTimelineResponse{Error: err.Error()})
+		}
+		ID, err := mana.IDFromStr(request.NodeID)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.GetManaTimelineResponse{Error: err.Error()})
+		}
+		if request.NodeID == "" {
+			ID = localID()
+		}
+		timeline := getTimeline()
+		if timeline == nil {
+			return c.JSON(http.StatusNotFound, jsonmodels.GetManaTimelineResponse{Error: "mana timeline is disabled"})
+		}
+
+		points := timeline.NodeMana(ID)
+		res := jsonmodels.GetManaTimelineResponse{
+			ShortNodeID: ID.String(),
+			NodeID:      base58.Encode(ID.Bytes()),
+			Timeline:    make([]jsonmodels.ManaTimelinePoint, len(points)),
+		}
+		for i, point := range points {
+			res.Timeline[i] = jsonmodels.ManaTimelinePoint{
+				Timestamp: point.Time.Unix(),
+				Access:    point.Mana[mana.AccessMana],
+				Consensus: point.Mana[mana.ConsensusMana],
+			}
+		}
+		return c.JSON(http.StatusOK, res)
+	}
+}
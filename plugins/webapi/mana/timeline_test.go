@@ -0,0 +1,65 @@
+package mana
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels"
+)
+
+func TestTimelineHandler(t *testing.T) {
+	localID, otherID := identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID()
+	timeline := mana.NewTimeline(2)
+	start := time.Unix(1614924235, 0)
+	for i := 0; i < 3; i++ {
+		timeline.Add(start.Add(time.Duration(i)*time.Minute), map[mana.Type]mana.NodeMap{
+			mana.AccessMana:    {localID: float64(i), otherID: float64(10 * i)},
+			mana.ConsensusMana: {localID: float64(2 * i)},
+		})
+	}
+
+	getTimeline := func(getTimeline timelineFunc, query string) (jsonmodels.GetManaTimelineResponse, int) {
+		req := httptest.NewRequest(http.MethodGet, "/mana/timeline"+query, nil)
+		rec := httptest.NewRecorder()
+		handler := timelineHandler(func() identity.ID { return localID }, getTimeline)
+		require.NoError(t, handler(echo.New().NewContext(req, rec)))
+
+		var res jsonmodels.GetManaTimelineResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		return res, rec.Code
+	}
+
+	// without a node ID, the timeline of the local node is returned
+	res, code := getTimeline(func() *mana.Timeline { return timeline }, "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, base58.Encode(localID.Bytes()), res.NodeID)
+	assert.Equal(t, []jsonmodels.ManaTimelinePoint{
+		{Timestamp: start.Add(time.Minute).Unix(), Access: 1, Consensus: 2},
+		{Timestamp: start.Add(2 * time.Minute).Unix(), Access: 2, Consensus: 4},
+	}, res.Timeline)
+
+	res, code = getTimeline(func() *mana.Timeline { return timeline }, "?nodeID="+base58.Encode(otherID.Bytes()))
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, otherID.String(), res.ShortNodeID)
+	assert.Equal(t, []jsonmodels.ManaTimelinePoint{
+		{Timestamp: start.Add(time.Minute).Unix(), Access: 10, Consensus: 0},
+		{Timestamp: start.Add(2 * time.Minute).Unix(), Access: 20, Consensus: 0},
+	}, res.Timeline)
+
+	_, code = getTimeline(func() *mana.Timeline { return timeline }, "?nodeID=invalid")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	res, code = getTimeline(func() *mana.Timeline { return nil }, "")
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.NotEmpty(t, res.Error)
+}
@@ -13,11 +13,16 @@ const (
 	CfgBasicAuthUsername = "webapi.basic_auth.username"
 	// CfgBasicAuthPassword defines the config flag of the webapi basic auth password.
 	CfgBasicAuthPassword = "webapi.basic_auth.password"
+
+	// defaultBasicAuthUsername is the default webapi basic auth username.
+	defaultBasicAuthUsername = "goshimmer"
+	// defaultBasicAuthPassword is the default webapi basic auth password.
+	defaultBasicAuthPassword = "goshimmer"
 )
 
 func init() {
 	flag.String(CfgBindAddress, "127.0.0.1:8080", "the bind address for the web API")
 	flag.Bool(CfgBasicAuthEnabled, false, "whether to enable HTTP basic auth")
-	flag.String(CfgBasicAuthUsername, "goshimmer", "HTTP basic auth username")
-	flag.String(CfgBasicAuthPassword, "goshimmer", "HTTP basic auth password")
+	flag.String(CfgBasicAuthUsername, defaultBasicAuthUsername, "HTTP basic auth username")
+	flag.String(CfgBasicAuthPassword, defaultBasicAuthPassword, "HTTP basic auth password")
 }
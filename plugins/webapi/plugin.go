@@ -40,6 +40,14 @@ func Plugin() *node.Plugin {
 	return plugin
 }
 
+// BasicAuthConfigured returns whether basic auth is enabled for the web API with credentials other than the default
+// ones, i.e. whether its routes are protected from anyone who can reach the web API.
+func BasicAuthConfigured() bool {
+	return config.Node().Bool(CfgBasicAuthEnabled) &&
+		(config.Node().String(CfgBasicAuthUsername) != defaultBasicAuthUsername ||
+			config.Node().String(CfgBasicAuthPassword) != defaultBasicAuthPassword)
+}
+
 // Server gets the server instance.
 func Server() *echo.Echo {
 	serverOnce.Do(func() {
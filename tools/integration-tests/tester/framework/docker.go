@@ -110,7 +110,13 @@ func (d *DockerContainer) CreateGoShimmerPeer(config GoShimmerConfig) error {
 				if !config.Faucet {
 					return ""
 				}
-				return fmt.Sprintf("--faucet.seed=%s", genesisSeedBase58)
+				return fmt.Sprintf("--faucet.seed=%s", config.FaucetSeed)
+			}(),
+			func() string {
+				if !config.Faucet {
+					return ""
+				}
+				return fmt.Sprintf("--faucet.genesisTokenAmount=%d", config.FaucetGenesisTokenAmount)
 			}(),
 			fmt.Sprintf("--faucet.tokensPerRequest=%d", ParaFaucetTokensPerRequest),
 			fmt.Sprintf("--messageLayer.snapshot.file=%s", config.SnapshotFilePath),
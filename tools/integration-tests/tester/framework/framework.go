@@ -72,6 +72,13 @@ func (f *Framework) CreateNetwork(name string, peers int, minimumNeighbors int,
 		return nil, err
 	}
 
+	if config.faucetCount() > 1 {
+		err = network.createFaucetsSnapshot(config.faucetCount())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// create peers/GoShimmer nodes
 	for i := 0; i < peers; i++ {
 		config := GoShimmerConfig{
@@ -94,7 +101,7 @@ func (f *Framework) CreateNetwork(name string, peers int, minimumNeighbors int,
 				}
 				return ""
 			}(i),
-			Faucet: config.Faucet && i == 0,
+			Faucet: i < config.faucetCount(),
 			Mana: func(i int) bool {
 				if ParaManaOnEveryNode {
 					return true
@@ -131,6 +138,13 @@ func (f *Framework) CreateNetworkWithPartitions(name string, peers, partitions,
 		return nil, err
 	}
 
+	if config.faucetCount() > 1 {
+		err = network.createFaucetsSnapshot(config.faucetCount())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// block all traffic from/to entry node
 	pumbaEntryNodeName := network.namePrefix(containerNameEntryNode) + containerNameSuffixPumba
 	pumbaEntryNode, err := network.createPumba(
@@ -165,7 +179,7 @@ func (f *Framework) CreateNetworkWithPartitions(name string, peers, partitions,
 				}
 				return ""
 			}(i),
-			Faucet:                     config.Faucet && i == 0,
+			Faucet:                     i < config.faucetCount(),
 			FPCRoundInterval:           ParaFPCRoundInterval,
 			FPCTotalRoundsFinalization: ParaFPCTotalRoundsFinalization,
 			WaitForStatement:           ParaWaitForStatement,
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
+	"os"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -14,6 +15,7 @@ import (
 	"github.com/mr-tron/base58"
 
 	walletseed "github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 )
 
 // Network represents a complete GoShimmer network within Docker.
@@ -25,11 +27,20 @@ type Network struct {
 	peers  []*Peer
 	tester *DockerContainer
 
+	// the peers which were dropped from the network and can be added back.
+	droppedPeers []*Peer
+
 	entryNode         *DockerContainer
 	entryNodeIdentity *identity.Identity
 
 	partitions []*Partition
 
+	// the snapshot the peers start with, if it differs from the default one
+	snapshotFilePath string
+	// the seeds of the faucets funded by the snapshot and the amount of tokens each of them holds
+	faucetSeeds        []*walletseed.Seed
+	faucetTokenAmounts []uint64
+
 	dockerClient *client.Client
 }
 
@@ -84,10 +95,58 @@ func (n *Network) createEntryNode() error {
 	return nil
 }
 
+// createFaucetsSnapshot creates a snapshot which splits the genesis tokens between the given number of faucets.
+// Every faucet gets its own seed, the first one keeps the genesis seed.
+func (n *Network) createFaucetsSnapshot(faucets int) error {
+	if faucets > MaxFaucets {
+		return fmt.Errorf("a network can have at most %d faucets", MaxFaucets)
+	}
+
+	snapshot := ledgerstate.Snapshot{
+		ledgerstate.GenesisTransactionID: make(map[ledgerstate.Address]*ledgerstate.ColoredBalances),
+	}
+	for i := 0; i < faucets; i++ {
+		seed := walletseed.NewSeed()
+		tokenAmount := uint64(GenesisTokenAmount / faucets)
+		if i == 0 {
+			// the first faucet also holds the tokens which can not be split evenly
+			seed = walletseed.NewSeed(genesisSeed)
+			tokenAmount += GenesisTokenAmount % uint64(faucets)
+		}
+		snapshot[ledgerstate.GenesisTransactionID][seed.Address(0).Address()] = ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{
+			ledgerstate.ColorIOTA: tokenAmount,
+		})
+		n.faucetSeeds = append(n.faucetSeeds, seed)
+		n.faucetTokenAmounts = append(n.faucetTokenAmounts, tokenAmount)
+	}
+
+	filePath := fmt.Sprintf(faucetsSnapshotFilePath, n.name)
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err = snapshot.WriteTo(f); err != nil {
+		return err
+	}
+
+	n.snapshotFilePath = filePath
+	return nil
+}
+
+// nextFaucetFunds returns the seed and the amount of tokens of the next faucet created in the network.
+func (n *Network) nextFaucetFunds() (*walletseed.Seed, uint64) {
+	faucetIndex := len(n.Faucets())
+	if faucetIndex < len(n.faucetSeeds) {
+		return n.faucetSeeds[faucetIndex], n.faucetTokenAmounts[faucetIndex]
+	}
+	return walletseed.NewSeed(genesisSeed), GenesisTokenAmount
+}
+
 // CreatePeer creates a new peer/GoShimmer node in the network and returns it.
 // Passing bootstrap true enables the bootstrap plugin on the given peer.
 func (n *Network) CreatePeer(c GoShimmerConfig) (*Peer, error) {
-	name := n.namePrefix(fmt.Sprintf("%s%d", containerNameReplica, len(n.peers)))
+	name := n.namePrefix(fmt.Sprintf("%s%d", containerNameReplica, len(n.peers)+len(n.droppedPeers)))
 	config := c
 
 	// create identity
@@ -119,6 +178,9 @@ func (n *Network) CreatePeer(c GoShimmerConfig) (*Peer, error) {
 		return disabledPluginsPeer
 	}()
 	config.SnapshotFilePath = snapshotFilePath
+	if n.snapshotFilePath != "" {
+		config.SnapshotFilePath = n.snapshotFilePath
+	}
 	if config.SyncBeaconFollowNodes == "" {
 		config.SyncBeaconFollowNodes = syncBeaconPublicKey
 	}
@@ -135,7 +197,8 @@ func (n *Network) CreatePeer(c GoShimmerConfig) (*Peer, error) {
 	// create wallet
 	var nodeSeed *walletseed.Seed
 	if c.Faucet == true {
-		nodeSeed = walletseed.NewSeed(genesisSeed)
+		nodeSeed, config.FaucetGenesisTokenAmount = n.nextFaucetFunds()
+		config.FaucetSeed = nodeSeed.String()
 	} else {
 		nodeSeed = walletseed.NewSeed()
 	}
@@ -159,6 +222,7 @@ func (n *Network) CreatePeer(c GoShimmerConfig) (*Peer, error) {
 	if err != nil {
 		return nil, err
 	}
+	peer.faucet = config.Faucet
 	n.peers = append(n.peers, peer)
 	return peer, nil
 }
@@ -184,6 +248,9 @@ func (n *Network) Shutdown() error {
 		return err
 	}
 
+	// the dropped peers are already stopped, but their logs and containers are handled as well
+	peers := append(append([]*Peer{}, n.peers...), n.droppedPeers...)
+
 	// retrieve logs
 	logs, err := n.entryNode.Logs()
 	if err != nil {
@@ -193,7 +260,7 @@ func (n *Network) Shutdown() error {
 	if err != nil {
 		return err
 	}
-	for _, p := range n.peers {
+	for _, p := range peers {
 		logs, err = p.Logs()
 		if err != nil {
 			return err
@@ -205,12 +272,12 @@ func (n *Network) Shutdown() error {
 	}
 
 	// save exit status of containers to check at end of shutdown process
-	exitStatus := make(map[string]int, len(n.peers)+1)
+	exitStatus := make(map[string]int, len(peers)+1)
 	exitStatus[containerNameEntryNode], err = n.entryNode.ExitStatus()
 	if err != nil {
 		return err
 	}
-	for _, p := range n.peers {
+	for _, p := range peers {
 		exitStatus[p.name], err = p.ExitStatus()
 		if err != nil {
 			return err
@@ -222,7 +289,7 @@ func (n *Network) Shutdown() error {
 	if err != nil {
 		return err
 	}
-	for _, p := range n.peers {
+	for _, p := range peers {
 		err = p.Remove()
 		if err != nil {
 			return err
@@ -241,6 +308,14 @@ func (n *Network) Shutdown() error {
 		return err
 	}
 
+	// remove the snapshot created for the network
+	if n.snapshotFilePath != "" {
+		err = os.Remove(n.snapshotFilePath)
+		if err != nil {
+			return err
+		}
+	}
+
 	// check exit codes of containers
 	for name, status := range exitStatus {
 		if status != exitStatusSuccessful {
@@ -293,6 +368,42 @@ func (n *Network) WaitForAutopeering(minimumNeighbors int) error {
 	return fmt.Errorf("autopeering not successful")
 }
 
+// DropPeer stops the given peer, so that the remaining peers drop it as a neighbor and select new ones.
+// The peer is no longer returned by Peers until it is added back with AddPeer.
+func (n *Network) DropPeer(p *Peer) error {
+	for i, peer := range n.peers {
+		if peer != p {
+			continue
+		}
+		if err := p.Stop(); err != nil {
+			return err
+		}
+		p.SetNeighbors(nil, nil)
+		n.peers = append(n.peers[:i], n.peers[i+1:]...)
+		n.droppedPeers = append(n.droppedPeers, p)
+		return nil
+	}
+	return fmt.Errorf("peer %s is not part of the network", p)
+}
+
+// AddPeer starts the given dropped peer again and adds it back to the network. It rejoins with its previous identity,
+// so that the autopeering has to re-select the neighbors once more. Afterwards, WaitForAutopeering should be called
+// to wait until the network has restabilized.
+func (n *Network) AddPeer(p *Peer) error {
+	for i, peer := range n.droppedPeers {
+		if peer != p {
+			continue
+		}
+		if err := p.Start(); err != nil {
+			return err
+		}
+		n.droppedPeers = append(n.droppedPeers[:i], n.droppedPeers[i+1:]...)
+		n.peers = append(n.peers, p)
+		return nil
+	}
+	return fmt.Errorf("peer %s was not dropped from the network", p)
+}
+
 // namePrefix returns the suffix prefixed with the name.
 func (n *Network) namePrefix(suffix string) string {
 	return fmt.Sprintf("%s-%s", n.name, suffix)
@@ -308,6 +419,17 @@ func (n *Network) Peers() []*Peer {
 	return n.peers
 }
 
+// Faucets returns all peers in the network that have the faucet enabled.
+func (n *Network) Faucets() []*Peer {
+	var faucets []*Peer
+	for _, peer := range n.peers {
+		if peer.faucet {
+			faucets = append(faucets, peer)
+		}
+	}
+	return faucets
+}
+
 // RandomPeer returns a random peer out of the list of peers.
 func (n *Network) RandomPeer() *Peer {
 	return n.peers[rand.Intn(len(n.peers))]
@@ -16,6 +16,7 @@ const (
 	disabledPluginsEntryNode = "portcheck,dashboard,analysis-client,profiling,gossip,drng,issuer,syncbeaconfollower,metrics,valuetransfers,consensus,messagelayer,pow,webapi,webapibroadcastdataendpoint,webapifindtransactionhashesendpoint,webapigetneighborsendpoint,webapigettransactionobjectsbyhashendpoint,webapigettransactiontrytesbyhashendpoint,clock"
 	disabledPluginsPeer      = "portcheck,dashboard,analysis-client,profiling,clock"
 	snapshotFilePath         = "/assets/7R1itJx5hVuo9w9hjg5cwKFmek4HMSoBDgJZN8hKGxih.bin"
+	faucetsSnapshotFilePath  = "/assets/%s_faucets.bin"
 	dockerLogsPrefixLen      = 8
 
 	dkgMaxTries = 50
@@ -27,6 +28,8 @@ const (
 
 	// GenesisTokenAmount is the amount of tokens in the genesis output.
 	GenesisTokenAmount = 1000000000000000
+	// MaxFaucets is the maximum number of faucets in a network, as every faucet needs at least 10% of the genesis tokens.
+	MaxFaucets = 10
 )
 
 // Parameters to override before calling any peer creation function.
@@ -65,11 +68,8 @@ var (
 	ParaWriteManaThreshold = 1.0
 )
 
-var (
-	genesisSeed = []byte{95, 76, 224, 164, 168, 80, 141, 174, 133, 77, 153, 100, 4, 202, 113,
-		104, 71, 130, 88, 200, 46, 56, 243, 121, 216, 236, 70, 146, 234, 158, 206, 230}
-	genesisSeedBase58 = "7R1itJx5hVuo9w9hjg5cwKFmek4HMSoBDgJZN8hKGxih"
-)
+var genesisSeed = []byte{95, 76, 224, 164, 168, 80, 141, 174, 133, 77, 153, 100, 4, 202, 113,
+	104, 71, 130, 88, 200, 46, 56, 243, 121, 216, 236, 70, 146, 234, 158, 206, 230}
 
 //GoShimmerConfig defines the config of a GoShimmer node.
 type GoShimmerConfig struct {
@@ -85,7 +85,9 @@ type GoShimmerConfig struct {
 	DRNGInstance  int
 	DRNGThreshold int
 
-	Faucet bool
+	Faucet                   bool
+	FaucetSeed               string
+	FaucetGenesisTokenAmount uint64
 
 	SyncBeacon                  bool
 	SyncBeaconFollower          bool
@@ -115,6 +117,18 @@ type NetworkConfig struct {
 
 // CreateNetworkConfig is the config for optional plugins passed through createNetwork.
 type CreateNetworkConfig struct {
+	// Faucet enables the faucet on the first peer. It is equivalent to Faucets set to 1.
 	Faucet bool
-	Mana   bool
+	// Faucets is the number of peers, starting from the first one, that have the faucet enabled.
+	// If there is more than one faucet, every faucet has its own seed and the genesis tokens are split between them.
+	Faucets int
+	Mana    bool
+}
+
+// faucetCount returns the number of faucet peers defined by the config.
+func (c CreateNetworkConfig) faucetCount() int {
+	if c.Faucets == 0 && c.Faucet {
+		return 1
+	}
+	return c.Faucets
 }
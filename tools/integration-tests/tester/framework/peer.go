@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/iotaledger/hive.go/identity"
+	"github.com/mr-tron/base58"
 
 	"github.com/iotaledger/goshimmer/client"
 	walletseed "github.com/iotaledger/goshimmer/client/wallet/packages/seed"
@@ -31,6 +32,9 @@ type Peer struct {
 
 	chosen   []jsonmodels.Neighbor
 	accepted []jsonmodels.Neighbor
+
+	// whether the faucet is enabled on the peer
+	faucet bool
 }
 
 // newPeer creates a new instance of Peer with the given information.
@@ -56,6 +60,16 @@ func (p *Peer) String() string {
 	return fmt.Sprintf("Peer:{%s, %s, %s, %d}", p.name, p.ID().String(), p.BaseURL(), p.TotalNeighbors())
 }
 
+// GetManaByNodeID returns the access and consensus mana of the node with the given ID as perceived by the peer.
+func (p *Peer) GetManaByNodeID(nodeID identity.ID) (*jsonmodels.GetManaResponse, error) {
+	return p.GoShimmerAPI.GetManaFullNodeID(base58.Encode(nodeID.Bytes()))
+}
+
+// IsFaucet returns whether the faucet is enabled on the peer.
+func (p *Peer) IsFaucet() bool {
+	return p.faucet
+}
+
 // TotalNeighbors returns the total number of neighbors the peer has.
 func (p *Peer) TotalNeighbors() int {
 	return len(p.chosen) + len(p.accepted)
@@ -2,6 +2,7 @@ package autopeering
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -38,3 +39,33 @@ func TestNetworkSplit(t *testing.T) {
 	err = n.WaitForAutopeering(4)
 	require.NoError(t, err)
 }
+
+// TestGossipAfterChurn checks whether messages are still gossiped to all peers after a peer dropped out of the network
+// and rejoined it, which forces the autopeering to re-select the neighbors.
+func TestGossipAfterChurn(t *testing.T) {
+	n, err := f.CreateNetwork("autopeering_TestGossipAfterChurn", 4, 2, framework.CreateNetworkConfig{})
+	require.NoError(t, err)
+	defer tests.ShutdownNetwork(t, n)
+
+	// drop a peer and wait for the remaining ones to re-select their neighbors
+	churnPeer := n.Peers()[len(n.Peers())-1]
+	err = n.DropPeer(churnPeer)
+	require.NoError(t, err)
+	err = n.WaitForAutopeering(2)
+	require.NoError(t, err)
+
+	// let the peer rejoin and wait until the autopeering has restabilized
+	err = n.AddPeer(churnPeer)
+	require.NoError(t, err)
+	err = n.WaitForAutopeering(2)
+	require.NoError(t, err)
+
+	// issue messages, so that the rejoined peer becomes synced again, and wait for them to be gossiped
+	ids := tests.SendDataMessagesOnRandomPeer(t, n.Peers(), 10)
+	time.Sleep(10 * time.Second)
+
+	// a newly issued message propagates to all peers
+	ids = tests.SendDataMessagesOnRandomPeer(t, n.Peers(), 1, ids)
+	time.Sleep(5 * time.Second)
+	tests.CheckForMessageIDs(t, n.Peers(), ids, true)
+}
@@ -0,0 +1,70 @@
+package faucet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/tools/integration-tests/tester/framework"
+	"github.com/iotaledger/goshimmer/tools/integration-tests/tester/tests"
+)
+
+// TestFaucetMana requests funds from the faucet and checks that the mana of the funding transaction is pledged
+// to the requesting node.
+func TestFaucetMana(t *testing.T) {
+	prevPoWDiff := framework.ParaPoWDifficulty
+	framework.ParaPoWDifficulty = 0
+	defer func() {
+		framework.ParaPoWDifficulty = prevPoWDiff
+	}()
+	n, err := f.CreateNetwork("faucet_TestMana", 3, 2, framework.CreateNetworkConfig{Faucet: true, Mana: true})
+	require.NoError(t, err)
+	defer tests.ShutdownNetwork(t, n)
+
+	peers := n.Peers()
+	faucet, requester, bystander := peers[0], peers[1], peers[2]
+
+	// wait for the faucet to prepare its outputs
+	time.Sleep(10 * time.Second)
+
+	addr := requester.Seed.Address(0).Address()
+	tests.SendFaucetRequest(t, requester, addr)
+
+	// wait for the funding transaction to be confirmed
+	require.Eventually(t, func() bool {
+		resp, err := requester.GetUnspentOutputs([]string{addr.Base58()})
+		if err != nil || len(resp.UnspentOutputs) == 0 {
+			return false
+		}
+		for _, output := range resp.UnspentOutputs[0].OutputIDs {
+			if output.InclusionState.Confirmed {
+				return true
+			}
+		}
+		return false
+	}, time.Minute, time.Second)
+
+	// wait for the mana to be booked on all peers
+	time.Sleep(5 * time.Second)
+
+	for _, peer := range peers {
+		// the funding transaction pledges access and consensus mana to the requester
+		resp, err := peer.GetManaByNodeID(requester.ID())
+		require.NoErrorf(t, err, "could not get mana on %s", peer)
+		assert.Greaterf(t, resp.Access, 0.0, "no access mana pledged to the requester (peer='%s')", peer)
+		assert.Greaterf(t, resp.Consensus, 0.0, "no consensus mana pledged to the requester (peer='%s')", peer)
+
+		// the faucet keeps the access mana pledged by its own preparation transactions
+		resp, err = peer.GetManaByNodeID(faucet.ID())
+		require.NoErrorf(t, err, "could not get mana on %s", peer)
+		assert.Greaterf(t, resp.Access, 0.0, "no access mana pledged to the faucet (peer='%s')", peer)
+	}
+
+	// neither the faucet preparation nor the funding transaction pledges any mana to an uninvolved node
+	tests.CheckManaPledge(t, peers, map[identity.ID]tests.ExpectedManaPledge{
+		bystander.ID(): {Access: 0, Consensus: 0, Delta: 0},
+	})
+}
@@ -0,0 +1,89 @@
+package faucet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/iotaledger/goshimmer/tools/integration-tests/tester/framework"
+	"github.com/iotaledger/goshimmer/tools/integration-tests/tester/tests"
+)
+
+// TestMultipleFaucets creates a network with two faucets, each holding half of the genesis tokens on its own seed,
+// and sends a faucet request on each of them.
+func TestMultipleFaucets(t *testing.T) {
+	prevPoWDiff := framework.ParaPoWDifficulty
+	framework.ParaPoWDifficulty = 0
+	defer func() {
+		framework.ParaPoWDifficulty = prevPoWDiff
+	}()
+	n, err := f.CreateNetwork("faucet_TestMultipleFaucets", 3, 2, framework.CreateNetworkConfig{Faucets: 2})
+	require.NoError(t, err)
+	defer tests.ShutdownNetwork(t, n)
+
+	faucets := n.Faucets()
+	require.Len(t, faucets, 2)
+	assert.True(t, faucets[0].IsFaucet())
+	assert.True(t, faucets[1].IsFaucet())
+	assert.False(t, n.Peers()[2].IsFaucet())
+	assert.NotEqual(t, faucets[0].Seed.String(), faucets[1].Seed.String())
+
+	// wait for peers to change their state to synchronized
+	time.Sleep(5 * time.Second)
+
+	ids := make(map[string]tests.DataMessageSent)
+	var addrs []string
+	for i := range faucets {
+		addr := n.Peers()[2].Seed.Address(uint64(i)).Address()
+		id, sent := tests.SendFaucetRequestOnFaucet(t, n, i, addr)
+		ids[id] = sent
+		addrs = append(addrs, addr.Base58())
+	}
+
+	// wait for messages to be gossiped
+	time.Sleep(2 * messagelayer.DefaultAverageNetworkDelay)
+
+	// check whether the requests sent on both faucets are available on all nodes
+	tests.CheckForMessageIDs(t, n.Peers(), ids, true)
+
+	// every faucet fulfills every request from its own funds, so each address receives the tokens of all faucets
+	expectedBalance := int64(len(faucets)) * framework.ParaFaucetTokensPerRequest
+	for _, peer := range n.Peers() {
+		for _, addr := range addrs {
+			assert.Eventuallyf(t, func() bool {
+				balance, err := iotaBalance(peer, addr)
+				return err == nil && balance == expectedBalance
+			}, time.Minute, time.Second, "address %s did not receive %d tokens on %s", addr, expectedBalance, peer)
+		}
+	}
+
+	// every faucet prepared its funding outputs from its share of the genesis tokens
+	expectedRemainder := int64(framework.GenesisTokenAmount/len(faucets) - framework.ParaFaucetPreparedOutputsCount*int(framework.ParaFaucetTokensPerRequest))
+	for _, faucetPeer := range faucets {
+		balance, err := iotaBalance(faucetPeer, faucetPeer.Seed.Address(0).Address().Base58())
+		require.NoError(t, err)
+		assert.Equalf(t, expectedRemainder, balance, "unexpected remainder of %s", faucetPeer)
+	}
+}
+
+// iotaBalance returns the IOTA balance of the unspent outputs on the given address as seen by the peer.
+func iotaBalance(peer *framework.Peer, addr string) (balance int64, err error) {
+	resp, err := peer.GetUnspentOutputs([]string{addr})
+	if err != nil {
+		return 0, err
+	}
+	for _, unspent := range resp.UnspentOutputs {
+		for _, output := range unspent.OutputIDs {
+			for _, b := range output.Balances {
+				if b.Color == ledgerstate.ColorIOTA.String() {
+					balance += b.Value
+				}
+			}
+		}
+	}
+	return balance, nil
+}
@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -116,6 +117,14 @@ func SendFaucetRequest(t *testing.T, peer *framework.Peer, addr ledgerstate.Addr
 	return resp.ID, sent
 }
 
+// SendFaucetRequestOnFaucet sends a faucet request for the given address on the faucet peer with the given index
+// and returns the id and a DataMessageSent struct.
+func SendFaucetRequestOnFaucet(t *testing.T, network *framework.Network, faucetIndex int, addr ledgerstate.Address) (string, DataMessageSent) {
+	faucets := network.Faucets()
+	require.Lessf(t, faucetIndex, len(faucets), "network has only %d faucets", len(faucets))
+	return SendFaucetRequest(t, faucets[faucetIndex], addr)
+}
+
 // CheckForMessageIDs performs checks to make sure that all peers received all given messages defined in ids.
 func CheckForMessageIDs(t *testing.T, peers []*framework.Peer, messageIDs map[string]DataMessageSent, checkSynchronized bool) {
 	for _, peer := range peers {
@@ -219,6 +228,51 @@ func SendTransactionOnRandomPeer(t *testing.T, peers []*framework.Peer, addrBala
 	return
 }
 
+// SpamTransactions issues count value transactions on the given peer at a rate of tps transactions per second and
+// returns their IDs. Every transaction sends the whole balance of the peer's address 0 back to itself, thus the ledger
+// state is not changed. It returns early, if ctx is canceled or the transactions can't be issued.
+func SpamTransactions(ctx context.Context, t *testing.T, peer *framework.Peer, count int, tps float64) (txIds []string) {
+	require.Greater(t, tps, 0.0, "tps must be positive")
+
+	addr := peer.Seed.Address(0).Address().Base58()
+	addrBalance := map[string]map[ledgerstate.Color]int64{addr: {}}
+
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / tps))
+	defer ticker.Stop()
+
+	counter := 0
+	var lastSpentOutputID string
+	for len(txIds) < count {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// the output spent by the previous transaction must be gone, otherwise a double spend would be issued
+		resp, err := peer.GetUnspentOutputs([]string{addr})
+		require.NoErrorf(t, err, "could not get unspent outputs on %s", peer.String())
+		var fail bool
+		if outputIDs := resp.UnspentOutputs[0].OutputIDs; len(outputIDs) == 0 || outputIDs[0].ID == lastSpentOutputID {
+			fail = true
+		} else {
+			var txId string
+			if fail, txId = SendIotaTransaction(t, peer, peer, addrBalance, 0, TransactionConfig{}); !fail {
+				lastSpentOutputID = outputIDs[0].ID
+				txIds = append(txIds, txId)
+			}
+		}
+		if fail {
+			counter++
+			if counter >= maxRetry {
+				return
+			}
+		}
+	}
+
+	return
+}
+
 // SendIotaTransaction sends sentValue amount of IOTA tokens and remainders from and to a given peer and returns the fail flag and the transaction ID.
 // Every peer sends and receives the transaction on the address of index 0.
 // Optionally, the nodes to pledge access and consensus mana can be specified.
@@ -378,7 +432,14 @@ func getColorFromString(colorStr string) (color ledgerstate.Color) {
 }
 
 // CheckBalances performs checks to make sure that all peers have the same ledger state.
-func CheckBalances(t *testing.T, peers []*framework.Peer, addrBalance map[string]map[ledgerstate.Color]int64) {
+// Optionally takes CheckOptions to poll the peers until the expected balances are reached.
+func CheckBalances(t *testing.T, peers []*framework.Peer, addrBalance map[string]map[ledgerstate.Color]int64, opts ...CheckOptions) {
+	poll(t, checkOptions(opts), func(t require.TestingT) {
+		checkBalances(t, peers, addrBalance)
+	})
+}
+
+func checkBalances(t require.TestingT, peers []*framework.Peer, addrBalance map[string]map[ledgerstate.Color]int64) {
 	for _, peer := range peers {
 		for addr, b := range addrBalance {
 			sum := make(map[ledgerstate.Color]int64)
@@ -414,6 +475,29 @@ func CheckAddressOutputsFullyConsumed(t *testing.T, peers []*framework.Peer, add
 	}
 }
 
+// ExpectedManaPledge defines the access and consensus mana expected to be pledged to a node.
+type ExpectedManaPledge struct {
+	// The expected access mana of the node.
+	Access float64
+	// The expected consensus mana of the node.
+	Consensus float64
+	// The allowed absolute deviation of the mana values, e.g. due to the time passed since the pledge.
+	Delta float64
+}
+
+// CheckManaPledge performs checks to make sure that all peers perceive the expected access and consensus mana
+// pledged to the given nodes.
+func CheckManaPledge(t *testing.T, peers []*framework.Peer, expectedPledges map[identity.ID]ExpectedManaPledge) {
+	for _, peer := range peers {
+		for nodeID, expected := range expectedPledges {
+			resp, err := peer.GetManaByNodeID(nodeID)
+			require.NoErrorf(t, err, "could not get mana of node '%s' on %s", nodeID, peer)
+			assert.InDeltaf(t, expected.Access, resp.Access, expected.Delta, "access mana of node '%s' (peer='%s') does not match", nodeID, peer)
+			assert.InDeltaf(t, expected.Consensus, resp.Consensus, expected.Delta, "consensus mana of node '%s' (peer='%s') does not match", nodeID, peer)
+		}
+	}
+}
+
 // ExpectedInclusionState is an expected inclusion state.
 // All fields are optional.
 type ExpectedInclusionState struct {
@@ -458,8 +542,14 @@ type ExpectedTransaction struct {
 
 // CheckTransactions performs checks to make sure that all peers have received all transactions.
 // Optionally takes an expected inclusion state for all supplied transaction IDs and expected transaction
-// data per transaction ID.
-func CheckTransactions(t *testing.T, peers []*framework.Peer, transactionIDs map[string]*ExpectedTransaction, checkSynchronized bool, expectedInclusionState ExpectedInclusionState) {
+// data per transaction ID, as well as CheckOptions to poll the peers until the expected state is reached.
+func CheckTransactions(t *testing.T, peers []*framework.Peer, transactionIDs map[string]*ExpectedTransaction, checkSynchronized bool, expectedInclusionState ExpectedInclusionState, opts ...CheckOptions) {
+	poll(t, checkOptions(opts), func(t require.TestingT) {
+		checkTransactions(t, peers, transactionIDs, checkSynchronized, expectedInclusionState)
+	})
+}
+
+func checkTransactions(t require.TestingT, peers []*framework.Peer, transactionIDs map[string]*ExpectedTransaction, checkSynchronized bool, expectedInclusionState ExpectedInclusionState) {
 	for _, peer := range peers {
 		if checkSynchronized {
 			// check that the peer sees itself as synchronized
@@ -635,3 +725,68 @@ func SelectIndex(transaction *ledgerstate.Transaction, address ledgerstate.Addre
 	}
 	return
 }
+
+// defaultPollInterval is the poll interval used if CheckOptions has a timeout but no poll interval.
+const defaultPollInterval = 500 * time.Millisecond
+
+// CheckOptions defines how the checks poll the peers until the expected state is reached.
+// The zero value checks only once without polling.
+type CheckOptions struct {
+	// PollInterval is the time to wait between two checks. It defaults to 500ms.
+	PollInterval time.Duration
+	// Timeout is the overall time after which the last check is performed and its failures are reported.
+	Timeout time.Duration
+}
+
+// checkOptions returns the first of the given options, or the zero value if none is given.
+func checkOptions(opts []CheckOptions) CheckOptions {
+	if len(opts) == 0 {
+		return CheckOptions{}
+	}
+	options := opts[0]
+	if options.PollInterval <= 0 {
+		options.PollInterval = defaultPollInterval
+	}
+	return options
+}
+
+// errCheckFailed is used to abort a check on a failed requirement while polling.
+var errCheckFailed = errors.New("check failed")
+
+// pollingT records whether a check failed, without reporting the failure.
+type pollingT struct {
+	failed bool
+}
+
+func (p *pollingT) Errorf(string, ...interface{}) {
+	p.failed = true
+}
+
+func (p *pollingT) FailNow() {
+	p.failed = true
+	panic(errCheckFailed)
+}
+
+// poll runs check until it passes or the timeout of the options is reached.
+// Only the failures of the last run are reported to t.
+func poll(t require.TestingT, options CheckOptions, check func(t require.TestingT)) {
+	for deadline := time.Now().Add(options.Timeout); time.Now().Before(deadline); time.Sleep(options.PollInterval) {
+		if tryCheck(check) {
+			return
+		}
+	}
+	check(t)
+}
+
+// tryCheck runs check and returns whether it passed.
+func tryCheck(check func(t require.TestingT)) (passed bool) {
+	p := &pollingT{}
+	defer func() {
+		if r := recover(); r != nil && r != errCheckFailed {
+			panic(r)
+		}
+		passed = !p.failed
+	}()
+	check(p)
+	return
+}
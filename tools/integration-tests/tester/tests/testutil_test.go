@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/client"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/plugins/webapi/jsonmodels/value"
+	"github.com/iotaledger/goshimmer/tools/integration-tests/tester/framework"
+)
+
+// newFakePeer returns a peer whose web API answers unspent output requests with the balance returned by balanceFunc
+// for the given call. It also returns the number of calls made so far.
+func newFakePeer(t *testing.T, balanceFunc func(call int32) int64) (*framework.Peer, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req value.UnspentOutputsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		balance := balanceFunc(atomic.AddInt32(&calls, 1))
+		_ = json.NewEncoder(w).Encode(&value.UnspentOutputsResponse{
+			UnspentOutputs: []value.UnspentOutput{{
+				Address: req.Addresses[0],
+				OutputIDs: []value.OutputID{{
+					Balances: []value.Balance{{Color: "IOTA", Value: balance}},
+				}},
+			}},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return &framework.Peer{
+		Identity:     identity.GenerateIdentity(),
+		GoShimmerAPI: client.NewGoShimmerAPI(server.URL),
+	}, &calls
+}
+
+func TestCheckBalancesPolling(t *testing.T) {
+	const addr = "address"
+	expected := map[string]map[ledgerstate.Color]int64{addr: {ledgerstate.ColorIOTA: 100}}
+	// the balance is only reached with the third request
+	balanceFunc := func(call int32) int64 {
+		if call < 3 {
+			return 0
+		}
+		return 100
+	}
+
+	t.Run("polls until reached", func(t *testing.T) {
+		peer, calls := newFakePeer(t, balanceFunc)
+		CheckBalances(t, []*framework.Peer{peer}, expected, CheckOptions{PollInterval: time.Millisecond, Timeout: time.Minute})
+		assert.EqualValues(t, 3, atomic.LoadInt32(calls))
+	})
+
+	t.Run("checks once by default", func(t *testing.T) {
+		peer, calls := newFakePeer(t, balanceFunc)
+		assert.False(t, tryCheck(func(t require.TestingT) {
+			poll(t, checkOptions(nil), func(t require.TestingT) {
+				checkBalances(t, []*framework.Peer{peer}, expected)
+			})
+		}))
+		assert.EqualValues(t, 1, atomic.LoadInt32(calls))
+	})
+
+	t.Run("fails after timeout", func(t *testing.T) {
+		peer, calls := newFakePeer(t, func(int32) int64 { return 0 })
+		options := CheckOptions{PollInterval: time.Millisecond, Timeout: 50 * time.Millisecond}
+		assert.False(t, tryCheck(func(t require.TestingT) {
+			poll(t, checkOptions([]CheckOptions{options}), func(t require.TestingT) {
+				checkBalances(t, []*framework.Peer{peer}, expected)
+			})
+		}))
+		assert.Greater(t, atomic.LoadInt32(calls), int32(2))
+	})
+}
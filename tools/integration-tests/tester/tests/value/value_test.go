@@ -1,6 +1,7 @@
 package value
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -146,3 +147,43 @@ func TestValueColoredPersistence(t *testing.T) {
 	// 5. check ledger state
 	tests.CheckBalances(t, n.Peers(), addrBalance)
 }
+
+// TestSpamTransactions issues a few transactions at a fixed rate and checks that they are all confirmed.
+func TestSpamTransactions(t *testing.T) {
+	n, err := f.CreateNetwork("value_TestSpamTransactions", 4, 2, framework.CreateNetworkConfig{Faucet: true})
+	require.NoError(t, err)
+	defer tests.ShutdownNetwork(t, n)
+
+	// wait for peers to change their state to synchronized
+	time.Sleep(5 * time.Second)
+
+	// master node sends funds to all peers in the network
+	txIdsSlice, addrBalance := tests.SendTransactionFromFaucet(t, n.Peers(), 100)
+	txIds := make(map[string]*tests.ExpectedTransaction)
+	for _, txID := range txIdsSlice {
+		txIds[txID] = nil
+	}
+
+	// wait for messages to be gossiped
+	time.Sleep(2 * messagelayer.DefaultAverageNetworkDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	const count = 5
+	spamTxIds := tests.SpamTransactions(ctx, t, n.Peers()[1], count, 1)
+	require.Len(t, spamTxIds, count)
+	for _, txID := range spamTxIds {
+		txIds[txID] = nil
+	}
+
+	// wait for messages to be gossiped
+	time.Sleep(2 * messagelayer.DefaultAverageNetworkDelay)
+
+	// check whether all issued transactions are available on all nodes and confirmed
+	tests.CheckTransactions(t, n.Peers(), txIds, true, tests.ExpectedInclusionState{
+		Confirmed: tests.True(),
+	})
+
+	// the spammed transactions don't change the ledger state
+	tests.CheckBalances(t, n.Peers(), addrBalance)
+}